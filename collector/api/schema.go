@@ -0,0 +1,10 @@
+// Package api embeds the collector's published contract artifacts.
+package api
+
+import _ "embed"
+
+// LogicalTopologySnapshotSchema is the JSON Schema for snapshot.LogicalTopologySnapshot. Its
+// x-schema-version keyword matches snapshot.SchemaVersion.
+//
+//go:embed logical-topology-snapshot.schema.json
+var LogicalTopologySnapshotSchema []byte
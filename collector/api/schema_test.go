@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// TestSchemaMatchesSnapshotTypes keeps the hand-maintained schema in sync with the Go types:
+// every JSON field must be a schema property, and exactly the fields without omitempty must be
+// required.
+func TestSchemaMatchesSnapshotTypes(t *testing.T) {
+	var schema map[string]any
+	if err := json.Unmarshal(LogicalTopologySnapshotSchema, &schema); err != nil {
+		t.Fatalf("embedded schema is not valid JSON: %v", err)
+	}
+	if got := schema["x-schema-version"]; got != snapshot.SchemaVersion {
+		t.Fatalf("expected x-schema-version %q, got %v", snapshot.SchemaVersion, got)
+	}
+	compareSchema(t, "LogicalTopologySnapshot", reflect.TypeOf(snapshot.LogicalTopologySnapshot{}), schema)
+}
+
+func compareSchema(t *testing.T, path string, typ reflect.Type, schema map[string]any) {
+	t.Helper()
+
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ == reflect.TypeOf(time.Time{}):
+		if schema["type"] != "string" || schema["format"] != "date-time" {
+			t.Errorf("%s: expected a date-time string, got %v", path, schema)
+		}
+	case typ.Kind() == reflect.Struct:
+		if schema["type"] != "object" {
+			t.Errorf("%s: expected type object, got %v", path, schema["type"])
+			return
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		wantRequired := []string{}
+		fields := map[string]bool{}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			name, options, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fields[name] = true
+			if !strings.Contains(options, "omitempty") {
+				wantRequired = append(wantRequired, name)
+			}
+			property, ok := properties[name].(map[string]any)
+			if !ok {
+				t.Errorf("%s: field %q is missing from the schema", path, name)
+				continue
+			}
+			compareSchema(t, path+"."+name, field.Type, property)
+		}
+		for name := range properties {
+			if !fields[name] {
+				t.Errorf("%s: schema property %q has no Go field", path, name)
+			}
+		}
+		gotRequired := []string{}
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				gotRequired = append(gotRequired, name.(string))
+			}
+		}
+		slices.Sort(wantRequired)
+		slices.Sort(gotRequired)
+		if !slices.Equal(gotRequired, wantRequired) {
+			t.Errorf("%s: expected required %v, got %v", path, wantRequired, gotRequired)
+		}
+	case typ.Kind() == reflect.Slice:
+		items, ok := schema["items"].(map[string]any)
+		if schema["type"] != "array" || !ok {
+			t.Errorf("%s: expected an array with items, got %v", path, schema)
+			return
+		}
+		compareSchema(t, path+"[]", typ.Elem(), items)
+	case typ.Kind() == reflect.Map:
+		if schema["type"] != "object" {
+			t.Errorf("%s: expected type object for map, got %v", path, schema["type"])
+		}
+	case typ.Kind() == reflect.String:
+		if schema["type"] != "string" {
+			t.Errorf("%s: expected type string, got %v", path, schema["type"])
+		}
+	case typ.Kind() == reflect.Int:
+		if schema["type"] != "integer" {
+			t.Errorf("%s: expected type integer, got %v", path, schema["type"])
+		}
+	}
+}
@@ -1,59 +1,280 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"os"
+	"os/exec"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/dlbewley/ovn-recon/collector/internal/probe"
 	"github.com/dlbewley/ovn-recon/collector/internal/server"
 	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 )
 
 func main() {
 	port := envOrDefault("PORT", "8090")
-	snapshotDir := envOrDefault("SNAPSHOT_DIR", "./fixtures/snapshots")
+	snapshotDirs := parseSnapshotDirs(os.Getenv("SNAPSHOT_DIRS"), envOrDefault("SNAPSHOT_DIR", "./fixtures/snapshots"))
 	targetNamespaces := parseCSV(envOrDefault("COLLECTOR_TARGET_NAMESPACES", "openshift-ovn-kubernetes,openshift-frr-k8s"))
 	logLevel := parseLogLevel(envOrDefault("COLLECTOR_LOG_LEVEL", "info"))
+	logFormat, logFormatErr := parseLogFormat(envOrDefault("COLLECTOR_LOG_FORMAT", logFormatJSON))
 	includeProbeOutput := parseBool(envOrDefault("COLLECTOR_INCLUDE_PROBE_OUTPUT", "false"))
+	includePhysical := parseBool(envOrDefault("COLLECTOR_INCLUDE_PHYSICAL", "false"))
+	detectCycles := parseBool(envOrDefault("COLLECTOR_DETECT_CYCLES", "false"))
+	columnAliases, columnAliasesErr := parseColumnAliases(os.Getenv("COLLECTOR_COLUMN_ALIASES"))
+	nbctlArgs := strings.Fields(os.Getenv("COLLECTOR_NBCTL_EXTRA_ARGS"))
+	runnerMode, runnerModeErr := parseRunnerMode(envOrDefault("COLLECTOR_RUNNER", runnerKubernetes))
+	nbctlPath := strings.TrimSpace(os.Getenv("COLLECTOR_NBCTL_PATH"))
+	dumpDir := strings.TrimSpace(os.Getenv("COLLECTOR_DUMP_DIR"))
+	probeContainers := parseCSV(envOrDefault("COLLECTOR_PROBE_CONTAINERS", strings.Join(probe.DefaultProbeContainers, ",")))
+	podSelector, podSelectorErr := parseLabelSelector(os.Getenv("COLLECTOR_POD_SELECTOR"))
+	clusterID := strings.TrimSpace(os.Getenv("COLLECTOR_CLUSTER_ID"))
+	defaultNode := strings.TrimSpace(os.Getenv("COLLECTOR_DEFAULT_NODE"))
+	nodeMatch, nodeMatchErr := snapshot.ParseNodeMatch(os.Getenv("COLLECTOR_NODE_MATCH"))
+	snapshotHistoryDir := strings.TrimSpace(os.Getenv("COLLECTOR_SNAPSHOT_HISTORY_DIR"))
+	retentionCount, retentionCountErr := parseNonNegativeInt(envOrDefault("COLLECTOR_SNAPSHOT_RETENTION_COUNT", "0"))
+	retentionAge, retentionAgeErr := parseDuration(envOrDefault("COLLECTOR_SNAPSHOT_RETENTION_AGE", "0s"))
+	authToken := strings.TrimSpace(os.Getenv("COLLECTOR_AUTH_TOKEN"))
+	probeTimeout, probeTimeoutErr := parseDuration(envOrDefault("COLLECTOR_PROBE_TIMEOUT", probe.DefaultProbeTimeout.String()))
+	execMaxRetries, execMaxRetriesErr := parseNonNegativeInt(envOrDefault("COLLECTOR_EXEC_MAX_RETRIES", strconv.Itoa(probe.DefaultExecMaxRetries)))
+	execRetryDelay, execRetryDelayErr := parseDuration(envOrDefault("COLLECTOR_EXEC_RETRY_BASE_DELAY", probe.DefaultExecRetryBaseDelay.String()))
+	snapshotCacheTTL, snapshotCacheTTLErr := parseDuration(envOrDefault("COLLECTOR_SNAPSHOT_CACHE_TTL", "0s"))
+	maxSnapshotBytes, maxSnapshotBytesErr := parseNonNegativeInt(envOrDefault("COLLECTOR_MAX_SNAPSHOT_BYTES", "0"))
+	maxNodes, maxNodesErr := parseNonNegativeInt(envOrDefault("COLLECTOR_MAX_NODES", "0"))
+	maxEdges, maxEdgesErr := parseNonNegativeInt(envOrDefault("COLLECTOR_MAX_EDGES", "0"))
+	fanoutConcurrency, fanoutConcurrencyErr := parseNonNegativeInt(envOrDefault("COLLECTOR_FANOUT_CONCURRENCY", strconv.Itoa(server.DefaultFanoutConcurrency)))
+	rateLimitRPS, rateLimitRPSErr := parseNonNegativeFloat(envOrDefault("COLLECTOR_RATE_LIMIT_RPS", "0"))
+	rateLimitBurst, rateLimitBurstErr := parseNonNegativeInt(envOrDefault("COLLECTOR_RATE_LIMIT_BURST", "0"))
+	rateLimitKeyHeader := strings.TrimSpace(os.Getenv("COLLECTOR_RATE_LIMIT_KEY_HEADER"))
+	basePath := strings.TrimSpace(os.Getenv("COLLECTOR_BASE_PATH"))
+	tlsCertFile := strings.TrimSpace(os.Getenv("COLLECTOR_TLS_CERT_FILE"))
+	tlsKeyFile := strings.TrimSpace(os.Getenv("COLLECTOR_TLS_KEY_FILE"))
+	tlsClientCAFile := strings.TrimSpace(os.Getenv("COLLECTOR_TLS_CLIENT_CA_FILE"))
+	enablePprof := parseBool(envOrDefault("COLLECTOR_ENABLE_PPROF", "false"))
+	pprofPort := strings.TrimSpace(os.Getenv("COLLECTOR_PPROF_PORT"))
+	upstreamURL := strings.TrimSpace(os.Getenv("COLLECTOR_UPSTREAM_URL"))
+	upstreamToken := strings.TrimSpace(os.Getenv("COLLECTOR_UPSTREAM_TOKEN"))
 
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+	logger := slog.New(newLogHandler(os.Stdout, logFormat, logLevel))
 	slog.SetDefault(logger)
+	if logFormatErr != nil {
+		logger.Warn("invalid COLLECTOR_LOG_FORMAT; using default", "default", logFormatJSON, "error", logFormatErr)
+	}
+	if probeTimeoutErr != nil {
+		logger.Warn("invalid COLLECTOR_PROBE_TIMEOUT; using default", "default", probe.DefaultProbeTimeout.String(), "error", probeTimeoutErr)
+		probeTimeout = probe.DefaultProbeTimeout
+	}
+	if execMaxRetriesErr != nil {
+		logger.Warn("invalid COLLECTOR_EXEC_MAX_RETRIES; using default", "default", probe.DefaultExecMaxRetries, "error", execMaxRetriesErr)
+		execMaxRetries = probe.DefaultExecMaxRetries
+	}
+	if execRetryDelayErr != nil {
+		logger.Warn("invalid COLLECTOR_EXEC_RETRY_BASE_DELAY; using default", "default", probe.DefaultExecRetryBaseDelay.String(), "error", execRetryDelayErr)
+		execRetryDelay = probe.DefaultExecRetryBaseDelay
+	}
+	if runnerModeErr != nil {
+		logger.Warn("invalid COLLECTOR_RUNNER; using default", "default", runnerKubernetes, "error", runnerModeErr)
+	}
+	if podSelectorErr != nil {
+		logger.Warn("invalid COLLECTOR_POD_SELECTOR; probing every running pod", "error", podSelectorErr)
+	}
+	if snapshotCacheTTLErr != nil {
+		logger.Warn("invalid COLLECTOR_SNAPSHOT_CACHE_TTL; live snapshot caching disabled", "error", snapshotCacheTTLErr)
+	}
+	if columnAliasesErr != nil {
+		logger.Warn("invalid COLLECTOR_COLUMN_ALIASES; using current OVN column headings", "error", columnAliasesErr)
+	}
+	if nodeMatchErr != nil {
+		logger.Warn("invalid COLLECTOR_NODE_MATCH; using exact node names", "error", nodeMatchErr)
+	}
+	if retentionCountErr != nil {
+		logger.Warn("invalid COLLECTOR_SNAPSHOT_RETENTION_COUNT; snapshot history count limit disabled", "error", retentionCountErr)
+	}
+	if retentionAgeErr != nil {
+		logger.Warn("invalid COLLECTOR_SNAPSHOT_RETENTION_AGE; snapshot history age limit disabled", "error", retentionAgeErr)
+	}
+	retention := snapshot.Retention{MaxCount: retentionCount, MaxAge: retentionAge}
+	if retention.Enabled() && snapshotHistoryDir == "" {
+		logger.Warn("snapshot retention has no effect without COLLECTOR_SNAPSHOT_HISTORY_DIR")
+	}
+	if maxSnapshotBytesErr != nil {
+		logger.Warn("invalid COLLECTOR_MAX_SNAPSHOT_BYTES; snapshot size limit disabled", "error", maxSnapshotBytesErr)
+	}
+	if maxNodesErr != nil {
+		logger.Warn("invalid COLLECTOR_MAX_NODES; snapshot node limit disabled", "error", maxNodesErr)
+	}
+	if maxEdgesErr != nil {
+		logger.Warn("invalid COLLECTOR_MAX_EDGES; snapshot edge limit disabled", "error", maxEdgesErr)
+	}
+	graphLimits := probe.GraphLimits{MaxNodes: maxNodes, MaxEdges: maxEdges}
+	if fanoutConcurrencyErr != nil || fanoutConcurrency == 0 {
+		logger.Warn("invalid COLLECTOR_FANOUT_CONCURRENCY; using default", "default", server.DefaultFanoutConcurrency, "error", fanoutConcurrencyErr)
+		fanoutConcurrency = server.DefaultFanoutConcurrency
+	}
+	if rateLimitRPSErr != nil {
+		logger.Warn("invalid COLLECTOR_RATE_LIMIT_RPS; client rate limiting disabled", "error", rateLimitRPSErr)
+	}
+	if rateLimitBurstErr != nil {
+		logger.Warn("invalid COLLECTOR_RATE_LIMIT_BURST; using the rate rounded up", "error", rateLimitBurstErr)
+	}
+	shutdownTracing, err := setupTracing(context.Background(), logger)
+	if err != nil {
+		logger.Warn("opentelemetry tracing disabled", "error", err)
+		shutdownTracing = func(context.Context) error { return nil }
+	}
 	probe.SetDefaultCollectOptions(probe.CollectOptions{
 		Logger:             logger.With("component", "probe"),
 		IncludeProbeOutput: includeProbeOutput,
+		IncludePhysical:    includePhysical,
+		DetectCycles:       detectCycles,
+		ColumnAliases:      columnAliases,
+		NbctlArgs:          nbctlArgs,
+		Limits:             graphLimits,
 	})
 
-	store := snapshot.NewFileStore(snapshotDir, "default.json")
+	store := snapshot.NewLayeredFileStore(snapshotDirs, "default.json", snapshot.FileStoreOptions{
+		NodeMatch:  nodeMatch,
+		HistoryDir: snapshotHistoryDir,
+		Retention:  retention,
+	})
+	if upstreamURL != "" {
+		httpStore, err := snapshot.NewHTTPStore(upstreamURL)
+		if err != nil {
+			logger.Error("invalid COLLECTOR_UPSTREAM_URL", "error", err)
+			os.Exit(1)
+		}
+		httpStore.SetBearerToken(upstreamToken)
+		store = httpStore
+	} else if upstreamToken != "" {
+		logger.Warn("COLLECTOR_UPSTREAM_TOKEN has no effect without COLLECTOR_UPSTREAM_URL")
+	}
 	srv := server.New(store)
-	liveCollector, err := buildLiveCollector(targetNamespaces, logger, includeProbeOutput)
-	if err != nil {
-		logger.Warn("live OVN probing disabled; serving file snapshots only", "error", err)
+	if upstreamURL != "" {
+		// A replica only proxies the upstream collector; it never probes OVN itself.
+		logger.Info("serving snapshots read-only from upstream collector; live OVN probing disabled", "upstreamURL", upstreamURL)
 	} else {
-		srv = server.NewWithLiveCollector(store, liveCollector)
-		logger.Info("live OVN probing enabled", "targetNamespaces", targetNamespaces)
+		var liveCollector *probe.SnapshotCollector
+		switch runnerMode {
+		case runnerLocal:
+			liveCollector, err = buildLocalCollector(nbctlPath, logger, includeProbeOutput)
+		case runnerDump:
+			liveCollector, err = buildDumpCollector(dumpDir, logger, includeProbeOutput)
+		default:
+			liveCollector, err = buildLiveCollector(targetNamespaces, probeContainers, podSelector, execMaxRetries, execRetryDelay, logger, includeProbeOutput)
+		}
+		if err != nil {
+			logger.Warn("live OVN probing disabled; serving file snapshots only", "error", err)
+		} else {
+			liveCollector.SetIncludePhysical(includePhysical)
+			liveCollector.SetDetectCycles(detectCycles)
+			liveCollector.SetColumnAliases(columnAliases)
+			liveCollector.SetNbctlArgs(nbctlArgs)
+			liveCollector.SetTimeout(probeTimeout)
+			liveCollector.SetGraphLimits(graphLimits)
+			srv = server.NewWithLiveCollector(store, liveCollector)
+			logger.Info("live OVN probing enabled", "runner", runnerMode, "targetNamespaces", targetNamespaces)
+		}
+	}
+	srv.SetClusterID(clusterID)
+	srv.SetDefaultNode(defaultNode)
+	srv.SetSnapshotCacheTTL(snapshotCacheTTL)
+	srv.SetMaxSnapshotBytes(maxSnapshotBytes)
+	srv.SetFanoutConcurrency(fanoutConcurrency)
+	srv.SetAuthToken(authToken)
+	srv.SetRateLimit(rateLimitRPS, rateLimitBurst, rateLimitKeyHeader)
+	srv.SetBasePath(basePath)
+	if pprofPort != "" && !enablePprof {
+		logger.Warn("COLLECTOR_PPROF_PORT has no effect without COLLECTOR_ENABLE_PPROF=true")
 	}
+	srv.SetPprofEnabled(enablePprof && pprofPort == "")
 	addr := ":" + port
+	tlsEnabled := tlsCertFile != "" && tlsKeyFile != ""
+	if !tlsEnabled && (tlsCertFile != "" || tlsKeyFile != "" || tlsClientCAFile != "") {
+		logger.Error("COLLECTOR_TLS_CERT_FILE and COLLECTOR_TLS_KEY_FILE must both be set to enable TLS")
+		os.Exit(1)
+	}
+	httpServer := &http.Server{Addr: addr, Handler: srv.Handler()}
+	if tlsEnabled {
+		tlsConfig, err := collectorTLSConfig(tlsClientCAFile)
+		if err != nil {
+			logger.Error("invalid collector TLS configuration", "error", err)
+			os.Exit(1)
+		}
+		httpServer.TLSConfig = tlsConfig
+	}
 
 	logger.Info("starting ovn-collector",
 		"addr", addr,
-		"snapshotDir", snapshotDir,
+		"snapshotDirs", snapshotDirs,
+		"snapshotHistoryDir", snapshotHistoryDir,
+		"snapshotRetentionCount", retention.MaxCount,
+		"snapshotRetentionAge", retention.MaxAge.String(),
+		"runner", runnerMode,
+		"nbctlPath", nbctlPath,
+		"dumpDir", dumpDir,
 		"targetNamespaces", targetNamespaces,
+		"probeContainers", probeContainers,
+		"podSelector", podSelector,
 		"logLevel", logLevel.String(),
+		"logFormat", logFormat,
 		"includeProbeOutput", includeProbeOutput,
+		"includePhysical", includePhysical,
+		"detectCycles", detectCycles,
+		"columnAliases", columnAliases,
+		"clusterID", clusterID,
+		"probeTimeout", probeTimeout.String(),
+		"execMaxRetries", execMaxRetries,
+		"execRetryBaseDelay", execRetryDelay.String(),
+		"snapshotCacheTTL", snapshotCacheTTL.String(),
+		"maxSnapshotBytes", maxSnapshotBytes,
+		"maxNodes", graphLimits.MaxNodes,
+		"maxEdges", graphLimits.MaxEdges,
+		"fanoutConcurrency", fanoutConcurrency,
+		"rateLimitRPS", rateLimitRPS,
+		"rateLimitBurst", rateLimitBurst,
+		"rateLimitKeyHeader", rateLimitKeyHeader,
+		"basePath", basePath,
+		"pprofEnabled", enablePprof,
+		"pprofPort", pprofPort,
+		"upstreamURL", upstreamURL,
+		"upstreamAuthEnabled", upstreamToken != "",
+		"authEnabled", authToken != "",
+		"tlsEnabled", tlsEnabled,
+		"clientCertRequired", tlsEnabled && tlsClientCAFile != "",
 	)
-	if err := http.ListenAndServe(addr, srv.Handler()); err != nil {
+	if enablePprof && pprofPort != "" {
+		go servePprof(":"+pprofPort, logger)
+	}
+	if tlsEnabled {
+		err = httpServer.ListenAndServeTLS(tlsCertFile, tlsKeyFile)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil {
 		logger.Error("collector server failed", "error", err)
+		_ = shutdownTracing(context.Background())
 		os.Exit(1)
 	}
 }
 
-func buildLiveCollector(targetNamespaces []string, logger *slog.Logger, includeProbeOutput bool) (*probe.SnapshotCollector, error) {
+func buildLiveCollector(
+	targetNamespaces, probeContainers []string,
+	podSelector string,
+	execMaxRetries int,
+	execRetryDelay time.Duration,
+	logger *slog.Logger,
+	includeProbeOutput bool,
+) (*probe.SnapshotCollector, error) {
 	if len(targetNamespaces) == 0 {
 		return nil, fmt.Errorf("at least one target namespace is required")
 	}
@@ -69,9 +290,71 @@ func buildLiveCollector(targetNamespaces []string, logger *slog.Logger, includeP
 	}
 
 	runnerFactory := probe.NewKubernetesExecRunnerFactory(clientset, restConfig, targetNamespaces, logger.With("component", "runner"))
+	runnerFactory.SetProbeContainers(probeContainers)
+	runnerFactory.SetPodSelector(podSelector)
+	runnerFactory.SetExecRetry(execMaxRetries, execRetryDelay)
+	return probe.NewSnapshotCollector(runnerFactory, logger.With("component", "collector"), includeProbeOutput), nil
+}
+
+// buildLocalCollector probes a locally reachable OVN NB database by running ovn-nbctl on this
+// host, for every requested node name.
+func buildLocalCollector(nbctlPath string, logger *slog.Logger, includeProbeOutput bool) (*probe.SnapshotCollector, error) {
+	binary := nbctlPath
+	if binary == "" {
+		binary = "ovn-nbctl"
+	}
+	if _, err := exec.LookPath(binary); err != nil {
+		return nil, fmt.Errorf("find ovn-nbctl: %w", err)
+	}
+
+	runnerFactory := probe.StaticRunnerFactory{Runner: probe.NewCommandRunner(nbctlPath)}
+	return probe.NewSnapshotCollector(runnerFactory, logger.With("component", "collector"), includeProbeOutput), nil
+}
+
+// buildDumpCollector serves snapshots parsed from captured `ovn-nbctl list` output in dumpDir,
+// such as a must-gather archive, for every requested node name.
+func buildDumpCollector(dumpDir string, logger *slog.Logger, includeProbeOutput bool) (*probe.SnapshotCollector, error) {
+	if dumpDir == "" {
+		return nil, fmt.Errorf("COLLECTOR_DUMP_DIR is required with COLLECTOR_RUNNER=%s", runnerDump)
+	}
+	info, err := os.Stat(dumpDir)
+	if err != nil {
+		return nil, fmt.Errorf("open dump directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("dump directory %s is not a directory", dumpDir)
+	}
+
+	runnerFactory := probe.StaticRunnerFactory{Runner: probe.NewFileRunner(dumpDir)}
 	return probe.NewSnapshotCollector(runnerFactory, logger.With("component", "collector"), includeProbeOutput), nil
 }
 
+// servePprof serves the profiling endpoints on their own listener so they stay off the API port.
+// A failure is logged but leaves the API server running.
+func servePprof(addr string, logger *slog.Logger) {
+	pprofServer := &http.Server{Addr: addr, Handler: server.PprofHandler()}
+	if err := pprofServer.ListenAndServe(); err != nil {
+		logger.Error("pprof server failed", "addr", addr, "error", err)
+	}
+}
+
+// Live probe runners selectable with COLLECTOR_RUNNER.
+const (
+	runnerKubernetes = "kubernetes"
+	runnerLocal      = "local"
+	runnerDump       = "dump"
+)
+
+// parseRunnerMode validates COLLECTOR_RUNNER, falling back to the Kubernetes exec runner.
+func parseRunnerMode(raw string) (string, error) {
+	switch mode := strings.ToLower(strings.TrimSpace(raw)); mode {
+	case runnerKubernetes, runnerLocal, runnerDump:
+		return mode, nil
+	default:
+		return runnerKubernetes, fmt.Errorf("unknown runner %q; expected %s, %s or %s", raw, runnerKubernetes, runnerLocal, runnerDump)
+	}
+}
+
 func envOrDefault(key, fallback string) string {
 	value := os.Getenv(key)
 	if value == "" {
@@ -93,6 +376,56 @@ func parseCSV(raw string) []string {
 	return values
 }
 
+// parseSnapshotDirs splits a colon-separated SNAPSHOT_DIRS value, using fallbackDir when it is empty.
+func parseSnapshotDirs(raw, fallbackDir string) []string {
+	dirs := []string{}
+	for _, part := range strings.Split(raw, ":") {
+		dir := strings.TrimSpace(part)
+		if dir == "" || slices.Contains(dirs, dir) {
+			continue
+		}
+		dirs = append(dirs, dir)
+	}
+	if len(dirs) == 0 {
+		return []string{fallbackDir}
+	}
+	return dirs
+}
+
+// parseColumnAliases reads comma-separated Table.alias=current entries, for example
+// "Logical_Router.uuid=_uuid", into per-table alias maps. Any malformed entry rejects the whole
+// value so a typo cannot silently drop part of the mapping.
+func parseColumnAliases(raw string) (map[string]map[string]string, error) {
+	aliases := map[string]map[string]string{}
+	for _, entry := range parseCSV(raw) {
+		column, current, ok := strings.Cut(entry, "=")
+		table, alias, tableOK := strings.Cut(column, ".")
+		table, alias, current = strings.TrimSpace(table), strings.TrimSpace(alias), strings.TrimSpace(current)
+		if !ok || !tableOK || table == "" || alias == "" || current == "" {
+			return nil, fmt.Errorf("expected Table.alias=current, got %q", entry)
+		}
+		if aliases[table] == nil {
+			aliases[table] = map[string]string{}
+		}
+		aliases[table][alias] = current
+	}
+	return aliases, nil
+}
+
+// parseLabelSelector validates a Kubernetes label selector such as "app=ovnkube-node" and
+// returns its canonical form. An empty value selects every pod.
+func parseLabelSelector(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", nil
+	}
+	selector, err := labels.Parse(raw)
+	if err != nil {
+		return "", err
+	}
+	return selector.String(), nil
+}
+
 func parseLogLevel(raw string) slog.Level {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "error":
@@ -111,6 +444,65 @@ func parseLogLevel(raw string) slog.Level {
 	}
 }
 
+// Log output formats selectable with COLLECTOR_LOG_FORMAT.
+const (
+	logFormatJSON = "json"
+	logFormatText = "text"
+)
+
+// parseLogFormat validates COLLECTOR_LOG_FORMAT, falling back to JSON.
+func parseLogFormat(raw string) (string, error) {
+	switch format := strings.ToLower(strings.TrimSpace(raw)); format {
+	case logFormatJSON, logFormatText:
+		return format, nil
+	default:
+		return logFormatJSON, fmt.Errorf("unknown log format %q; expected %s or %s", raw, logFormatJSON, logFormatText)
+	}
+}
+
+// newLogHandler returns the slog handler for format: logfmt-style key=value lines for text and
+// one JSON object per line otherwise.
+func newLogHandler(w io.Writer, format string, level slog.Level) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if format == logFormatText {
+		return slog.NewTextHandler(w, opts)
+	}
+	return slog.NewJSONHandler(w, opts)
+}
+
+func parseDuration(raw string) (time.Duration, error) {
+	value, err := time.ParseDuration(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("duration must not be negative: %s", raw)
+	}
+	return value, nil
+}
+
+func parseNonNegativeInt(raw string) (int, error) {
+	value, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 {
+		return 0, fmt.Errorf("value must not be negative: %s", raw)
+	}
+	return value, nil
+}
+
+func parseNonNegativeFloat(raw string) (float64, error) {
+	value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 || math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, fmt.Errorf("value must be a finite non-negative number: %s", raw)
+	}
+	return value, nil
+}
+
 func parseBool(raw string) bool {
 	switch strings.ToLower(strings.TrimSpace(raw)) {
 	case "1", "t", "true", "y", "yes", "on":
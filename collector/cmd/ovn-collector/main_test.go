@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNewLogHandlerFormats(t *testing.T) {
+	tests := []struct {
+		raw   string
+		check func(t *testing.T, line string)
+	}{
+		{raw: "json", check: func(t *testing.T, line string) {
+			var record map[string]any
+			if err := json.Unmarshal([]byte(line), &record); err != nil {
+				t.Fatalf("expected a JSON log line, got %q: %v", line, err)
+			}
+			if record["level"] != "INFO" || record["node"] != "worker-a" {
+				t.Fatalf("unexpected JSON record %v", record)
+			}
+		}},
+		{raw: " Text ", check: func(t *testing.T, line string) {
+			if !strings.Contains(line, "level=INFO") || !strings.Contains(line, "node=worker-a") || strings.Contains(line, `"level"`) {
+				t.Fatalf("expected a logfmt line, got %q", line)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		format, err := parseLogFormat(tt.raw)
+		if err != nil {
+			t.Fatalf("parseLogFormat(%q) error = %v", tt.raw, err)
+		}
+		var buf bytes.Buffer
+		slog.New(newLogHandler(&buf, format, slog.LevelInfo)).Info("collected", "node", "worker-a")
+		tt.check(t, strings.TrimSpace(buf.String()))
+	}
+
+	format, err := parseLogFormat("yaml")
+	if err == nil || format != logFormatJSON {
+		t.Fatalf("expected an unknown format to fall back to json with an error, got %q, %v", format, err)
+	}
+}
@@ -0,0 +1,24 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClockOnlyMovesWhenTold(t *testing.T) {
+	start := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+	if got := fake.Now(); !got.Equal(start) {
+		t.Fatalf("expected %s, got %s", start, got)
+	}
+
+	fake.Step(90 * time.Second)
+	if got := fake.Now(); !got.Equal(start.Add(90 * time.Second)) {
+		t.Fatalf("expected clock to step 90s, got %s", got)
+	}
+
+	fake.Set(start)
+	if got := fake.Now(); !got.Equal(start) {
+		t.Fatalf("expected clock to reset to %s, got %s", start, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -17,6 +18,17 @@ var (
 	logicalRouterPortCommand = []string{"ovn-nbctl", "--format=json", "list", "Logical_Router_Port"}
 	logicalSwitchCommand     = []string{"ovn-nbctl", "--format=json", "list", "Logical_Switch"}
 	logicalSwitchPortCommand = []string{"ovn-nbctl", "--format=json", "list", "Logical_Switch_Port"}
+	loadBalancerCommand      = []string{"ovn-nbctl", "--format=json", "list", "Load_Balancer"}
+	natCommand               = []string{"ovn-nbctl", "--format=json", "list", "NAT"}
+	aclCommand               = []string{"ovn-nbctl", "--format=json", "list", "ACL"}
+	qosCommand               = []string{"ovn-nbctl", "--format=json", "list", "QoS"}
+	dhcpOptionsCommand       = []string{"ovn-nbctl", "--format=json", "list", "DHCP_Options"}
+	staticRouteCommand       = []string{"ovn-nbctl", "--format=json", "list", "Logical_Router_Static_Route"}
+	portGroupCommand         = []string{"ovn-nbctl", "--format=json", "list", "Port_Group"}
+	gatewayChassisCommand    = []string{"ovn-nbctl", "--format=json", "list", "Gateway_Chassis"}
+	haChassisGroupCommand    = []string{"ovn-nbctl", "--format=json", "list", "HA_Chassis_Group"}
+	chassisCommand           = []string{"ovn-sbctl", "--format=json", "list", "Chassis"}
+	portBindingCommand       = []string{"ovn-sbctl", "--format=json", "list", "Port_Binding"}
 )
 
 var (
@@ -27,10 +39,25 @@ var (
 	}
 )
 
-// CollectOptions controls collector probe logging behavior.
+// CollectOptions controls collector probe logging behavior and which tables are collected.
+// IncludePhysical adds the OVN SB Chassis and Port_Binding tables to the logical NB tables.
+// DetectCycles runs a router/switch cycle check over the assembled graph, which costs CPU on
+// large topologies. ColumnAliases maps an OVN table name to alternate column headings and the
+// current heading each one stands for, for OVN releases that name columns differently; tables
+// without an entry are parsed with the current headings. NbctlArgs are inserted right after
+// ovn-nbctl in every NB command, e.g. --db=unix:/var/run/ovn/ovnnb_db.sock. Limits truncates
+// graphs with more nodes or edges than allowed and degrades their source health.
+// ObserveResource, when set, is called once per table with nil on success or the command or
+// parse error.
 type CollectOptions struct {
 	Logger             *slog.Logger
 	IncludeProbeOutput bool
+	IncludePhysical    bool
+	DetectCycles       bool
+	ColumnAliases      map[string]map[string]string
+	NbctlArgs          []string
+	Limits             GraphLimits
+	ObserveResource    func(resource string, err error)
 }
 
 // SetDefaultCollectOptions updates process-wide defaults for probe collection logging.
@@ -65,32 +92,96 @@ func CollectSnapshot(ctx context.Context, runner Runner, nodeName string, now ti
 
 // CollectSnapshotWithOptions builds a logical topology snapshot with explicit logging options.
 func CollectSnapshotWithOptions(ctx context.Context, runner Runner, nodeName string, now time.Time, opts CollectOptions) (snapshot.LogicalTopologySnapshot, error) {
-	routers, routerPorts, switches, switchPorts, warnings, err := collectResources(ctx, runner, opts)
+	resources, warnings, err := collectResources(ctx, runner, opts)
 	if err != nil {
 		return snapshot.LogicalTopologySnapshot{}, err
 	}
 
-	nodes, edges := buildGraph(routers, routerPorts, switches, switchPorts)
-	sourceHealth := "healthy"
+	result := BuildSnapshotFromResources(resources, now, nodeName)
 	if len(warnings) > 0 {
+		result.Metadata.SourceHealth = "degraded"
+		result.Warnings = append(warnings, result.Warnings...)
+	}
+	if opts.DetectCycles {
+		// A cycle describes the topology itself rather than a collection problem, so it is
+		// reported without degrading source health.
+		result.Warnings = append(result.Warnings, routingCycleWarnings(result.Nodes, result.Edges)...)
+	}
+	if truncateSnapshot(&result, opts.Limits) {
+		result.Metadata.SourceHealth = "degraded"
+	}
+	return result, nil
+}
+
+// Resources groups the parsed OVN NB tables used for logical topology assembly, plus the
+// optional OVN SB tables used for physical topology.
+type Resources struct {
+	Routers         []LogicalRouter
+	RouterPorts     []LogicalRouterPort
+	Switches        []LogicalSwitch
+	SwitchPorts     []LogicalSwitchPort
+	LoadBalancers   []LogicalLoadBalancer
+	NATs            []LogicalNAT
+	ACLs            []LogicalACL
+	QoSRules        []LogicalQoS
+	DHCPOptions     []DHCPOptions
+	StaticRoutes    []StaticRoute
+	PortGroups      []PortGroup
+	GatewayChassis  []GatewayChassis
+	HAChassisGroups []HAChassisGroup
+	Chassis         []Chassis
+	PortBindings    []PortBinding
+}
+
+// BuildSnapshot assembles a logical topology snapshot from already-parsed OVN NB resources
+// without running any probe commands. The result reports healthy source health and no warnings
+// unless a table repeats a UUID.
+func BuildSnapshot(
+	routers []LogicalRouter,
+	routerPorts []LogicalRouterPort,
+	switches []LogicalSwitch,
+	switchPorts []LogicalSwitchPort,
+	now time.Time,
+	nodeName string,
+) snapshot.LogicalTopologySnapshot {
+	return BuildSnapshotFromResources(Resources{
+		Routers:     routers,
+		RouterPorts: routerPorts,
+		Switches:    switches,
+		SwitchPorts: switchPorts,
+	}, now, nodeName)
+}
+
+// BuildSnapshotFromResources assembles a logical topology snapshot from a full set of parsed
+// OVN NB resources, including tables not covered by BuildSnapshot. Rows repeating a UUID
+// already seen in the same table are dropped with a DUPLICATE_UUID warning and degrade the
+// source health.
+func BuildSnapshotFromResources(resources Resources, now time.Time, nodeName string) snapshot.LogicalTopologySnapshot {
+	graph := buildGraph(resources)
+	sourceHealth := "healthy"
+	if len(graph.warnings) > 0 {
 		sourceHealth = "degraded"
 	}
 
 	return snapshot.LogicalTopologySnapshot{
 		Metadata: snapshot.Metadata{
-			SchemaVersion: "v1alpha1",
-			GeneratedAt:   now.UTC(),
-			SourceHealth:  sourceHealth,
-			NodeName:      nodeName,
+			SchemaVersion:  snapshot.SchemaVersion,
+			GeneratedAt:    now.UTC(),
+			SourceHealth:   sourceHealth,
+			NodeName:       nodeName,
+			KindCounts:     graph.kindCounts,
+			EdgeKindCounts: graph.edgeKindCounts,
 		},
-		Nodes:    nodes,
-		Edges:    edges,
-		Groups:   []snapshot.Group{},
-		Warnings: warnings,
-	}, nil
+		Nodes:    graph.nodes,
+		Edges:    graph.edges,
+		Groups:   graph.groups,
+		Warnings: graph.warnings,
+	}
 }
 
-func collectResources(ctx context.Context, runner Runner, opts CollectOptions) ([]LogicalRouter, []LogicalRouterPort, []LogicalSwitch, []LogicalSwitchPort, []snapshot.Warning, error) {
+type warningAppender func(code snapshot.WarningCode, message string)
+
+func collectResources(ctx context.Context, runner Runner, opts CollectOptions) (Resources, []snapshot.Warning, error) {
 	logger := opts.Logger
 	if logger == nil {
 		logger = slog.Default()
@@ -98,139 +189,339 @@ func collectResources(ctx context.Context, runner Runner, opts CollectOptions) (
 	warnings := []snapshot.Warning{}
 	addedWarnings := map[string]bool{}
 
-	appendWarning := func(code, message string) {
-		if addedWarnings[code+message] {
+	appendWarning := func(code snapshot.WarningCode, message string) {
+		key := string(code) + message
+		if addedWarnings[key] {
 			return
 		}
-		warnings = append(warnings, snapshot.Warning{Code: code, Message: message})
-		addedWarnings[code+message] = true
+		warnings = append(warnings, snapshot.NewWarning(code, message))
+		addedWarnings[key] = true
 	}
 
-	routers := []LogicalRouter{}
-	logger.Debug("running OVN probe command", "resource", "Logical_Router", "command", strings.Join(logicalRouterCommand, " "))
-	rawRouters, err := runner.Run(ctx, logicalRouterCommand)
-	if err != nil {
-		logger.Warn("OVN probe command failed", "resource", "Logical_Router", "error", err)
-		appendWarning("COMMAND_FAILED", fmt.Sprintf("Logical_Router command failed: %v", err))
-	} else {
-		logProbeOutput(logger, opts.IncludeProbeOutput, logicalRouterCommand, rawRouters)
-		parsedRouters, normalized, parseErr := ParseLogicalRouters(rawRouters)
-		if parseErr != nil {
-			logger.Warn("OVN probe parser failed", "resource", "Logical_Router", "error", parseErr)
-			logProbeParseContext(logger, opts.IncludeProbeOutput, rawRouters)
-			appendWarning("PARSER_FAILED", fmt.Sprintf("Logical_Router parse failed: %v", parseErr))
-		} else {
-			routers = parsedRouters
-			if normalized {
-				logger.Debug("OVN probe parser normalized input", "resource", "Logical_Router")
-				appendWarning("PARSER_NORMALIZED", "Input required normalization due to inconsistent OVN command output")
-			}
-		}
+	table := tableCollector{
+		ctx:                ctx,
+		runner:             runner,
+		logger:             logger,
+		includeProbeOutput: opts.IncludeProbeOutput,
+		columnAliases:      opts.ColumnAliases,
+		nbctlArgs:          opts.NbctlArgs,
+		appendWarning:      appendWarning,
+		observe:            opts.ObserveResource,
+	}
+	resources := Resources{
+		Routers:         collectTable(table, "Logical_Router", logicalRouterCommand, ParseLogicalRouters),
+		RouterPorts:     collectTable(table, "Logical_Router_Port", logicalRouterPortCommand, ParseLogicalRouterPorts),
+		Switches:        collectTable(table, "Logical_Switch", logicalSwitchCommand, ParseLogicalSwitches),
+		SwitchPorts:     collectTable(table, "Logical_Switch_Port", logicalSwitchPortCommand, ParseLogicalSwitchPorts),
+		LoadBalancers:   collectTable(table, "Load_Balancer", loadBalancerCommand, ParseLoadBalancers),
+		NATs:            collectTable(table, "NAT", natCommand, ParseNATs),
+		ACLs:            collectTable(table, "ACL", aclCommand, ParseACLs),
+		QoSRules:        collectTable(table, "QoS", qosCommand, ParseQoS),
+		DHCPOptions:     collectTable(table, "DHCP_Options", dhcpOptionsCommand, ParseDHCPOptions),
+		StaticRoutes:    collectTable(table, "Logical_Router_Static_Route", staticRouteCommand, ParseStaticRoutes),
+		PortGroups:      collectTable(table, "Port_Group", portGroupCommand, ParsePortGroups),
+		GatewayChassis:  collectTable(table, "Gateway_Chassis", gatewayChassisCommand, ParseGatewayChassis),
+		HAChassisGroups: collectTable(table, "HA_Chassis_Group", haChassisGroupCommand, ParseHAChassisGroups),
+	}
+	if opts.IncludePhysical {
+		resources.Chassis = collectTable(table, "Chassis", chassisCommand, ParseChassis)
+		resources.PortBindings = collectTable(table, "Port_Binding", portBindingCommand, ParsePortBindings)
 	}
 
-	routerPorts := []LogicalRouterPort{}
-	logger.Debug("running OVN probe command", "resource", "Logical_Router_Port", "command", strings.Join(logicalRouterPortCommand, " "))
-	rawRouterPorts, err := runner.Run(ctx, logicalRouterPortCommand)
-	if err != nil {
-		logger.Warn("OVN probe command failed", "resource", "Logical_Router_Port", "error", err)
-		appendWarning("COMMAND_FAILED", fmt.Sprintf("Logical_Router_Port command failed: %v", err))
-	} else {
-		logProbeOutput(logger, opts.IncludeProbeOutput, logicalRouterPortCommand, rawRouterPorts)
-		parsedRouterPorts, normalized, parseErr := ParseLogicalRouterPorts(rawRouterPorts)
-		if parseErr != nil {
-			logger.Warn("OVN probe parser failed", "resource", "Logical_Router_Port", "error", parseErr)
-			logProbeParseContext(logger, opts.IncludeProbeOutput, rawRouterPorts)
-			appendWarning("PARSER_FAILED", fmt.Sprintf("Logical_Router_Port parse failed: %v", parseErr))
-		} else {
-			routerPorts = parsedRouterPorts
-			if normalized {
-				logger.Debug("OVN probe parser normalized input", "resource", "Logical_Router_Port")
-				appendWarning("PARSER_NORMALIZED", "Input required normalization due to inconsistent OVN command output")
-			}
-		}
+	return resources, warnings, nil
+}
+
+// tableCollector carries the per-collection state shared by every collectTable call.
+type tableCollector struct {
+	ctx                context.Context
+	runner             Runner
+	logger             *slog.Logger
+	includeProbeOutput bool
+	columnAliases      map[string]map[string]string
+	nbctlArgs          []string
+	appendWarning      warningAppender
+	observe            func(resource string, err error)
+}
+
+func (c tableCollector) observeResource(resource string, err error) {
+	if c.observe != nil {
+		c.observe(resource, err)
 	}
+}
 
-	switches := []LogicalSwitch{}
-	logger.Debug("running OVN probe command", "resource", "Logical_Switch", "command", strings.Join(logicalSwitchCommand, " "))
-	rawSwitches, err := runner.Run(ctx, logicalSwitchCommand)
+// collectTable runs one OVN list command and parses its rows. Command and parser failures
+// are recorded as warnings and yield an empty result so other tables can still be assembled.
+func collectTable[T any](
+	c tableCollector,
+	resource string,
+	command []string,
+	parse func(string) ([]T, bool, error),
+) []T {
+	logger := c.logger
+	command = withNbctlArgs(command, c.nbctlArgs)
+	logger.Debug("running OVN probe command", "resource", resource, "command", strings.Join(command, " "))
+	raw, err := c.runner.Run(c.ctx, command)
 	if err != nil {
-		logger.Warn("OVN probe command failed", "resource", "Logical_Switch", "error", err)
-		appendWarning("COMMAND_FAILED", fmt.Sprintf("Logical_Switch command failed: %v", err))
-	} else {
-		logProbeOutput(logger, opts.IncludeProbeOutput, logicalSwitchCommand, rawSwitches)
-		parsedSwitches, normalized, parseErr := ParseLogicalSwitches(rawSwitches)
-		if parseErr != nil {
-			logger.Warn("OVN probe parser failed", "resource", "Logical_Switch", "error", parseErr)
-			logProbeParseContext(logger, opts.IncludeProbeOutput, rawSwitches)
-			appendWarning("PARSER_FAILED", fmt.Sprintf("Logical_Switch parse failed: %v", parseErr))
-		} else {
-			switches = parsedSwitches
-			if normalized {
-				logger.Debug("OVN probe parser normalized input", "resource", "Logical_Switch")
-				appendWarning("PARSER_NORMALIZED", "Input required normalization due to inconsistent OVN command output")
-			}
-		}
+		logger.Warn("OVN probe command failed", "resource", resource, "error", err)
+		c.appendWarning(snapshot.WarningCommandFailed, fmt.Sprintf("%s command failed: %v", resource, err))
+		c.observeResource(resource, fmt.Errorf("command failed: %w", err))
+		return []T{}
 	}
 
-	switchPorts := []LogicalSwitchPort{}
-	logger.Debug("running OVN probe command", "resource", "Logical_Switch_Port", "command", strings.Join(logicalSwitchPortCommand, " "))
-	rawSwitchPorts, err := runner.Run(ctx, logicalSwitchPortCommand)
-	if err != nil {
-		logger.Warn("OVN probe command failed", "resource", "Logical_Switch_Port", "error", err)
-		appendWarning("COMMAND_FAILED", fmt.Sprintf("Logical_Switch_Port command failed: %v", err))
-	} else {
-		logProbeOutput(logger, opts.IncludeProbeOutput, logicalSwitchPortCommand, rawSwitchPorts)
-		parsedSwitchPorts, normalized, parseErr := ParseLogicalSwitchPorts(rawSwitchPorts)
-		if parseErr != nil {
-			logger.Warn("OVN probe parser failed", "resource", "Logical_Switch_Port", "error", parseErr)
-			logProbeParseContext(logger, opts.IncludeProbeOutput, rawSwitchPorts)
-			appendWarning("PARSER_FAILED", fmt.Sprintf("Logical_Switch_Port parse failed: %v", parseErr))
-		} else {
-			switchPorts = parsedSwitchPorts
-			if normalized {
-				logger.Debug("OVN probe parser normalized input", "resource", "Logical_Switch_Port")
-				appendWarning("PARSER_NORMALIZED", "Input required normalization due to inconsistent OVN command output")
-			}
-		}
+	logProbeOutput(logger, c.includeProbeOutput, command, raw)
+	// An undecodable payload is passed through unchanged so the parser reports the failure.
+	aliased, aliasNormalized, aliasErr := applyColumnAliases(raw, c.columnAliases[resource])
+	if aliasErr == nil {
+		raw = aliased
+	}
+	parsed, normalized, parseErr := parse(raw)
+	if parseErr != nil {
+		logger.Warn("OVN probe parser failed", "resource", resource, "error", parseErr)
+		logProbeParseContext(logger, c.includeProbeOutput, raw)
+		c.appendWarning(snapshot.WarningParserFailed, fmt.Sprintf("%s parse failed: %v", resource, parseErr))
+		c.observeResource(resource, fmt.Errorf("parse failed: %w", parseErr))
+		return []T{}
+	}
+	c.observeResource(resource, nil)
+	if normalized || aliasNormalized {
+		logger.Debug("OVN probe parser normalized input", "resource", resource)
+		c.appendWarning(snapshot.WarningParserNormalized, "Input required normalization due to inconsistent OVN command output")
+	}
+	return parsed
+}
+
+// withNbctlArgs returns command with args inserted after ovn-nbctl and before its options and
+// subcommand. Other commands, and calls without args, are returned unchanged.
+func withNbctlArgs(command, args []string) []string {
+	if len(args) == 0 || len(command) == 0 || command[0] != "ovn-nbctl" {
+		return command
 	}
+	extended := make([]string, 0, len(command)+len(args))
+	extended = append(extended, command[0])
+	extended = append(extended, args...)
+	return append(extended, command[1:]...)
+}
 
-	return routers, routerPorts, switches, switchPorts, warnings, nil
+// graph is the assembled topology along with per-kind tallies of its nodes and edges.
+type graph struct {
+	nodes          []snapshot.Node
+	edges          []snapshot.Edge
+	groups         []snapshot.Group
+	kindCounts     map[string]int
+	edgeKindCounts map[string]int
+	warnings       []snapshot.Warning
 }
 
-func buildGraph(
-	routers []LogicalRouter,
-	routerPorts []LogicalRouterPort,
-	switches []LogicalSwitch,
-	switchPorts []LogicalSwitchPort,
-) ([]snapshot.Node, []snapshot.Edge) {
+func buildGraph(resources Resources) graph {
 	nodes := map[string]snapshot.Node{}
 	edges := map[string]snapshot.Edge{}
 
+	// Keep the first row for each UUID so later rows cannot silently replace it in the maps below.
+	warnings := []snapshot.Warning{}
+	resources.Routers = firstByID(resources.Routers, "Logical_Router", routerNodeID, &warnings)
+	resources.RouterPorts = firstByID(resources.RouterPorts, "Logical_Router_Port", func(port LogicalRouterPort) string {
+		return strings.TrimSpace(port.UUID)
+	}, &warnings)
+	resources.Switches = firstByID(resources.Switches, "Logical_Switch", switchNodeID, &warnings)
+	resources.SwitchPorts = firstByID(resources.SwitchPorts, "Logical_Switch_Port", switchPortNodeID, &warnings)
+	resources.LoadBalancers = firstByID(resources.LoadBalancers, "Load_Balancer", loadBalancerNodeID, &warnings)
+	resources.NATs = firstByID(resources.NATs, "NAT", natNodeID, &warnings)
+	resources.ACLs = firstByID(resources.ACLs, "ACL", aclNodeID, &warnings)
+	resources.QoSRules = firstByID(resources.QoSRules, "QoS", qosNodeID, &warnings)
+	resources.DHCPOptions = firstByID(resources.DHCPOptions, "DHCP_Options", dhcpOptionsNodeID, &warnings)
+	resources.StaticRoutes = firstByID(resources.StaticRoutes, "Logical_Router_Static_Route", staticRouteNodeID, &warnings)
+	resources.PortGroups = firstByID(resources.PortGroups, "Port_Group", portGroupNodeID, &warnings)
+	resources.GatewayChassis = firstByID(resources.GatewayChassis, "Gateway_Chassis", gatewayChassisNodeID, &warnings)
+	resources.HAChassisGroups = firstByID(resources.HAChassisGroups, "HA_Chassis_Group", haChassisGroupNodeID, &warnings)
+	resources.Chassis = firstByID(resources.Chassis, "Chassis", chassisNodeID, &warnings)
+
 	routerPortByUUID := map[string]LogicalRouterPort{}
-	for _, port := range routerPorts {
+	for _, port := range resources.RouterPorts {
 		routerPortByUUID[port.UUID] = port
 	}
 
+	loadBalancerNodeIDByUUID := map[string]string{}
+	for _, loadBalancer := range resources.LoadBalancers {
+		loadBalancerNodeID := loadBalancerNodeID(loadBalancer)
+		nodes[loadBalancerNodeID] = snapshot.Node{
+			ID:    loadBalancerNodeID,
+			Kind:  "load_balancer",
+			Label: labelOrID(loadBalancer.Name, loadBalancerNodeID),
+			Data: map[string]interface{}{
+				"uuid":     loadBalancer.UUID,
+				"vips":     loadBalancer.VIPs,
+				"protocol": loadBalancer.Protocol,
+			},
+		}
+		loadBalancerNodeIDByUUID[loadBalancer.UUID] = loadBalancerNodeID
+	}
+
+	natNodeIDByUUID := map[string]string{}
+	for _, nat := range resources.NATs {
+		natNodeID := natNodeID(nat)
+		nodes[natNodeID] = snapshot.Node{
+			ID:    natNodeID,
+			Kind:  "nat",
+			Label: labelOrID(natLabel(nat), natNodeID),
+			Data: map[string]interface{}{
+				"uuid":        nat.UUID,
+				"type":        nat.Type,
+				"externalIP":  nat.ExternalIP,
+				"logicalIP":   nat.LogicalIP,
+				"logicalPort": nat.LogicalPort,
+			},
+		}
+		natNodeIDByUUID[nat.UUID] = natNodeID
+	}
+
+	aclNodeIDByUUID := map[string]string{}
+	for _, acl := range resources.ACLs {
+		aclNodeID := aclNodeID(acl)
+		nodes[aclNodeID] = snapshot.Node{
+			ID:    aclNodeID,
+			Kind:  "acl",
+			Label: labelOrID(aclLabel(acl), aclNodeID),
+			Data: map[string]interface{}{
+				"uuid":      acl.UUID,
+				"priority":  acl.Priority,
+				"direction": acl.Direction,
+				"match":     acl.Match,
+				"action":    acl.Action,
+				"stateful":  acl.Stateful(),
+			},
+		}
+		aclNodeIDByUUID[acl.UUID] = aclNodeID
+	}
+
+	qosNodeIDByUUID := map[string]string{}
+	for _, qos := range resources.QoSRules {
+		qosNodeID := qosNodeID(qos)
+		data := map[string]interface{}{
+			"uuid":      qos.UUID,
+			"priority":  qos.Priority,
+			"direction": qos.Direction,
+			"match":     qos.Match,
+		}
+		if rate, ok := qos.Bandwidth["rate"]; ok {
+			data["rate"] = rate
+		}
+		if burst, ok := qos.Bandwidth["burst"]; ok {
+			data["burst"] = burst
+		}
+		if dscp, ok := qos.Action["dscp"]; ok {
+			data["dscp"] = dscp
+		}
+		nodes[qosNodeID] = snapshot.Node{
+			ID:    qosNodeID,
+			Kind:  "qos",
+			Label: labelOrID(qosLabel(qos), qosNodeID),
+			Data:  data,
+		}
+		qosNodeIDByUUID[qos.UUID] = qosNodeID
+	}
+
+	dhcpOptionsNodeIDByUUID := map[string]string{}
+	for _, options := range resources.DHCPOptions {
+		dhcpOptionsNodeID := dhcpOptionsNodeID(options)
+		nodes[dhcpOptionsNodeID] = snapshot.Node{
+			ID:    dhcpOptionsNodeID,
+			Kind:  "dhcp_options",
+			Label: labelOrID(options.CIDR, dhcpOptionsNodeID),
+			Data: map[string]interface{}{
+				"uuid":    options.UUID,
+				"cidr":    options.CIDR,
+				"options": options.Options,
+			},
+		}
+		dhcpOptionsNodeIDByUUID[options.UUID] = dhcpOptionsNodeID
+	}
+
+	staticRouteByUUID := map[string]StaticRoute{}
+	for _, route := range resources.StaticRoutes {
+		staticRouteByUUID[route.UUID] = route
+	}
+
 	routerIDByRouterPortName := map[string]string{}
-	for _, router := range routers {
+	for _, router := range resources.Routers {
 		routerNodeID := routerNodeID(router)
+		routerPorts := []map[string]interface{}{}
+		for _, portUUID := range router.PortUUIDs {
+			port, ok := routerPortByUUID[portUUID]
+			if !ok {
+				continue
+			}
+			if port.Name != "" {
+				routerIDByRouterPortName[port.Name] = routerNodeID
+			}
+			routerPorts = append(routerPorts, map[string]interface{}{
+				"uuid":     port.UUID,
+				"name":     port.Name,
+				"mac":      port.MAC,
+				"networks": port.Networks,
+				"peer":     port.Peer,
+			})
+		}
 		nodes[routerNodeID] = snapshot.Node{
 			ID:    routerNodeID,
 			Kind:  "logical_router",
 			Label: labelOrID(router.Name, routerNodeID),
 			Data: map[string]interface{}{
-				"uuid": router.UUID,
+				"uuid":  router.UUID,
+				"ports": routerPorts,
 			},
 		}
-		for _, portUUID := range router.PortUUIDs {
-			if port, ok := routerPortByUUID[portUUID]; ok && port.Name != "" {
-				routerIDByRouterPortName[port.Name] = routerNodeID
+		for _, loadBalancerUUID := range router.LoadBalancerUUIDs {
+			if loadBalancerNodeID, ok := loadBalancerNodeIDByUUID[loadBalancerUUID]; ok {
+				addEdge(edges, "router_to_load_balancer", routerNodeID, loadBalancerNodeID, "Logical_Router.load_balancer")
+			}
+		}
+		for _, natUUID := range router.NATUUIDs {
+			if natNodeID, ok := natNodeIDByUUID[natUUID]; ok {
+				addEdge(edges, "router_to_nat", routerNodeID, natNodeID, "Logical_Router.nat")
 			}
 		}
+		// Routes are only rendered for the router that owns them; unreferenced rows are skipped.
+		for _, routeUUID := range router.StaticRouteUUIDs {
+			route, ok := staticRouteByUUID[routeUUID]
+			if !ok {
+				continue
+			}
+			routeNodeID := staticRouteNodeID(route)
+			nodes[routeNodeID] = snapshot.Node{
+				ID:    routeNodeID,
+				Kind:  "static_route",
+				Label: labelOrID(staticRouteLabel(route), routeNodeID),
+				Data: map[string]interface{}{
+					"uuid":       route.UUID,
+					"ipPrefix":   route.IPPrefix,
+					"nexthop":    route.Nexthop,
+					"outputPort": route.OutputPort,
+				},
+			}
+			addEdge(edges, "router_to_route", routerNodeID, routeNodeID, "Logical_Router.static_routes")
+		}
+	}
+
+	// Peered router ports connect routers directly. The edge is keyed by the sorted router
+	// pair so symmetric peers and one-sided (dangling) peer references yield a single edge.
+	for _, port := range resources.RouterPorts {
+		if port.Peer == "" {
+			continue
+		}
+		routerNodeID, hasRouter := routerIDByRouterPortName[port.Name]
+		peerRouterNodeID, hasPeer := routerIDByRouterPortName[port.Peer]
+		if !hasRouter || !hasPeer || routerNodeID == peerRouterNodeID {
+			continue
+		}
+		source, target := routerNodeID, peerRouterNodeID
+		if target < source {
+			source, target = target, source
+		}
+		addEdge(edges, "router_to_router", source, target, "Logical_Router_Port.peer")
 	}
 
 	switchIDByPortUUID := map[string]string{}
-	for _, logicalSwitch := range switches {
+	for _, logicalSwitch := range resources.Switches {
 		switchNodeID := switchNodeID(logicalSwitch)
 		nodes[switchNodeID] = snapshot.Node{
 			ID:    switchNodeID,
@@ -243,10 +534,30 @@ func buildGraph(
 		for _, portUUID := range logicalSwitch.PortUUIDs {
 			switchIDByPortUUID[portUUID] = switchNodeID
 		}
+		for _, loadBalancerUUID := range logicalSwitch.LoadBalancerUUIDs {
+			if loadBalancerNodeID, ok := loadBalancerNodeIDByUUID[loadBalancerUUID]; ok {
+				addEdge(edges, "switch_to_load_balancer", switchNodeID, loadBalancerNodeID, "Logical_Switch.load_balancer")
+			}
+		}
+		for _, aclUUID := range logicalSwitch.ACLUUIDs {
+			if aclNodeID, ok := aclNodeIDByUUID[aclUUID]; ok {
+				addEdge(edges, "switch_to_acl", switchNodeID, aclNodeID, "Logical_Switch.acls")
+			}
+		}
+		for _, qosUUID := range logicalSwitch.QoSUUIDs {
+			if qosNodeID, ok := qosNodeIDByUUID[qosUUID]; ok {
+				addEdge(edges, "switch_to_qos", switchNodeID, qosNodeID, "Logical_Switch.qos_rules")
+			}
+		}
 	}
 
-	for _, port := range switchPorts {
+	switchPortNodeIDByName := map[string]string{}
+	switchPortNodeIDByRouterPortName := map[string]string{}
+	for _, port := range resources.SwitchPorts {
 		portNodeID := switchPortNodeID(port)
+		if port.Name != "" {
+			switchPortNodeIDByName[port.Name] = portNodeID
+		}
 		nodes[portNodeID] = snapshot.Node{
 			ID:    portNodeID,
 			Kind:  "logical_switch_port",
@@ -255,52 +566,218 @@ func buildGraph(
 				"uuid":    port.UUID,
 				"type":    port.Type,
 				"options": port.Options,
+				"macs":    nonNilStrings(port.MACs),
+				"ips":     nonNilStrings(port.IPs),
 			},
 		}
 
 		if switchNodeID, ok := switchIDByPortUUID[port.UUID]; ok {
-			edgeID := edgeKey("switch_to_port", switchNodeID, portNodeID)
-			edges[edgeID] = snapshot.Edge{
-				ID:     edgeID,
-				Source: switchNodeID,
-				Target: portNodeID,
-				Kind:   "switch_to_port",
+			addEdge(edges, "switch_to_port", switchNodeID, portNodeID, "Logical_Switch.ports")
+		}
+
+		// Ports without DHCP options leave both references empty and get no edge.
+		// A port whose v4 and v6 references name the same row gets one edge with both reasons.
+		for _, reference := range []struct{ uuid, column string }{
+			{port.DHCPv4OptionsUUID, "Logical_Switch_Port.dhcpv4_options"},
+			{port.DHCPv6OptionsUUID, "Logical_Switch_Port.dhcpv6_options"},
+		} {
+			if dhcpOptionsNodeID, ok := dhcpOptionsNodeIDByUUID[reference.uuid]; ok && reference.uuid != "" {
+				addEdge(edges, "port_to_dhcp", portNodeID, dhcpOptionsNodeID, reference.column)
 			}
 		}
 
 		if port.Type == "router" {
 			routerPortName := port.Options["router-port"]
+			if routerPortName != "" {
+				switchPortNodeIDByRouterPortName[routerPortName] = portNodeID
+			}
 			routerNodeID, hasRouter := routerIDByRouterPortName[routerPortName]
 			switchNodeID, hasSwitch := switchIDByPortUUID[port.UUID]
 			if hasRouter && hasSwitch {
-				edgeID := edgeKey("router_to_switch", routerNodeID, switchNodeID)
-				edges[edgeID] = snapshot.Edge{
-					ID:     edgeID,
-					Source: routerNodeID,
-					Target: switchNodeID,
-					Kind:   "router_to_switch",
-				}
+				addEdge(edges, "router_to_switch", routerNodeID, switchNodeID, "Logical_Switch_Port.options:router-port")
 			}
 		}
 	}
 
+	// Router ports are not graph nodes, so a route's output port is drawn to the switch port
+	// attached to that router port. Output ports that are not known router ports get no edge.
+	for _, route := range resources.StaticRoutes {
+		routeNodeID := staticRouteNodeID(route)
+		if _, rendered := nodes[routeNodeID]; !rendered || route.OutputPort == "" {
+			continue
+		}
+		if _, known := routerIDByRouterPortName[route.OutputPort]; !known {
+			continue
+		}
+		portNodeID, ok := switchPortNodeIDByRouterPortName[route.OutputPort]
+		if !ok {
+			continue
+		}
+		addEdge(edges, "route_to_port", routeNodeID, portNodeID, "Logical_Router_Static_Route.output_port")
+	}
+
+	switchPortNodeIDByUUID := map[string]string{}
+	for _, port := range resources.SwitchPorts {
+		if port.UUID != "" {
+			switchPortNodeIDByUUID[port.UUID] = switchPortNodeID(port)
+		}
+	}
+	for _, portGroup := range resources.PortGroups {
+		portGroupNodeID := portGroupNodeID(portGroup)
+		nodes[portGroupNodeID] = snapshot.Node{
+			ID:    portGroupNodeID,
+			Kind:  "port_group",
+			Label: labelOrID(portGroup.Name, portGroupNodeID),
+			Data: map[string]interface{}{
+				"uuid": portGroup.UUID,
+				"name": portGroup.Name,
+			},
+		}
+		for _, portUUID := range portGroup.Ports {
+			if portNodeID, ok := switchPortNodeIDByUUID[portUUID]; ok {
+				addEdge(edges, "portgroup_to_port", portGroupNodeID, portNodeID, "Port_Group.ports")
+			}
+		}
+		for _, aclUUID := range portGroup.ACLs {
+			if aclNodeID, ok := aclNodeIDByUUID[aclUUID]; ok {
+				addEdge(edges, "portgroup_to_acl", portGroupNodeID, aclNodeID, "Port_Group.acls")
+			}
+		}
+	}
+
+	// Gateway router ports are rendered as logical_router_port nodes so their Gateway_Chassis and
+	// HA_Chassis_Group references start at the port. Unreferenced gateway rows are still rendered.
+	gatewayNodeIDByUUID := map[string]string{}
+	for _, gateway := range resources.GatewayChassis {
+		gatewayNodeID := gatewayChassisNodeID(gateway)
+		nodes[gatewayNodeID] = snapshot.Node{
+			ID:    gatewayNodeID,
+			Kind:  "gateway_chassis",
+			Label: labelOrID(gateway.Name, gatewayNodeID),
+			Data: map[string]interface{}{
+				"uuid":        gateway.UUID,
+				"name":        gateway.Name,
+				"chassisName": gateway.ChassisName,
+				"priority":    gateway.Priority,
+			},
+		}
+		gatewayNodeIDByUUID[gateway.UUID] = gatewayNodeID
+	}
+	for _, group := range resources.HAChassisGroups {
+		groupNodeID := haChassisGroupNodeID(group)
+		nodes[groupNodeID] = snapshot.Node{
+			ID:    groupNodeID,
+			Kind:  "ha_chassis_group",
+			Label: labelOrID(group.Name, groupNodeID),
+			Data: map[string]interface{}{
+				"uuid":      group.UUID,
+				"name":      group.Name,
+				"haChassis": group.HAChassisUUIDs,
+			},
+		}
+		gatewayNodeIDByUUID[group.UUID] = groupNodeID
+	}
+	for _, port := range resources.RouterPorts {
+		if len(port.GatewayChassisUUIDs) == 0 && port.HAChassisGroupUUID == "" {
+			continue
+		}
+		portNodeID := routerPortNodeID(port)
+		nodes[portNodeID] = snapshot.Node{
+			ID:    portNodeID,
+			Kind:  "logical_router_port",
+			Label: labelOrID(port.Name, portNodeID),
+			Data: map[string]interface{}{
+				"uuid":     port.UUID,
+				"mac":      port.MAC,
+				"networks": nonNilStrings(port.Networks),
+			},
+		}
+		if routerNodeID, ok := routerIDByRouterPortName[port.Name]; ok {
+			addEdge(edges, "router_to_port", routerNodeID, portNodeID, "Logical_Router.ports")
+		}
+		for _, gatewayUUID := range port.GatewayChassisUUIDs {
+			if gatewayNodeID, ok := gatewayNodeIDByUUID[gatewayUUID]; ok {
+				addEdge(edges, "routerport_to_gateway", portNodeID, gatewayNodeID, "Logical_Router_Port.gateway_chassis")
+			}
+		}
+		if groupNodeID, ok := gatewayNodeIDByUUID[port.HAChassisGroupUUID]; ok && port.HAChassisGroupUUID != "" {
+			addEdge(edges, "routerport_to_gateway", portNodeID, groupNodeID, "Logical_Router_Port.ha_chassis_group")
+		}
+	}
+
+	chassisNodeIDByUUID := map[string]string{}
+	for _, chassis := range resources.Chassis {
+		chassisNodeID := chassisNodeID(chassis)
+		nodes[chassisNodeID] = snapshot.Node{
+			ID:    chassisNodeID,
+			Kind:  "chassis",
+			Label: labelOrID(labelOrID(chassis.Hostname, chassis.Name), chassisNodeID),
+			Data: map[string]interface{}{
+				"uuid":     chassis.UUID,
+				"name":     chassis.Name,
+				"hostname": chassis.Hostname,
+			},
+		}
+		chassisNodeIDByUUID[chassis.UUID] = chassisNodeID
+	}
+
+	for _, binding := range resources.PortBindings {
+		portNodeID, hasPort := switchPortNodeIDByName[binding.LogicalPort]
+		chassisNodeID, hasChassis := chassisNodeIDByUUID[binding.ChassisUUID]
+		if !hasPort || !hasChassis {
+			continue
+		}
+		addEdge(edges, "port_binding", portNodeID, chassisNodeID, "Port_Binding.chassis")
+	}
+
+	kindCounts := map[string]int{}
 	orderedNodes := make([]snapshot.Node, 0, len(nodes))
 	for _, node := range nodes {
 		orderedNodes = append(orderedNodes, node)
+		kindCounts[node.Kind]++
 	}
 	sort.Slice(orderedNodes, func(i, j int) bool {
 		return orderedNodes[i].ID < orderedNodes[j].ID
 	})
 
+	edgeKindCounts := map[string]int{}
 	orderedEdges := make([]snapshot.Edge, 0, len(edges))
 	for _, edge := range edges {
 		orderedEdges = append(orderedEdges, edge)
+		edgeKindCounts[edge.Kind]++
 	}
 	sort.Slice(orderedEdges, func(i, j int) bool {
 		return orderedEdges[i].ID < orderedEdges[j].ID
 	})
 
-	return orderedNodes, orderedEdges
+	return graph{
+		nodes:          orderedNodes,
+		edges:          orderedEdges,
+		groups:         namespaceGroups(resources.SwitchPorts, switchIDByPortUUID),
+		kindCounts:     kindCounts,
+		edgeKindCounts: edgeKindCounts,
+		warnings:       warnings,
+	}
+}
+
+// firstByID drops rows whose ID repeats an earlier row in the same table, appending a
+// DUPLICATE_UUID warning for each one. Rows without an ID are kept.
+func firstByID[T any](rows []T, resource string, id func(T) string, warnings *[]snapshot.Warning) []T {
+	seen := make(map[string]bool, len(rows))
+	kept := make([]T, 0, len(rows))
+	for _, row := range rows {
+		rowID := id(row)
+		if rowID != "" && seen[rowID] {
+			*warnings = append(*warnings, snapshot.NewWarning(
+				snapshot.WarningDuplicateUUID,
+				fmt.Sprintf("%s returned _uuid %s more than once; kept the first row", resource, rowID),
+			))
+			continue
+		}
+		seen[rowID] = true
+		kept = append(kept, row)
+	}
+	return kept
 }
 
 func routerNodeID(router LogicalRouter) string {
@@ -317,6 +794,94 @@ func switchNodeID(logicalSwitch LogicalSwitch) string {
 	return strings.TrimSpace(logicalSwitch.Name)
 }
 
+func loadBalancerNodeID(loadBalancer LogicalLoadBalancer) string {
+	if strings.TrimSpace(loadBalancer.UUID) != "" {
+		return loadBalancer.UUID
+	}
+	return strings.TrimSpace(loadBalancer.Name)
+}
+
+func chassisNodeID(chassis Chassis) string {
+	if strings.TrimSpace(chassis.UUID) != "" {
+		return chassis.UUID
+	}
+	return strings.TrimSpace(chassis.Name)
+}
+
+func gatewayChassisNodeID(gateway GatewayChassis) string {
+	if strings.TrimSpace(gateway.UUID) != "" {
+		return gateway.UUID
+	}
+	return strings.TrimSpace(gateway.Name)
+}
+
+func haChassisGroupNodeID(group HAChassisGroup) string {
+	if strings.TrimSpace(group.UUID) != "" {
+		return group.UUID
+	}
+	return strings.TrimSpace(group.Name)
+}
+
+func natNodeID(nat LogicalNAT) string {
+	return strings.TrimSpace(nat.UUID)
+}
+
+func natLabel(nat LogicalNAT) string {
+	return strings.TrimSpace(strings.Join([]string{nat.Type, nat.ExternalIP}, " "))
+}
+
+func aclNodeID(acl LogicalACL) string {
+	return strings.TrimSpace(acl.UUID)
+}
+
+func aclLabel(acl LogicalACL) string {
+	if strings.TrimSpace(acl.Name) != "" {
+		return acl.Name
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s %d %s", acl.Direction, acl.Priority, acl.Action))
+}
+
+func qosNodeID(qos LogicalQoS) string {
+	return strings.TrimSpace(qos.UUID)
+}
+
+// qosLabel summarizes a QoS rule as its direction and priority followed by what it does, e.g.
+// "from-lport 100 rate 2000 burst 100" or "to-lport 100 dscp 46".
+func qosLabel(qos LogicalQoS) string {
+	parts := []string{fmt.Sprintf("%s %d", qos.Direction, qos.Priority)}
+	for _, key := range []string{"rate", "burst"} {
+		if value, ok := qos.Bandwidth[key]; ok {
+			parts = append(parts, fmt.Sprintf("%s %d", key, value))
+		}
+	}
+	if dscp, ok := qos.Action["dscp"]; ok {
+		parts = append(parts, fmt.Sprintf("dscp %d", dscp))
+	}
+	return strings.TrimSpace(strings.Join(parts, " "))
+}
+
+func dhcpOptionsNodeID(options DHCPOptions) string {
+	return strings.TrimSpace(options.UUID)
+}
+
+func staticRouteNodeID(route StaticRoute) string {
+	return strings.TrimSpace(route.UUID)
+}
+
+func staticRouteLabel(route StaticRoute) string {
+	if strings.TrimSpace(route.Nexthop) == "" {
+		return strings.TrimSpace(route.IPPrefix)
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s via %s", route.IPPrefix, route.Nexthop))
+}
+
+func portGroupNodeID(portGroup PortGroup) string {
+	if strings.TrimSpace(portGroup.UUID) != "" {
+		return portGroup.UUID
+	}
+	return strings.TrimSpace(portGroup.Name)
+}
+
 func switchPortNodeID(port LogicalSwitchPort) string {
 	if strings.TrimSpace(port.UUID) != "" {
 		return port.UUID
@@ -324,6 +889,13 @@ func switchPortNodeID(port LogicalSwitchPort) string {
 	return strings.TrimSpace(port.Name)
 }
 
+func routerPortNodeID(port LogicalRouterPort) string {
+	if strings.TrimSpace(port.UUID) != "" {
+		return port.UUID
+	}
+	return strings.TrimSpace(port.Name)
+}
+
 func labelOrID(label, id string) string {
 	if strings.TrimSpace(label) != "" {
 		return label
@@ -331,10 +903,41 @@ func labelOrID(label, id string) string {
 	return id
 }
 
+// nonNilStrings returns values, or an empty slice when it is nil, so node data encodes lists as
+// [] rather than null for resources built without parsing.
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
 func edgeKey(kind, source, target string) string {
 	return fmt.Sprintf("%s:%s:%s", kind, source, target)
 }
 
+// addEdge records an edge implied by the OVN relationship named by reason. When several
+// relationships imply the same edge, the reasons accumulate in Data["reasons"] instead of the
+// later relationship replacing the earlier one.
+func addEdge(edges map[string]snapshot.Edge, kind, source, target, reason string) {
+	edgeID := edgeKey(kind, source, target)
+	edge, exists := edges[edgeID]
+	if !exists {
+		edge = snapshot.Edge{
+			ID:     edgeID,
+			Source: source,
+			Target: target,
+			Kind:   kind,
+			Data:   map[string]interface{}{"reasons": []string{}},
+		}
+	}
+	reasons, _ := edge.Data["reasons"].([]string)
+	if !slices.Contains(reasons, reason) {
+		edge.Data["reasons"] = append(reasons, reason)
+	}
+	edges[edgeID] = edge
+}
+
 func logProbeOutput(logger *slog.Logger, includeProbeOutput bool, command []string, output string) {
 	if includeProbeOutput {
 		// Intentionally log full probe output when explicitly enabled for debugging.
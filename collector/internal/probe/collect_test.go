@@ -3,11 +3,16 @@ package probe
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"log/slog"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
 )
 
 type fakeRunner struct {
@@ -47,6 +52,40 @@ func TestParseLogicalSwitchesNormalizesSingleQuotePayload(t *testing.T) {
 	}
 }
 
+func TestCollectSnapshotInsertsNbctlArgsBeforeSubcommand(t *testing.T) {
+	dbArg := "--db=unix:/var/run/ovn/ovnnb_db.sock"
+	outputs := map[string]string{}
+	for _, command := range [][]string{
+		logicalRouterCommand, logicalRouterPortCommand, logicalSwitchCommand, logicalSwitchPortCommand,
+		loadBalancerCommand, natCommand, aclCommand, qosCommand, dhcpOptionsCommand, staticRouteCommand, portGroupCommand,
+		gatewayChassisCommand, haChassisGroupCommand,
+	} {
+		withArgs := append([]string{"ovn-nbctl", dbArg}, command[1:]...)
+		outputs[strings.Join(withArgs, " ")] = `{"headings":["_uuid"],"data":[]}`
+	}
+	outputs[strings.Join(chassisCommand, " ")] = `{"headings":["_uuid","name","hostname"],"data":[]}`
+	outputs[strings.Join(portBindingCommand, " ")] = `{"headings":["_uuid","logical_port","chassis"],"data":[]}`
+	runner := &fakeRunner{outputs: outputs}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{
+		IncludePhysical: true,
+		NbctlArgs:       []string{dbArg},
+	})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected every NB command to carry the extra args and SB commands to be unchanged, got %#v", result.Warnings)
+	}
+
+	if got := strings.Join(withNbctlArgs(natCommand, []string{dbArg}), " "); got != "ovn-nbctl "+dbArg+" --format=json list NAT" {
+		t.Fatalf("unexpected command with extra args: %q", got)
+	}
+	if got := withNbctlArgs(natCommand, nil); strings.Join(got, " ") != strings.Join(natCommand, " ") {
+		t.Fatalf("expected no change without extra args, got %q", got)
+	}
+}
+
 func TestCollectSnapshotBuildsExpectedTopology(t *testing.T) {
 	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
 	runner := &fakeRunner{
@@ -55,6 +94,15 @@ func TestCollectSnapshotBuildsExpectedTopology(t *testing.T) {
 			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtos-red"]]}`,
 			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"red-net",["set",[["uuid","lsp-r"],["uuid","lsp-pod"]]]]]}`,
 			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-r"],"red-router-port","router",["map",[["router-port","rtos-red"]]]],[["uuid","lsp-pod"],"pod-a","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
 		},
 	}
 
@@ -101,6 +149,1170 @@ func TestCollectSnapshotBuildsExpectedTopology(t *testing.T) {
 	if edgeKinds["switch_to_port:ls-1:lsp-pod"] != "switch_to_port" {
 		t.Fatalf("expected switch_to_port edge for pod port, got %#v", edgeKinds)
 	}
+
+	if got := snapshot.Metadata.EdgeKindCounts["switch_to_port"]; got != 2 {
+		t.Fatalf("expected 2 switch_to_port edges in metadata, got %d (%#v)", got, snapshot.Metadata.EdgeKindCounts)
+	}
+	if got := snapshot.Metadata.EdgeKindCounts["router_to_switch"]; got != 1 {
+		t.Fatalf("expected 1 router_to_switch edge in metadata, got %d (%#v)", got, snapshot.Metadata.EdgeKindCounts)
+	}
+	if got := snapshot.Metadata.KindCounts["logical_switch_port"]; got != 2 {
+		t.Fatalf("expected 2 logical_switch_port nodes in metadata, got %d (%#v)", got, snapshot.Metadata.KindCounts)
+	}
+}
+
+func TestParseLoadBalancersDecodesVIPMapAndOptionalProtocol(t *testing.T) {
+	raw := `{"headings":["_uuid","name","vips","protocol"],"data":[` +
+		`[["uuid","lb-1"],"Service_default/web_TCP_cluster",["map",[["172.30.0.10:80","10.128.0.5:8080,10.128.0.6:8080"]]],"tcp"],` +
+		`[["uuid","lb-2"],"",["map",[]],["set",[]]]]}`
+
+	loadBalancers, normalized, err := ParseLoadBalancers(raw)
+	if err != nil {
+		t.Fatalf("parse load balancers failed: %v", err)
+	}
+	if normalized {
+		t.Fatalf("did not expect normalization for well-formed JSON")
+	}
+	if len(loadBalancers) != 2 {
+		t.Fatalf("expected two load balancers, got %d", len(loadBalancers))
+	}
+	if got := loadBalancers[0].VIPs["172.30.0.10:80"]; got != "10.128.0.5:8080,10.128.0.6:8080" {
+		t.Fatalf("unexpected vip backends: %q", got)
+	}
+	if loadBalancers[0].Protocol != "tcp" {
+		t.Fatalf("unexpected protocol: %q", loadBalancers[0].Protocol)
+	}
+	if len(loadBalancers[1].VIPs) != 0 || loadBalancers[1].Protocol != "" {
+		t.Fatalf("expected empty vips and protocol, got %#v", loadBalancers[1])
+	}
+}
+
+func TestParseSwitchAndRouterPortsAcrossSetSizes(t *testing.T) {
+	cases := map[string]struct {
+		ports string
+		want  []string
+	}{
+		"empty set":           {ports: `["set",[]]`, want: []string{}},
+		"one port as atom":    {ports: `["uuid","p-1"]`, want: []string{"p-1"}},
+		"one port as set":     {ports: `["set",[["uuid","p-1"]]]`, want: []string{"p-1"}},
+		"one named-uuid port": {ports: `["named-uuid","p-1"]`, want: []string{"p-1"}},
+		"many ports":          {ports: `["set",[["uuid","p-1"],["uuid","p-2"],["uuid","p-3"]]]`, want: []string{"p-1", "p-2", "p-3"}},
+	}
+
+	for name, tc := range cases {
+		raw := `{"headings":["_uuid","name","ports"],"data":[[["uuid","row-1"],"row",` + tc.ports + `]]}`
+
+		switches, _, err := ParseLogicalSwitches(raw)
+		if err != nil {
+			t.Fatalf("%s: parse switches failed: %v", name, err)
+		}
+		if len(switches) != 1 || !equalStrings(switches[0].PortUUIDs, tc.want) {
+			t.Fatalf("%s: expected switch ports %v, got %#v", name, tc.want, switches)
+		}
+
+		routers, _, err := ParseLogicalRouters(raw)
+		if err != nil {
+			t.Fatalf("%s: parse routers failed: %v", name, err)
+		}
+		if len(routers) != 1 || !equalStrings(routers[0].PortUUIDs, tc.want) {
+			t.Fatalf("%s: expected router ports %v, got %#v", name, tc.want, routers)
+		}
+	}
+}
+
+func equalStrings(got, want []string) bool {
+	if got == nil || len(got) != len(want) {
+		return false
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestParseLogicalSwitchPortsSplitsAddresses(t *testing.T) {
+	cases := map[string]struct {
+		addresses string
+		wantMACs  []string
+		wantIPs   []string
+	}{
+		"mac and ipv4":         {addresses: `"0a:58:0a:80:00:05 10.128.0.5"`, wantMACs: []string{"0a:58:0a:80:00:05"}, wantIPs: []string{"10.128.0.5"}},
+		"dual stack":           {addresses: `["set",["0a:58:0a:80:00:05 10.128.0.5 fd01:0:0:1::5"]]`, wantMACs: []string{"0a:58:0a:80:00:05"}, wantIPs: []string{"10.128.0.5", "fd01:0:0:1::5"}},
+		"multiple entries":     {addresses: `["set",["0a:58:0a:80:00:05 10.128.0.5","0a:58:0a:80:00:06 10.128.0.6","0a:58:0a:80:00:05 10.128.0.5"]]`, wantMACs: []string{"0a:58:0a:80:00:05", "0a:58:0a:80:00:06"}, wantIPs: []string{"10.128.0.5", "10.128.0.6"}},
+		"dynamic":              {addresses: `"dynamic"`, wantMACs: []string{}, wantIPs: []string{}},
+		"mac with dynamic ip":  {addresses: `"0a:58:0a:80:00:07 dynamic"`, wantMACs: []string{"0a:58:0a:80:00:07"}, wantIPs: []string{}},
+		"dynamic with ip":      {addresses: `"dynamic 10.128.0.8"`, wantMACs: []string{}, wantIPs: []string{"10.128.0.8"}},
+		"router":               {addresses: `"router"`, wantMACs: []string{}, wantIPs: []string{}},
+		"unknown and a static": {addresses: `["set",["unknown","0a:58:0a:80:00:09 10.128.0.9"]]`, wantMACs: []string{"0a:58:0a:80:00:09"}, wantIPs: []string{"10.128.0.9"}},
+		"empty":                {addresses: `["set",[]]`, wantMACs: []string{}, wantIPs: []string{}},
+	}
+
+	for name, tc := range cases {
+		raw := `{"headings":["_uuid","name","addresses"],"data":[[["uuid","lsp-1"],"pod-a",` + tc.addresses + `]]}`
+		ports, _, err := ParseLogicalSwitchPorts(raw)
+		if err != nil {
+			t.Fatalf("%s: parse switch ports failed: %v", name, err)
+		}
+		if len(ports) != 1 {
+			t.Fatalf("%s: expected one port, got %d", name, len(ports))
+		}
+		if !equalStrings(ports[0].MACs, tc.wantMACs) {
+			t.Fatalf("%s: expected MACs %v, got %#v", name, tc.wantMACs, ports[0].MACs)
+		}
+		if !equalStrings(ports[0].IPs, tc.wantIPs) {
+			t.Fatalf("%s: expected IPs %v, got %#v", name, tc.wantIPs, ports[0].IPs)
+		}
+	}
+
+	result := BuildSnapshotFromResources(Resources{
+		SwitchPorts: []LogicalSwitchPort{
+			{UUID: "lsp-1", Name: "pod-a", MACs: []string{"0a:58:0a:80:00:05"}, IPs: []string{"10.128.0.5"}},
+			{UUID: "lsp-2", Name: "pod-b"},
+		},
+	}, time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC), "worker-a")
+	data := map[string]map[string]interface{}{}
+	for _, node := range result.Nodes {
+		data[node.ID] = node.Data
+	}
+	if macs, _ := data["lsp-1"]["macs"].([]string); !equalStrings(macs, []string{"0a:58:0a:80:00:05"}) {
+		t.Fatalf("expected port node macs, got %#v", data["lsp-1"]["macs"])
+	}
+	if ips, _ := data["lsp-1"]["ips"].([]string); !equalStrings(ips, []string{"10.128.0.5"}) {
+		t.Fatalf("expected port node ips, got %#v", data["lsp-1"]["ips"])
+	}
+	if ips, _ := data["lsp-2"]["ips"].([]string); !equalStrings(ips, []string{}) {
+		t.Fatalf("expected empty ips for a port without addresses, got %#v", data["lsp-2"]["ips"])
+	}
+}
+
+func TestParseLogicalRouterPortsDecodesNetworksAndMAC(t *testing.T) {
+	raw := `{"headings":["_uuid","name","mac","networks"],"data":[` +
+		`[["uuid","lrp-1"],"rtos-worker-a","0a:58:0a:80:00:01",["set",["10.128.0.1/23","fd01:0:0:1::1/64"]]],` +
+		`[["uuid","lrp-2"],"rtoj-ovn_cluster_router","0a:58:64:40:00:01","100.64.0.1/16"]]}`
+
+	ports, _, err := ParseLogicalRouterPorts(raw)
+	if err != nil {
+		t.Fatalf("parse router ports failed: %v", err)
+	}
+	if len(ports) != 2 {
+		t.Fatalf("expected two router ports, got %d", len(ports))
+	}
+	if ports[0].MAC != "0a:58:0a:80:00:01" {
+		t.Fatalf("unexpected mac: %q", ports[0].MAC)
+	}
+	if strings.Join(ports[0].Networks, ",") != "10.128.0.1/23,fd01:0:0:1::1/64" {
+		t.Fatalf("unexpected networks: %v", ports[0].Networks)
+	}
+	if strings.Join(ports[1].Networks, ",") != "100.64.0.1/16" {
+		t.Fatalf("expected single network decoded from scalar, got %v", ports[1].Networks)
+	}
+
+	result := BuildSnapshotFromResources(Resources{
+		Routers:     []LogicalRouter{{UUID: "lr-1", Name: "ovn_cluster_router", PortUUIDs: []string{"lrp-1"}}},
+		RouterPorts: ports,
+	}, time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC), "worker-a")
+	routerPorts, ok := result.Nodes[0].Data["ports"].([]map[string]interface{})
+	if !ok || len(routerPorts) != 1 {
+		t.Fatalf("expected one router port in router data, got %#v", result.Nodes[0].Data["ports"])
+	}
+	if routerPorts[0]["mac"] != "0a:58:0a:80:00:01" || routerPorts[0]["name"] != "rtos-worker-a" {
+		t.Fatalf("unexpected router port data: %#v", routerPorts[0])
+	}
+}
+
+func TestApplyColumnAliasesResolvesAliasedHeading(t *testing.T) {
+	raw := `{"headings":["uuid","name","ports"],"data":[[["uuid","lr-1"],"ovn_cluster_router",["set",[]]]]}`
+
+	aliased, normalized, err := applyColumnAliases(raw, map[string]string{"uuid": "_uuid"})
+	if err != nil {
+		t.Fatalf("apply column aliases failed: %v", err)
+	}
+	if normalized {
+		t.Fatalf("expected well-formed payload not to need normalization")
+	}
+	routers, _, err := ParseLogicalRouters(aliased)
+	if err != nil {
+		t.Fatalf("parse routers failed: %v", err)
+	}
+	if len(routers) != 1 || routers[0].UUID != "lr-1" || routers[0].Name != "ovn_cluster_router" {
+		t.Fatalf("expected aliased uuid heading to populate UUID, got %#v", routers)
+	}
+
+	// The current heading wins when a payload carries both.
+	both := `{"headings":["_uuid","uuid","name","ports"],"data":[[["uuid","lr-1"],"legacy","ovn_cluster_router",["set",[]]]]}`
+	if got, _, _ := applyColumnAliases(both, map[string]string{"uuid": "_uuid"}); got != both {
+		t.Fatalf("expected payload with current heading to be left unchanged, got %s", got)
+	}
+}
+
+func TestCollectSnapshotAppliesColumnAliasesPerTable(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["uuid","name","ports"],"data":[[["uuid","lr-1"],"ovn_cluster_router",["set",[]]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","mac","networks"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC), CollectOptions{
+		Logger:        slog.New(slog.NewTextHandler(&bytes.Buffer{}, nil)),
+		ColumnAliases: map[string]map[string]string{"Logical_Router": {"uuid": "_uuid"}},
+	})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if len(result.Nodes) != 1 || result.Nodes[0].ID != "lr-1" {
+		t.Fatalf("expected router node keyed by aliased uuid, got %#v", result.Nodes)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %#v", result.Warnings)
+	}
+}
+
+func TestCollectSnapshotLinksLoadBalancerToEveryReferencingSwitchAndRouter(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports","load_balancer"],"data":[[["uuid","lr-1"],"cluster-router",["set",[]],["uuid","lb-1"]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports","load_balancer"],"data":[[["uuid","ls-1"],"node-a",["set",[]],["set",[["uuid","lb-1"],["uuid","lb-missing"]]]],[["uuid","ls-2"],"node-b",["set",[]],["uuid","lb-1"]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[[["uuid","lb-1"],"Service_default/web_TCP_cluster",["map",[["172.30.0.10:80","10.128.0.5:8080"]]],"tcp"]]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	snapshot, err := CollectSnapshot(context.Background(), runner, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if snapshot.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", snapshot.Metadata.SourceHealth, snapshot.Warnings)
+	}
+
+	var loadBalancerNodes int
+	for _, node := range snapshot.Nodes {
+		if node.Kind != "load_balancer" {
+			continue
+		}
+		loadBalancerNodes++
+		if node.ID != "lb-1" || node.Label != "Service_default/web_TCP_cluster" {
+			t.Fatalf("unexpected load balancer node: %#v", node)
+		}
+		vips, ok := node.Data["vips"].(map[string]string)
+		if !ok || vips["172.30.0.10:80"] != "10.128.0.5:8080" {
+			t.Fatalf("unexpected load balancer vips: %#v", node.Data["vips"])
+		}
+	}
+	if loadBalancerNodes != 1 {
+		t.Fatalf("expected exactly one load balancer node, got %d", loadBalancerNodes)
+	}
+
+	edgeIDs := []string{}
+	for _, edge := range snapshot.Edges {
+		edgeIDs = append(edgeIDs, edge.ID)
+	}
+	expectedEdgeIDs := []string{
+		"router_to_load_balancer:lr-1:lb-1",
+		"switch_to_load_balancer:ls-1:lb-1",
+		"switch_to_load_balancer:ls-2:lb-1",
+	}
+	if strings.Join(edgeIDs, ",") != strings.Join(expectedEdgeIDs, ",") {
+		t.Fatalf("unexpected edges: got=%v want=%v", edgeIDs, expectedEdgeIDs)
+	}
+}
+
+func TestCollectSnapshotLinksNATRulesToOwningRouter(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports","nat"],"data":[[["uuid","lr-1"],"GR_worker-a",["set",[]],["set",[["uuid","nat-snat"],["uuid","nat-dnat"]]]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "): `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[` +
+				`[["uuid","nat-snat"],"snat","172.16.0.10","10.128.0.0/14",["set",[]]],` +
+				`[["uuid","nat-dnat"],"dnat_and_snat","172.16.0.20","10.128.0.5","pod-a"]]}`,
+			strings.Join(dhcpOptionsCommand, " "):    `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):      `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):    `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):            `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):            `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	snapshot, err := CollectSnapshot(context.Background(), runner, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if snapshot.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", snapshot.Metadata.SourceHealth, snapshot.Warnings)
+	}
+
+	natNodes := map[string]map[string]interface{}{}
+	for _, node := range snapshot.Nodes {
+		if node.Kind == "nat" {
+			natNodes[node.ID] = node.Data
+		}
+	}
+	if len(natNodes) != 2 {
+		t.Fatalf("expected two nat nodes, got %#v", natNodes)
+	}
+	if natNodes["nat-dnat"]["type"] != "dnat_and_snat" || natNodes["nat-dnat"]["logicalPort"] != "pod-a" {
+		t.Fatalf("unexpected dnat_and_snat node data: %#v", natNodes["nat-dnat"])
+	}
+	if natNodes["nat-snat"]["externalIP"] != "172.16.0.10" || natNodes["nat-snat"]["logicalPort"] != "" {
+		t.Fatalf("unexpected snat node data: %#v", natNodes["nat-snat"])
+	}
+
+	edgeIDs := []string{}
+	for _, edge := range snapshot.Edges {
+		edgeIDs = append(edgeIDs, edge.ID)
+	}
+	expectedEdgeIDs := []string{
+		"router_to_nat:lr-1:nat-dnat",
+		"router_to_nat:lr-1:nat-snat",
+	}
+	if strings.Join(edgeIDs, ",") != strings.Join(expectedEdgeIDs, ",") {
+		t.Fatalf("unexpected edges: got=%v want=%v", edgeIDs, expectedEdgeIDs)
+	}
+}
+
+func TestCollectSnapshotDegradesWhenNATCommandFails(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports","nat"],"data":[[["uuid","lr-1"],"GR_worker-a",["set",[]],["uuid","nat-snat"]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"node-a",["set",[]]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+		errs: map[string]error{
+			strings.Join(natCommand, " "): errors.New("exec denied"),
+		},
+	}
+
+	snapshot, err := CollectSnapshot(context.Background(), runner, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed unexpectedly: %v", err)
+	}
+	if snapshot.Metadata.SourceHealth != "degraded" {
+		t.Fatalf("expected degraded source health, got %q", snapshot.Metadata.SourceHealth)
+	}
+	if len(snapshot.Warnings) != 1 || snapshot.Warnings[0].Code != "COMMAND_FAILED" || !strings.HasPrefix(snapshot.Warnings[0].Message, "NAT command failed") {
+		t.Fatalf("expected single NAT COMMAND_FAILED warning, got %#v", snapshot.Warnings)
+	}
+	if snapshot.Warnings[0].Severity != "error" {
+		t.Fatalf("expected COMMAND_FAILED to default to error severity, got %q", snapshot.Warnings[0].Severity)
+	}
+	if len(snapshot.Nodes) != 2 {
+		t.Fatalf("expected router and switch nodes despite NAT failure, got %#v", snapshot.Nodes)
+	}
+}
+
+func TestCollectSnapshotAttachesACLsToSwitch(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports","acls"],"data":[[["uuid","ls-1"],"node-a",["set",[]],["set",[["uuid","acl-allow"],["uuid","acl-drop"]]]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "): `{"headings":["_uuid","name","priority","direction","match","action"],"data":[` +
+				`[["uuid","acl-allow"],"NP:default:allow-web",1001,"to-lport","outport == @a123 && ip4 && tcp.dst == 80","allow-related"],` +
+				`[["uuid","acl-drop"],["set",[]],1000,"to-lport","outport == @a123_ingressDefaultDeny","drop"]]}`,
+			strings.Join(qosCommand, " "): `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshot(context.Background(), runner, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+
+	aclNodes := map[string]snapshot.Node{}
+	for _, node := range result.Nodes {
+		if node.Kind == "acl" {
+			aclNodes[node.ID] = node
+		}
+	}
+	if len(aclNodes) != 2 {
+		t.Fatalf("expected two acl nodes, got %#v", aclNodes)
+	}
+
+	allow := aclNodes["acl-allow"]
+	if allow.Label != "NP:default:allow-web" {
+		t.Fatalf("unexpected allow acl label: %q", allow.Label)
+	}
+	if allow.Data["priority"] != 1001 || allow.Data["stateful"] != true {
+		t.Fatalf("unexpected allow acl data: %#v", allow.Data)
+	}
+	if allow.Data["match"] != "outport == @a123 && ip4 && tcp.dst == 80" {
+		t.Fatalf("expected raw match in node data, got %#v", allow.Data["match"])
+	}
+
+	drop := aclNodes["acl-drop"]
+	if drop.Label != "to-lport 1000 drop" {
+		t.Fatalf("unexpected drop acl label: %q", drop.Label)
+	}
+	if drop.Data["stateful"] != false || drop.Data["direction"] != "to-lport" {
+		t.Fatalf("unexpected drop acl data: %#v", drop.Data)
+	}
+
+	if result.Metadata.EdgeKindCounts["switch_to_acl"] != 2 {
+		t.Fatalf("expected two switch_to_acl edges, got %#v", result.Metadata.EdgeKindCounts)
+	}
+}
+
+func TestCollectSnapshotAttachesQoSRulesToSwitch(t *testing.T) {
+	outputs := map[string]string{
+		strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+		strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+		strings.Join(logicalSwitchCommand, " "): `{"headings":["_uuid","name","ports","qos_rules"],"data":[` +
+			`[["uuid","ls-policed"],"node-a",["set",[]],["set",[["uuid","qos-police"],["uuid","qos-mark"]]]],` +
+			`[["uuid","ls-plain"],"node-b",["set",[]],["set",[]]]]}`,
+		strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+		strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+		strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+		strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+		strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+		strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+		strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+		strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+		strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		strings.Join(qosCommand, " "): `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[` +
+			`[["uuid","qos-police"],100,"from-lport","inport == \"pod-a\"",["map",[]],["map",[["burst",100],["rate",2000]]]],` +
+			`[["uuid","qos-mark"],90,"to-lport","ip4",["map",[["dscp",46]]],["map",[]]]]}`,
+	}
+
+	result, err := CollectSnapshot(context.Background(), &fakeRunner{outputs: outputs}, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+
+	qosNodes := map[string]snapshot.Node{}
+	for _, node := range result.Nodes {
+		if node.Kind == "qos" {
+			qosNodes[node.ID] = node
+		}
+	}
+	if len(qosNodes) != 2 {
+		t.Fatalf("expected two qos nodes, got %#v", qosNodes)
+	}
+	police := qosNodes["qos-police"]
+	if police.Label != "from-lport 100 rate 2000 burst 100" {
+		t.Fatalf("unexpected policing qos label: %q", police.Label)
+	}
+	if police.Data["rate"] != 2000 || police.Data["burst"] != 100 || police.Data["match"] != `inport == "pod-a"` {
+		t.Fatalf("unexpected policing qos data: %#v", police.Data)
+	}
+	mark := qosNodes["qos-mark"]
+	if _, ok := mark.Data["rate"]; ok || mark.Data["dscp"] != 46 || mark.Label != "to-lport 90 dscp 46" {
+		t.Fatalf("unexpected marking qos node: %#v", mark)
+	}
+
+	qosEdges := map[string]bool{}
+	for _, edge := range result.Edges {
+		if edge.Kind == "switch_to_qos" {
+			qosEdges[edge.Source+"->"+edge.Target] = true
+		}
+	}
+	if len(qosEdges) != 2 || !qosEdges["ls-policed->qos-police"] || !qosEdges["ls-policed->qos-mark"] {
+		t.Fatalf("expected ls-policed to link both qos rules and ls-plain none, got %v", qosEdges)
+	}
+
+	// An empty QoS table adds no nodes, edges or warnings.
+	outputs[strings.Join(qosCommand, " ")] = `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`
+	result, err = CollectSnapshot(context.Background(), &fakeRunner{outputs: outputs}, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if len(result.Warnings) != 0 || result.Metadata.KindCounts["qos"] != 0 || result.Metadata.EdgeKindCounts["switch_to_qos"] != 0 {
+		t.Fatalf("expected an empty QoS table to add nothing, got warnings %#v and counts %#v / %#v",
+			result.Warnings, result.Metadata.KindCounts, result.Metadata.EdgeKindCounts)
+	}
+}
+
+func TestCollectSnapshotLinksSwitchPortsToDHCPOptions(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options","dhcpv4_options","dhcpv6_options"],"data":[` +
+				`[["uuid","lsp-dual"],"vm-dual","",["map",[]],["uuid","dhcp-v4"],["uuid","dhcp-v6"]],` +
+				`[["uuid","lsp-v6"],"vm-v6","",["map",[]],["set",[]],["uuid","dhcp-v6"]],` +
+				`[["uuid","lsp-none"],"default_pod-a","",["map",[]],["set",[]],["set",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "): `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):          `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "): `{"headings":["_uuid","cidr","options"],"data":[` +
+				`[["uuid","dhcp-v4"],"10.0.0.0/24",["map",[["lease_time","3600"],["router","10.0.0.1"]]]],` +
+				`[["uuid","dhcp-v6"],"fd00::/64",["map",[["server_id","0a:58:0a:00:00:01"]]]]]}`,
+			strings.Join(portGroupCommand, " "):      `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):    `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):            `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):            `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+	if result.Metadata.KindCounts["dhcp_options"] != 2 {
+		t.Fatalf("expected two dhcp_options nodes, got %#v", result.Metadata.KindCounts)
+	}
+
+	targetsBySource := map[string][]string{}
+	for _, edge := range result.Edges {
+		if edge.Kind == "port_to_dhcp" {
+			targetsBySource[edge.Source] = append(targetsBySource[edge.Source], edge.Target)
+		}
+	}
+	if got := strings.Join(targetsBySource["lsp-dual"], ","); got != "dhcp-v4,dhcp-v6" {
+		t.Fatalf("expected dual-stack port linked to both options, got %q", got)
+	}
+	if got := strings.Join(targetsBySource["lsp-v6"], ","); got != "dhcp-v6" {
+		t.Fatalf("expected IPv6-only port linked to DHCPv6 options, got %q", got)
+	}
+	if _, ok := targetsBySource["lsp-none"]; ok {
+		t.Fatalf("expected no port_to_dhcp edge for port without DHCP options")
+	}
+
+	for _, node := range result.Nodes {
+		if node.ID == "dhcp-v6" && (node.Label != "fd00::/64" || node.Data["cidr"] != "fd00::/64") {
+			t.Fatalf("unexpected DHCPv6 options node: %#v", node)
+		}
+	}
+}
+
+func TestBuildSnapshotMergesDuplicateEdgeReasons(t *testing.T) {
+	result := BuildSnapshotFromResources(Resources{
+		Switches: []LogicalSwitch{{UUID: "ls-1", Name: "worker-a", PortUUIDs: []string{"lsp-1"}}},
+		SwitchPorts: []LogicalSwitchPort{{
+			UUID:              "lsp-1",
+			Name:              "vm-dual",
+			DHCPv4OptionsUUID: "dhcp-shared",
+			DHCPv6OptionsUUID: "dhcp-shared",
+		}},
+		DHCPOptions: []DHCPOptions{{UUID: "dhcp-shared", CIDR: "10.0.0.0/24"}},
+	}, time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC), "worker-a")
+
+	var dhcpEdges []map[string]interface{}
+	for _, edge := range result.Edges {
+		switch edge.Kind {
+		case "port_to_dhcp":
+			dhcpEdges = append(dhcpEdges, edge.Data)
+		case "switch_to_port":
+			if reasons, _ := edge.Data["reasons"].([]string); strings.Join(reasons, ",") != "Logical_Switch.ports" {
+				t.Fatalf("expected single-relationship edge to record one reason, got %#v", edge.Data)
+			}
+		}
+	}
+	if len(dhcpEdges) != 1 {
+		t.Fatalf("expected v4 and v6 references to the same row to merge into one edge, got %d", len(dhcpEdges))
+	}
+	reasons, _ := dhcpEdges[0]["reasons"].([]string)
+	if strings.Join(reasons, ",") != "Logical_Switch_Port.dhcpv4_options,Logical_Switch_Port.dhcpv6_options" {
+		t.Fatalf("expected both relationships recorded as reasons, got %#v", dhcpEdges[0])
+	}
+}
+
+func TestCollectSnapshotAttachesStaticRoutesToRouters(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "): `{"headings":["_uuid","name","ports","static_routes"],"data":[` +
+				`[["uuid","lr-1"],"GR_worker-a",["uuid","lrp-1"],["set",[["uuid","route-default"],["uuid","route-pod"]]]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtoe-GR_worker-a"]]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-ext"],"ext_worker-a",["uuid","lsp-1"]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[` +
+				`[["uuid","lsp-1"],"etor-GR_worker-a","router",["map",[["router-port","rtoe-GR_worker-a"]]]]]}`,
+			strings.Join(loadBalancerCommand, " "):   `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):            `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):            `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):            `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):    `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):      `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "): `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[` +
+				`[["uuid","route-default"],"0.0.0.0/0","192.168.1.1","rtoe-GR_worker-a"],` +
+				`[["uuid","route-pod"],"10.128.0.0/14","100.64.0.1",["set",[]]],` +
+				`[["uuid","route-orphan"],"10.0.0.0/8","100.64.0.2",["set",[]]]]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+	if result.Metadata.KindCounts["static_route"] != 2 {
+		t.Fatalf("expected two static_route nodes for routes referenced by a router, got %#v", result.Metadata.KindCounts)
+	}
+	if result.Metadata.EdgeKindCounts["router_to_route"] != 2 {
+		t.Fatalf("expected two router_to_route edges, got %#v", result.Metadata.EdgeKindCounts)
+	}
+
+	var portEdges []snapshot.Edge
+	for _, edge := range result.Edges {
+		if edge.Kind == "route_to_port" {
+			portEdges = append(portEdges, edge)
+		}
+	}
+	if len(portEdges) != 1 || portEdges[0].Source != "route-default" || portEdges[0].Target != "lsp-1" {
+		t.Fatalf("expected the default route linked to its output port, got %#v", portEdges)
+	}
+
+	for _, node := range result.Nodes {
+		if node.ID == "route-default" && (node.Label != "0.0.0.0/0 via 192.168.1.1" || node.Data["nexthop"] != "192.168.1.1" || node.Data["ipPrefix"] != "0.0.0.0/0") {
+			t.Fatalf("unexpected static route node: %#v", node)
+		}
+	}
+}
+
+func TestCollectSnapshotKeepsFirstRowForDuplicateSwitchUUID(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "): `{"headings":["_uuid","name","ports"],"data":[` +
+				`[["uuid","ls-1"],"worker-a",["set",[]]],` +
+				`[["uuid","ls-1"],"worker-a-stale",["set",[]]],` +
+				`[["uuid","ls-2"],"join",["set",[]]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.KindCounts["logical_switch"] != 2 {
+		t.Fatalf("expected two distinct switches, got %#v", result.Metadata.KindCounts)
+	}
+	for _, node := range result.Nodes {
+		if node.ID == "ls-1" && node.Label != "worker-a" {
+			t.Fatalf("expected the first row for ls-1 to be kept, got label %q", node.Label)
+		}
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != "DUPLICATE_UUID" || !strings.Contains(result.Warnings[0].Message, "ls-1") {
+		t.Fatalf("expected one DUPLICATE_UUID warning naming ls-1, got %#v", result.Warnings)
+	}
+	if result.Metadata.SourceHealth != "degraded" {
+		t.Fatalf("expected duplicate rows to degrade source health, got %q", result.Metadata.SourceHealth)
+	}
+}
+
+func TestCollectSnapshotLinksPortGroupsToPortsAndACLs(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"worker-a",["set",[["uuid","lsp-1"],["uuid","lsp-2"]]]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[` +
+				`[["uuid","lsp-1"],"demo_web-1","",["map",[]]],` +
+				`[["uuid","lsp-2"],"demo_web-2","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "): `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):          `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "): `{"headings":["_uuid","name","priority","direction","match","action"],"data":[` +
+				`[["uuid","acl-1"],"demo_allow-web",1001,"to-lport","outport == @a123","allow-related"]]}`,
+			strings.Join(qosCommand, " "):         `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "): `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "): `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "): `{"headings":["_uuid","name","ports","acls"],"data":[` +
+				`[["uuid","pg-1"],"a123_demo",["set",[["uuid","lsp-1"],["uuid","lsp-2"]]],["uuid","acl-1"]]]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+	if result.Metadata.KindCounts["port_group"] != 1 {
+		t.Fatalf("expected one port_group node, got %#v", result.Metadata.KindCounts)
+	}
+	if result.Metadata.EdgeKindCounts["portgroup_to_port"] != 2 || result.Metadata.EdgeKindCounts["portgroup_to_acl"] != 1 {
+		t.Fatalf("expected two port edges and one ACL edge, got %#v", result.Metadata.EdgeKindCounts)
+	}
+	for _, node := range result.Nodes {
+		if node.Kind == "port_group" && node.Data["name"] != "a123_demo" {
+			t.Fatalf("expected port group name in node data, got %#v", node.Data)
+		}
+	}
+}
+
+func TestCollectSnapshotGroupsPortsAndSwitchesByNamespace(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "): `{"headings":["_uuid","name","ports"],"data":[` +
+				`[["uuid","ls-1"],"worker-a",["set",[["uuid","lsp-1"],["uuid","lsp-2"],["uuid","lsp-3"],["uuid","lsp-5"]]]],` +
+				`[["uuid","ls-2"],"join",["uuid","lsp-4"]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[` +
+				`[["uuid","lsp-1"],"demo_web-1","",["map",[]]],` +
+				`[["uuid","lsp-2"],"kube-system_dns-1","",["map",[]]],` +
+				`[["uuid","lsp-3"],"stor-worker-a","router",["map",[["router-port","rtos-worker-a"]]]],` +
+				`[["uuid","lsp-4"],"jtor-GR_worker-a","router",["map",[["router-port","rtoj-GR_worker-a"]]]],` +
+				`[["uuid","lsp-5"],"k8s-worker-a","",["map",[]]],` +
+				`[["uuid","lsp-6"],"demo_web-2","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "):   `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):            `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):            `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):            `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):    `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):    `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):      `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+
+	// ls-1 serves two namespaces, so it belongs to both of their groups and not to unscoped;
+	// lsp-6 has no switch and only joins its namespace group.
+	want := []snapshot.Group{
+		{ID: "namespace:demo", Label: "demo", NodeIDs: []string{"ls-1", "lsp-1", "lsp-6"}},
+		{ID: "namespace:kube-system", Label: "kube-system", NodeIDs: []string{"ls-1", "lsp-2"}},
+		{ID: "unscoped", Label: "unscoped", NodeIDs: []string{"ls-2", "lsp-3", "lsp-4", "lsp-5"}},
+	}
+	if !reflect.DeepEqual(result.Groups, want) {
+		t.Fatalf("expected groups %#v, got %#v", want, result.Groups)
+	}
+
+	payload, err := json.Marshal(BuildSnapshot(nil, nil, nil, nil, time.Now(), "worker-a"))
+	if err != nil {
+		t.Fatalf("marshal empty snapshot: %v", err)
+	}
+	if !strings.Contains(string(payload), `"groups":[]`) {
+		t.Fatalf("expected an empty topology to serialize an empty groups array, got %s", payload)
+	}
+}
+
+func TestCollectSnapshotLinksGatewayChassisToRouterPorts(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "): `{"headings":["_uuid","name","ports"],"data":[` +
+				`[["uuid","lr-1"],"ovn_cluster_router",["set",[["uuid","lrp-1"],["uuid","lrp-2"]]]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","gateway_chassis","ha_chassis_group"],"data":[` +
+				`[["uuid","lrp-1"],"rtoe-GR_worker-a",["set",[["uuid","gwc-1"],["uuid","gwc-2"]]],["set",[]]],` +
+				`[["uuid","lrp-2"],"rtoj-ovn_cluster_router",["set",[]],["uuid","hag-1"]]]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "): `{"headings":["_uuid","name","chassis_name","priority"],"data":[` +
+				`[["uuid","gwc-1"],"rtoe-GR_worker-a_worker-a","worker-a",20],` +
+				`[["uuid","gwc-2"],"rtoe-GR_worker-a_worker-b","worker-b",10]]}`,
+			strings.Join(haChassisGroupCommand, " "): `{"headings":["_uuid","name","ha_chassis"],"data":[` +
+				`[["uuid","hag-1"],"external-gw",["set",[["uuid","hac-1"],["uuid","hac-2"]]]]]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+	if result.Metadata.KindCounts["gateway_chassis"] != 2 || result.Metadata.KindCounts["ha_chassis_group"] != 1 {
+		t.Fatalf("expected two gateway_chassis and one ha_chassis_group node, got %#v", result.Metadata.KindCounts)
+	}
+	if result.Metadata.EdgeKindCounts["routerport_to_gateway"] != 3 {
+		t.Fatalf("expected three routerport_to_gateway edges, got %#v", result.Metadata.EdgeKindCounts)
+	}
+	for _, node := range result.Nodes {
+		if node.ID == "gwc-1" && (node.Data["priority"] != 20 || node.Data["chassisName"] != "worker-a") {
+			t.Fatalf("expected gateway chassis priority and chassis name in node data, got %#v", node.Data)
+		}
+	}
+	if result.Metadata.KindCounts["logical_router_port"] != 2 || result.Metadata.EdgeKindCounts["router_to_port"] != 2 {
+		t.Fatalf("expected both gateway router ports linked to their router, got %#v / %#v", result.Metadata.KindCounts, result.Metadata.EdgeKindCounts)
+	}
+	gotEndpoints := map[string]bool{}
+	for _, edge := range result.Edges {
+		if edge.Kind == "routerport_to_gateway" {
+			gotEndpoints[edge.Source+"->"+edge.Target] = true
+		}
+	}
+	wantEndpoints := map[string]bool{"lrp-1->gwc-1": true, "lrp-1->gwc-2": true, "lrp-2->hag-1": true}
+	if !reflect.DeepEqual(gotEndpoints, wantEndpoints) {
+		t.Fatalf("expected gateway edges to start at their router port, got %#v", gotEndpoints)
+	}
+}
+
+func TestCollectSnapshotEmptyGatewayTablesAddNothing(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","lr-1"],"ovn_cluster_router",["uuid","lrp-1"]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","gateway_chassis","ha_chassis_group"],"data":[[["uuid","lrp-1"],"rtos-worker-a",["set",[]],["set",[]]]]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected no warnings for empty gateway tables, got %#v", result.Warnings)
+	}
+	if result.Metadata.KindCounts["gateway_chassis"] != 0 || result.Metadata.KindCounts["ha_chassis_group"] != 0 {
+		t.Fatalf("expected no gateway nodes, got %#v", result.Metadata.KindCounts)
+	}
+	if result.Metadata.EdgeKindCounts["routerport_to_gateway"] != 0 {
+		t.Fatalf("expected no gateway edges, got %#v", result.Metadata.EdgeKindCounts)
+	}
+}
+
+func TestCollectSnapshotBindsSwitchPortsToChassisWhenPhysicalEnabled(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"node-a",["set",[["uuid","lsp-1"],["uuid","lsp-2"]]]]]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-1"],"default_pod-a","",["map",[]]],[["uuid","lsp-2"],"default_pod-b","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(chassisCommand, " "):           `{"headings":["_uuid","name","hostname"],"data":[[["uuid","ch-1"],"6b2d7c1e","worker-a.example.com"]]}`,
+			strings.Join(portBindingCommand, " "): `{"headings":["_uuid","logical_port","type","chassis"],"data":[` +
+				`[["uuid","pb-1"],"default_pod-a","",["uuid","ch-1"]],` +
+				`[["uuid","pb-2"],"default_pod-b","",["set",[]]]]}`,
+		},
+	}
+
+	logical, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect logical snapshot failed: %v", err)
+	}
+	if logical.Metadata.KindCounts["chassis"] != 0 || logical.Metadata.EdgeKindCounts["port_binding"] != 0 {
+		t.Fatalf("expected no physical topology without IncludePhysical, got %#v %#v", logical.Metadata.KindCounts, logical.Metadata.EdgeKindCounts)
+	}
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{IncludePhysical: true})
+	if err != nil {
+		t.Fatalf("collect physical snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+
+	var chassis snapshot.Node
+	for _, node := range result.Nodes {
+		if node.Kind == "chassis" {
+			chassis = node
+		}
+	}
+	if chassis.ID != "ch-1" || chassis.Label != "worker-a.example.com" {
+		t.Fatalf("unexpected chassis node: %#v", chassis)
+	}
+
+	bindings := []snapshot.Edge{}
+	for _, edge := range result.Edges {
+		if edge.Kind == "port_binding" {
+			bindings = append(bindings, edge)
+		}
+	}
+	if len(bindings) != 1 || bindings[0].Source != "lsp-1" || bindings[0].Target != "ch-1" {
+		t.Fatalf("expected only the bound port to link to its chassis, got %#v", bindings)
+	}
+}
+
+func TestCollectSnapshotLinksPeeredRoutersOnce(t *testing.T) {
+	runner := &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "): `{"headings":["_uuid","name","ports"],"data":[` +
+				`[["uuid","lr-cluster"],"ovn_cluster_router",["set",[["uuid","lrp-rtoj"]]]],` +
+				`[["uuid","lr-gw"],"GR_worker-a",["set",[["uuid","lrp-jtor"],["uuid","lrp-dangling"]]]]]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","mac","networks","peer"],"data":[` +
+				`[["uuid","lrp-rtoj"],"rtoj-ovn_cluster_router","0a:58:64:40:00:01",["set",["100.64.0.1/16"]],"jtor-GR_worker-a"],` +
+				`[["uuid","lrp-jtor"],"jtor-GR_worker-a","0a:58:64:40:00:02",["set",["100.64.0.2/16"]],"rtoj-ovn_cluster_router"],` +
+				`[["uuid","lrp-dangling"],"rtoj-dangling","0a:58:64:40:00:03","100.64.0.3/16","jtor-missing"]]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+		},
+	}
+
+	result, err := CollectSnapshot(context.Background(), runner, "worker-a", time.Now())
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+
+	peerings := []snapshot.Edge{}
+	for _, edge := range result.Edges {
+		if edge.Kind == "router_to_router" {
+			peerings = append(peerings, edge)
+		}
+	}
+	if len(peerings) != 1 {
+		t.Fatalf("expected exactly one router_to_router edge for the symmetric peers, got %#v", peerings)
+	}
+	if peerings[0].Source != "lr-cluster" || peerings[0].Target != "lr-gw" {
+		t.Fatalf("expected edge endpoints sorted as lr-cluster -> lr-gw, got %s -> %s", peerings[0].Source, peerings[0].Target)
+	}
+}
+
+// blockingRunner simulates a hung exec that only returns once its context is done.
+type blockingRunner struct{}
+
+func (blockingRunner) Run(ctx context.Context, _ []string) (string, error) {
+	<-ctx.Done()
+	return "", ctx.Err()
+}
+
+func TestSnapshotCollectorTimesOutHungProbe(t *testing.T) {
+	collector := NewSnapshotCollector(StaticRunnerFactory{Runner: blockingRunner{}}, nil, false)
+	collector.SetTimeout(20 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := collector.Collect(context.Background(), "worker-a")
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected deadline exceeded error, got %v", err)
+		}
+		if !strings.Contains(err.Error(), "timed out after 20ms") {
+			t.Fatalf("expected timeout-specific error message, got %q", err.Error())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("collection did not honor the probe timeout")
+	}
+}
+
+func TestSnapshotCollectorStampsGeneratedAtFromClock(t *testing.T) {
+	generatedAt := time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)
+	collector := NewSnapshotCollector(StaticRunnerFactory{Runner: &fakeRunner{outputs: map[string]string{
+		strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+		strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","mac","networks"],"data":[]}`,
+		strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+		strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+		strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+		strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+		strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+		strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+		strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+		strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+		strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+		strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+	}}}, nil, false)
+	collector.SetClock(clock.NewFake(generatedAt))
+
+	payload, err := collector.Collect(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if !payload.Metadata.GeneratedAt.Equal(generatedAt) {
+		t.Fatalf("expected generatedAt %s from fake clock, got %s", generatedAt, payload.Metadata.GeneratedAt)
+	}
+}
+
+func TestSnapshotCollectorTracksResourceStatus(t *testing.T) {
+	fakeClock := clock.NewFake(time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC))
+	runner := &fakeRunner{outputs: map[string]string{
+		strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+		strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","mac","networks"],"data":[]}`,
+		strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
+		strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+		strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+		strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+		strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+		strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+		strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+		strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+		strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+		strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+	}}
+	collector := NewSnapshotCollector(StaticRunnerFactory{Runner: runner}, nil, false)
+	collector.SetClock(fakeClock)
+
+	firstProbe := fakeClock.Now()
+	if _, err := collector.Collect(context.Background(), "worker-a"); err != nil {
+		t.Fatalf("first collect failed: %v", err)
+	}
+
+	fakeClock.Step(time.Minute)
+	secondProbe := fakeClock.Now()
+	runner.errs = map[string]error{
+		strings.Join(logicalSwitchPortCommand, " "): errors.New("connection refused"),
+	}
+	if _, err := collector.Collect(context.Background(), "worker-a"); err != nil {
+		t.Fatalf("second collect failed: %v", err)
+	}
+
+	statuses := map[string]snapshot.ResourceStatus{}
+	for _, status := range collector.ResourceStatuses() {
+		statuses[status.Resource] = status
+	}
+	if len(statuses) != 13 {
+		t.Fatalf("expected 13 tracked resources, got %d: %#v", len(statuses), statuses)
+	}
+
+	lsp := statuses["Logical_Switch_Port"]
+	if lsp.LastSuccessAt == nil || !lsp.LastSuccessAt.Equal(firstProbe) {
+		t.Fatalf("expected Logical_Switch_Port last success at %s, got %v", firstProbe, lsp.LastSuccessAt)
+	}
+	if lsp.LastFailureAt == nil || !lsp.LastFailureAt.Equal(secondProbe) {
+		t.Fatalf("expected Logical_Switch_Port last failure at %s, got %v", secondProbe, lsp.LastFailureAt)
+	}
+	if !strings.Contains(lsp.LastFailure, "connection refused") {
+		t.Fatalf("expected failure message to carry the command error, got %q", lsp.LastFailure)
+	}
+
+	router := statuses["Logical_Router"]
+	if router.LastSuccessAt == nil || !router.LastSuccessAt.Equal(secondProbe) {
+		t.Fatalf("expected Logical_Router last success at %s, got %v", secondProbe, router.LastSuccessAt)
+	}
+	if router.LastFailureAt != nil || router.LastFailure != "" {
+		t.Fatalf("expected no Logical_Router failure, got %#v", router)
+	}
+}
+
+func TestBuildSnapshotFromParsedResources(t *testing.T) {
+	now := time.Date(2026, 2, 14, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+
+	snapshot := BuildSnapshot(
+		[]LogicalRouter{{UUID: "lr-1", Name: "cluster-router", PortUUIDs: []string{"lrp-1"}}},
+		[]LogicalRouterPort{{UUID: "lrp-1", Name: "rtos-red"}},
+		[]LogicalSwitch{{UUID: "ls-1", Name: "red-net", PortUUIDs: []string{"lsp-r", "lsp-pod"}}},
+		[]LogicalSwitchPort{
+			{UUID: "lsp-r", Name: "red-router-port", Type: "router", Options: map[string]string{"router-port": "rtos-red"}},
+			{UUID: "lsp-pod", Name: "pod-a", Options: map[string]string{}},
+		},
+		now,
+		"worker-a",
+	)
+
+	if snapshot.Metadata.NodeName != "worker-a" {
+		t.Fatalf("unexpected node name: %q", snapshot.Metadata.NodeName)
+	}
+	if snapshot.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source, got %q", snapshot.Metadata.SourceHealth)
+	}
+	if !snapshot.Metadata.GeneratedAt.Equal(now) || snapshot.Metadata.GeneratedAt.Location() != time.UTC {
+		t.Fatalf("expected generatedAt normalized to UTC, got %v", snapshot.Metadata.GeneratedAt)
+	}
+	if snapshot.Warnings == nil || len(snapshot.Warnings) != 0 {
+		t.Fatalf("expected empty warnings, got %#v", snapshot.Warnings)
+	}
+	if len(snapshot.Nodes) != 4 {
+		t.Fatalf("expected 4 nodes, got %d", len(snapshot.Nodes))
+	}
+
+	edgeIDs := []string{}
+	for _, edge := range snapshot.Edges {
+		edgeIDs = append(edgeIDs, edge.ID)
+	}
+	expectedEdgeIDs := []string{
+		"router_to_switch:lr-1:ls-1",
+		"switch_to_port:ls-1:lsp-pod",
+		"switch_to_port:ls-1:lsp-r",
+	}
+	if strings.Join(edgeIDs, ",") != strings.Join(expectedEdgeIDs, ",") {
+		t.Fatalf("unexpected edges: got=%v want=%v", edgeIDs, expectedEdgeIDs)
+	}
 }
 
 func TestCollectSnapshotDegradesOnCommandFailure(t *testing.T) {
@@ -109,6 +1321,15 @@ func TestCollectSnapshotDegradesOnCommandFailure(t *testing.T) {
 			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[]}`,
 			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[]}`,
 			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
 		},
 		errs: map[string]error{
 			strings.Join(logicalRouterCommand, " "): errors.New("exec denied"),
@@ -136,6 +1357,15 @@ func TestCollectSnapshotWithOptionsLogsProbeOutputWhenEnabled(t *testing.T) {
 			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtos-red"]]}`,
 			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"red-net",["set",[["uuid","lsp-r"],["uuid","lsp-pod"]]]]]}`,
 			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-r"],"red-router-port","router",["map",[["router-port","rtos-red"]]]],[["uuid","lsp-pod"],"pod-a","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
 		},
 	}
 
@@ -166,6 +1396,15 @@ func TestCollectSnapshotWithOptionsOmitsProbeOutputByDefault(t *testing.T) {
 			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtos-red"]]}`,
 			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"red-net",["set",[["uuid","lsp-r"],["uuid","lsp-pod"]]]]]}`,
 			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-r"],"red-router-port","router",["map",[["router-port","rtos-red"]]]],[["uuid","lsp-pod"],"pod-a","",["map",[]]]]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
 		},
 	}
 
@@ -2,18 +2,53 @@ package probe
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
 	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// tracer resolves spans through the global provider, which is a no-op unless main installs one.
+var tracer = otel.Tracer("github.com/dlbewley/ovn-recon/collector/internal/probe")
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
 // RunnerFactory resolves a runner for a specific node target.
 type RunnerFactory interface {
 	RunnerForNode(nodeName string) (Runner, error)
 }
 
+// NodeDiscoverer lists nodes that can be probed live.
+type NodeDiscoverer interface {
+	ListNodes(ctx context.Context) ([]string, error)
+}
+
+// ReadinessChecker reports whether the probe backend can currently be reached.
+type ReadinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// NodeAddressResolver reports the IP addresses of a node, IPv4 and IPv6 on dual-stack nodes.
+type NodeAddressResolver interface {
+	NodeAddresses(ctx context.Context, nodeName string) ([]string, error)
+}
+
 // StaticRunnerFactory always returns the same runner.
 type StaticRunnerFactory struct {
 	Runner Runner
@@ -27,12 +62,24 @@ func (f StaticRunnerFactory) RunnerForNode(_ string) (Runner, error) {
 	return f.Runner, nil
 }
 
+// DefaultProbeTimeout bounds a single live collection so a hung exec cannot hold a request open.
+const DefaultProbeTimeout = 15 * time.Second
+
 // SnapshotCollector executes live probe collection for a requested node.
 type SnapshotCollector struct {
 	runnerFactory      RunnerFactory
 	logger             *slog.Logger
 	includeProbeOutput bool
-	now                func() time.Time
+	includePhysical    bool
+	detectCycles       bool
+	columnAliases      map[string]map[string]string
+	nbctlArgs          []string
+	limits             GraphLimits
+	timeout            time.Duration
+	clock              clock.Clock
+
+	statusMu sync.Mutex
+	statuses map[string]snapshot.ResourceStatus
 }
 
 // NewSnapshotCollector constructs a live snapshot collector.
@@ -44,25 +91,112 @@ func NewSnapshotCollector(factory RunnerFactory, logger *slog.Logger, includePro
 		runnerFactory:      factory,
 		logger:             logger,
 		includeProbeOutput: includeProbeOutput,
-		now:                time.Now,
+		timeout:            DefaultProbeTimeout,
+		clock:              clock.Real{},
+		statuses:           map[string]snapshot.ResourceStatus{},
+	}
+}
+
+// SetIncludePhysical enables collection of OVN SB chassis and port bindings alongside the
+// logical NB tables.
+func (c *SnapshotCollector) SetIncludePhysical(includePhysical bool) {
+	c.includePhysical = includePhysical
+}
+
+// SetColumnAliases sets the per-table heading aliases applied before parsing probe output.
+// See CollectOptions.ColumnAliases.
+func (c *SnapshotCollector) SetColumnAliases(aliases map[string]map[string]string) {
+	c.columnAliases = aliases
+}
+
+// SetNbctlArgs inserts args after ovn-nbctl in every NB probe command, e.g. to select the NB
+// database with --db.
+func (c *SnapshotCollector) SetNbctlArgs(args []string) {
+	c.nbctlArgs = args
+}
+
+// SetGraphLimits caps the nodes and edges of collected snapshots. Larger graphs are truncated
+// with a SNAPSHOT_TRUNCATED warning.
+func (c *SnapshotCollector) SetGraphLimits(limits GraphLimits) {
+	c.limits = limits
+}
+
+// SetTimeout bounds each live collection, including every probe command it runs. A
+// non-positive timeout leaves collection bounded only by the caller's context.
+func (c *SnapshotCollector) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// SetClock replaces the time source stamped into GeneratedAt. A nil clock restores the wall clock.
+func (c *SnapshotCollector) SetClock(clk clock.Clock) {
+	if clk == nil {
+		clk = clock.Real{}
 	}
+	c.clock = clk
+}
+
+// SetDetectCycles enables the routing cycle check on collected snapshots.
+func (c *SnapshotCollector) SetDetectCycles(detectCycles bool) {
+	c.detectCycles = detectCycles
+}
+
+// ListNodes returns nodes discovered by the runner factory, or none when it cannot discover nodes.
+func (c *SnapshotCollector) ListNodes(ctx context.Context) ([]string, error) {
+	discoverer, ok := c.runnerFactory.(NodeDiscoverer)
+	if !ok {
+		return []string{}, nil
+	}
+	return discoverer.ListNodes(ctx)
+}
+
+// Ready reports whether the runner factory can reach its backend. Factories that cannot check
+// connectivity are always ready.
+func (c *SnapshotCollector) Ready(ctx context.Context) error {
+	checker, ok := c.runnerFactory.(ReadinessChecker)
+	if !ok {
+		return nil
+	}
+	return checker.Ready(ctx)
 }
 
 // Collect builds a snapshot for a specific node by running probe commands.
-func (c *SnapshotCollector) Collect(ctx context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error) {
+func (c *SnapshotCollector) Collect(ctx context.Context, nodeName string) (payload snapshot.LogicalTopologySnapshot, err error) {
+	ctx, span := tracer.Start(ctx, "probe.Collect", trace.WithAttributes(attribute.String("ovn.node", nodeName)))
+	defer func() { endSpan(span, err) }()
+
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
 	runner, err := c.runnerFactory.RunnerForNode(nodeName)
 	if err != nil {
 		return snapshot.LogicalTopologySnapshot{}, fmt.Errorf("resolve probe runner: %w", err)
 	}
 
 	start := time.Now()
-	logger := c.logger.With("node", nodeName)
+	logger := requestid.Logger(ctx, c.logger).With("node", nodeName)
 	logger.Info("collecting logical topology snapshot")
-	payload, err := CollectSnapshotWithOptions(ctx, runner, nodeName, c.now(), CollectOptions{
+	payload, err = CollectSnapshotWithOptions(ctx, runner, nodeName, c.clock.Now(), CollectOptions{
 		Logger:             logger.With("subcomponent", "probe"),
 		IncludeProbeOutput: c.includeProbeOutput,
+		IncludePhysical:    c.includePhysical,
+		DetectCycles:       c.detectCycles,
+		ColumnAliases:      c.columnAliases,
+		NbctlArgs:          c.nbctlArgs,
+		Limits:             c.limits,
+		ObserveResource:    c.observeResource,
 	})
+	if err == nil {
+		payload.Metadata.NodeAddresses = c.nodeAddresses(ctx, logger, nodeName)
+	}
 	durationMs := time.Since(start).Milliseconds()
+	if err == nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		// Probe commands that hit the deadline only degrade the snapshot, so report the
+		// timeout here and let the caller fall back instead of serving a partial graph.
+		err = fmt.Errorf("live probe collection timed out after %s: %w", c.timeout, context.DeadlineExceeded)
+	}
 	if err != nil {
 		logger.Error("live probe collection failed", "durationMs", durationMs, "error", err)
 		return snapshot.LogicalTopologySnapshot{}, err
@@ -76,5 +210,53 @@ func (c *SnapshotCollector) Collect(ctx context.Context, nodeName string) (snaps
 		"warningCount", len(payload.Warnings),
 		"sourceHealth", payload.Metadata.SourceHealth,
 	)
+	span.SetAttributes(
+		attribute.Int("ovn.snapshot.node_count", len(payload.Nodes)),
+		attribute.Int("ovn.snapshot.edge_count", len(payload.Edges)),
+		attribute.String("ovn.snapshot.source_health", payload.Metadata.SourceHealth),
+	)
 	return payload, nil
 }
+
+// nodeAddresses resolves the node's addresses for the snapshot metadata when the runner factory
+// can. A lookup failure is only logged: the snapshot can still be served by node name.
+func (c *SnapshotCollector) nodeAddresses(ctx context.Context, logger *slog.Logger, nodeName string) []string {
+	resolver, ok := c.runnerFactory.(NodeAddressResolver)
+	if !ok {
+		return nil
+	}
+	addresses, err := resolver.NodeAddresses(ctx, nodeName)
+	if err != nil {
+		logger.Warn("failed to resolve node addresses", "error", err)
+		return nil
+	}
+	return addresses
+}
+
+// ResourceStatuses returns the last success and failure recorded for each OVN table across live
+// collections, sorted by table name.
+func (c *SnapshotCollector) ResourceStatuses() []snapshot.ResourceStatus {
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	statuses := make([]snapshot.ResourceStatus, 0, len(c.statuses))
+	for _, status := range c.statuses {
+		statuses = append(statuses, status)
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Resource < statuses[j].Resource })
+	return statuses
+}
+
+func (c *SnapshotCollector) observeResource(resource string, err error) {
+	now := c.clock.Now().UTC()
+	c.statusMu.Lock()
+	defer c.statusMu.Unlock()
+	status := c.statuses[resource]
+	status.Resource = resource
+	if err != nil {
+		status.LastFailureAt = &now
+		status.LastFailure = err.Error()
+	} else {
+		status.LastSuccessAt = &now
+	}
+	c.statuses[resource] = status
+}
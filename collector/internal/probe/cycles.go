@@ -0,0 +1,140 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// routingKinds are the node kinds considered when looking for routing cycles.
+var routingKinds = map[string]bool{
+	"logical_router": true,
+	"logical_switch": true,
+}
+
+// findRoutingCycles returns the node IDs of every cycle among routers and switches, one sorted
+// slice per group of nodes joined by cycles. Router/switch adjacency is treated as undirected:
+// buildGraph always draws router_to_switch from the router and router_to_router from the lower
+// ID, so the directed graph never contains a cycle. Each linked pair counts once, however many
+// ports connect it. Results are ordered by their first ID.
+func findRoutingCycles(nodes []snapshot.Node, edges []snapshot.Edge) [][]string {
+	kindByID := make(map[string]string, len(nodes))
+	for _, node := range nodes {
+		kindByID[node.ID] = node.Kind
+	}
+
+	type link struct{ a, b string }
+	links := map[link]bool{}
+	adjacency := map[string][]string{}
+	for _, edge := range edges {
+		if !routingKinds[kindByID[edge.Source]] || !routingKinds[kindByID[edge.Target]] || edge.Source == edge.Target {
+			continue
+		}
+		a, b := edge.Source, edge.Target
+		if b < a {
+			a, b = b, a
+		}
+		if links[link{a, b}] {
+			continue
+		}
+		links[link{a, b}] = true
+		adjacency[a] = append(adjacency[a], b)
+		adjacency[b] = append(adjacency[b], a)
+	}
+
+	// A link lies on a cycle unless it is a bridge. Bridges are found with a depth-first search
+	// comparing each node's discovery index to the lowest index reachable from its subtree.
+	index := 0
+	indexes := map[string]int{}
+	lowlinks := map[string]int{}
+	bridges := map[link]bool{}
+
+	var visit func(id, parent string)
+	visit = func(id, parent string) {
+		indexes[id] = index
+		lowlinks[id] = index
+		index++
+		for _, next := range adjacency[id] {
+			if next == parent {
+				continue
+			}
+			if _, seen := indexes[next]; seen {
+				lowlinks[id] = min(lowlinks[id], indexes[next])
+				continue
+			}
+			visit(next, id)
+			lowlinks[id] = min(lowlinks[id], lowlinks[next])
+			if lowlinks[next] > indexes[id] {
+				a, b := id, next
+				if b < a {
+					a, b = b, a
+				}
+				bridges[link{a, b}] = true
+			}
+		}
+	}
+
+	ids := make([]string, 0, len(adjacency))
+	for id := range adjacency {
+		ids = append(ids, id)
+		sort.Strings(adjacency[id])
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, seen := indexes[id]; !seen {
+			visit(id, "")
+		}
+	}
+
+	// Group the nodes joined by non-bridge links.
+	groupOf := map[string]string{}
+	var find func(id string) string
+	find = func(id string) string {
+		if groupOf[id] == id {
+			return id
+		}
+		groupOf[id] = find(groupOf[id])
+		return groupOf[id]
+	}
+	for pair := range links {
+		if bridges[pair] {
+			continue
+		}
+		for _, id := range []string{pair.a, pair.b} {
+			if _, ok := groupOf[id]; !ok {
+				groupOf[id] = id
+			}
+		}
+		if rootA, rootB := find(pair.a), find(pair.b); rootA != rootB {
+			groupOf[rootA] = rootB
+		}
+	}
+
+	members := map[string][]string{}
+	for id := range groupOf {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+	cycles := make([][]string, 0, len(members))
+	for _, component := range members {
+		sort.Strings(component)
+		cycles = append(cycles, component)
+	}
+	sort.Slice(cycles, func(i, j int) bool {
+		return cycles[i][0] < cycles[j][0]
+	})
+	return cycles
+}
+
+func routingCycleWarnings(nodes []snapshot.Node, edges []snapshot.Edge) []snapshot.Warning {
+	warnings := []snapshot.Warning{}
+	for _, cycle := range findRoutingCycles(nodes, edges) {
+		warnings = append(warnings, snapshot.NewWarning(
+			snapshot.WarningTopologyCycle,
+			fmt.Sprintf("Routing cycle detected among nodes: %s", strings.Join(cycle, ", ")),
+		))
+	}
+	return warnings
+}
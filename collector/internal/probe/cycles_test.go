@@ -0,0 +1,101 @@
+package probe
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// cycleTestRunner serves the given router and switch tables and leaves every other table empty.
+func cycleTestRunner(routers, routerPorts, switches, switchPorts string) *fakeRunner {
+	return &fakeRunner{
+		outputs: map[string]string{
+			strings.Join(logicalRouterCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[` + routers + `]}`,
+			strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name","peer"],"data":[` + routerPorts + `]}`,
+			strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[` + switches + `]}`,
+			strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[` + switchPorts + `]}`,
+			strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+			strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+			strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+			strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
+			strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+			strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+			strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+			strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+			strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		},
+	}
+}
+
+func cycleWarnings(warnings []snapshot.Warning) []snapshot.Warning {
+	cycles := []snapshot.Warning{}
+	for _, warning := range warnings {
+		if warning.Code == snapshot.WarningTopologyCycle {
+			cycles = append(cycles, warning)
+		}
+	}
+	return cycles
+}
+
+func TestCollectSnapshotDetectsRouterSwitchLoop(t *testing.T) {
+	// lr-a and lr-b peer directly and both attach to ls-1, closing a loop. ls-2 hangs off lr-b
+	// and is not part of it.
+	runner := cycleTestRunner(
+		`[["uuid","lr-a"],"router-a",["set",[["uuid","lrp-a-peer"],["uuid","lrp-a-ls1"]]]],`+
+			`[["uuid","lr-b"],"router-b",["set",[["uuid","lrp-b-peer"],["uuid","lrp-b-ls1"],["uuid","lrp-b-ls2"]]]]`,
+		`[["uuid","lrp-a-peer"],"atob","btoa"],`+
+			`[["uuid","lrp-b-peer"],"btoa","atob"],`+
+			`[["uuid","lrp-a-ls1"],"atos-1",["set",[]]],`+
+			`[["uuid","lrp-b-ls1"],"btos-1",["set",[]]],`+
+			`[["uuid","lrp-b-ls2"],"btos-2",["set",[]]]`,
+		`[["uuid","ls-1"],"switch-1",["set",[["uuid","lsp-a"],["uuid","lsp-b"]]]],`+
+			`[["uuid","ls-2"],"switch-2",["uuid","lsp-c"]]`,
+		`[["uuid","lsp-a"],"stoa-1","router",["map",[["router-port","atos-1"]]]],`+
+			`[["uuid","lsp-b"],"stob-1","router",["map",[["router-port","btos-1"]]]],`+
+			`[["uuid","lsp-c"],"stob-2","router",["map",[["router-port","btos-2"]]]]`,
+	)
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{DetectCycles: true})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected a cycle to leave source health alone, got %q (%#v)", result.Metadata.SourceHealth, result.Warnings)
+	}
+	warnings := cycleWarnings(result.Warnings)
+	if len(warnings) != 1 || warnings[0].Severity != snapshot.SeverityWarning || !strings.Contains(warnings[0].Message, "lr-a, lr-b, ls-1") {
+		t.Fatalf("expected one cycle warning naming lr-a, lr-b and ls-1, got %#v", result.Warnings)
+	}
+	if strings.Contains(warnings[0].Message, "ls-2") {
+		t.Fatalf("expected the branch switch outside the cycle, got %q", warnings[0].Message)
+	}
+}
+
+func TestCollectSnapshotReportsNoCycleForTreeTopology(t *testing.T) {
+	// lr-a reaches ls-1 through two ports; the repeated link is not a loop.
+	runner := cycleTestRunner(
+		`[["uuid","lr-a"],"router-a",["set",[["uuid","lrp-1"],["uuid","lrp-2"],["uuid","lrp-3"]]]]`,
+		`[["uuid","lrp-1"],"atos-1",["set",[]]],`+
+			`[["uuid","lrp-2"],"atos-1b",["set",[]]],`+
+			`[["uuid","lrp-3"],"atos-2",["set",[]]]`,
+		`[["uuid","ls-1"],"switch-1",["set",[["uuid","lsp-1"],["uuid","lsp-2"]]]],`+
+			`[["uuid","ls-2"],"switch-2",["uuid","lsp-3"]]`,
+		`[["uuid","lsp-1"],"stoa-1","router",["map",[["router-port","atos-1"]]]],`+
+			`[["uuid","lsp-2"],"stoa-1b","router",["map",[["router-port","atos-1b"]]]],`+
+			`[["uuid","lsp-3"],"stoa-2","router",["map",[["router-port","atos-2"]]]]`,
+	)
+
+	result, err := CollectSnapshotWithOptions(context.Background(), runner, "worker-a", time.Now(), CollectOptions{DetectCycles: true})
+	if err != nil {
+		t.Fatalf("collect snapshot failed: %v", err)
+	}
+	if result.Metadata.EdgeKindCounts["router_to_switch"] != 2 {
+		t.Fatalf("expected the router linked to both switches, got %#v", result.Metadata.EdgeKindCounts)
+	}
+	if warnings := cycleWarnings(result.Warnings); len(warnings) != 0 {
+		t.Fatalf("expected no cycle warnings for a tree, got %#v", warnings)
+	}
+}
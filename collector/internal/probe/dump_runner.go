@@ -0,0 +1,68 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// FileRunner answers probe commands from a directory of captured `ovn-nbctl --format=json list`
+// output, such as the OVN database dumps in a must-gather archive, so a cluster can be analyzed
+// after the fact with the same parsing and graph assembly as a live probe. Each `list <Table>`
+// command reads DumpFileName(Table) from Dir. A missing core table (routers, switches and
+// their ports) fails like a failed command and degrades the snapshot; any other missing table
+// is read as empty, so a dump of the core tables alone yields a clean snapshot.
+type FileRunner struct {
+	Dir string
+}
+
+// coreDumpTables are the tables a dump must contain; the topology cannot be built without them.
+var coreDumpTables = []string{"Logical_Router", "Logical_Router_Port", "Logical_Switch", "Logical_Switch_Port"}
+
+// emptyTableDump is the `list` output of a table with no rows.
+const emptyTableDump = `{"headings":[],"data":[]}`
+
+// NewFileRunner returns a FileRunner reading dumps from dir.
+func NewFileRunner(dir string) *FileRunner {
+	return &FileRunner{Dir: dir}
+}
+
+// DumpFileName returns the file name FileRunner reads for an OVN table, e.g.
+// logical_router.json for Logical_Router.
+func DumpFileName(table string) string {
+	return strings.ToLower(table) + ".json"
+}
+
+// Run implements Runner.
+func (r *FileRunner) Run(_ context.Context, command []string) (string, error) {
+	table, ok := listedTable(command)
+	if !ok {
+		return "", fmt.Errorf("no dump file for command %q", strings.Join(command, " "))
+	}
+	raw, err := os.ReadFile(filepath.Join(r.Dir, DumpFileName(table)))
+	if errors.Is(err, os.ErrNotExist) && !slices.Contains(coreDumpTables, table) {
+		return emptyTableDump, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("read %s dump: %w", table, err)
+	}
+	return string(raw), nil
+}
+
+// listedTable returns the table named by a `list <Table>` command, skipping any options such
+// as --format=json or extra nbctl args that precede the subcommand.
+func listedTable(command []string) (string, bool) {
+	index := slices.Index(command, "list")
+	if index < 0 || index+1 >= len(command) {
+		return "", false
+	}
+	table := command[index+1]
+	if table == "" || strings.ContainsAny(table, `/\`) || strings.HasPrefix(table, ".") {
+		return "", false
+	}
+	return table, true
+}
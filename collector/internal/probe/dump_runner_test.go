@@ -0,0 +1,110 @@
+package probe
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeDump(t *testing.T, dir, table, raw string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, DumpFileName(table)), []byte(raw), 0o600); err != nil {
+		t.Fatalf("write %s dump: %v", table, err)
+	}
+}
+
+func TestFileRunnerBuildsTopologyFromDump(t *testing.T) {
+	dir := t.TempDir()
+	writeDump(t, dir, "Logical_Router", `{"headings":["_uuid","name","ports"],"data":[[["uuid","lr-1"],"cluster-router",["uuid","lrp-1"]]]}`)
+	writeDump(t, dir, "Logical_Router_Port", `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtos-red"]]}`)
+	writeDump(t, dir, "Logical_Switch", `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"red-net",["set",[["uuid","lsp-r"],["uuid","lsp-pod"]]]]]}`)
+	writeDump(t, dir, "Logical_Switch_Port", `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-r"],"red-router-port","router",["map",[["router-port","rtos-red"]]]],[["uuid","lsp-pod"],"pod-a","",["map",[]]]]}`)
+
+	result, err := CollectSnapshotWithOptions(context.Background(), NewFileRunner(dir), "worker-a",
+		time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC), CollectOptions{NbctlArgs: []string{"--db=unix:/tmp/ovnnb_db.sock"}})
+	if err != nil {
+		t.Fatalf("collect snapshot from dump failed: %v", err)
+	}
+
+	nodeKinds := map[string]string{}
+	for _, node := range result.Nodes {
+		nodeKinds[node.ID] = node.Kind
+	}
+	for id, want := range map[string]string{
+		"lr-1":    "logical_router",
+		"ls-1":    "logical_switch",
+		"lsp-r":   "logical_switch_port",
+		"lsp-pod": "logical_switch_port",
+	} {
+		if nodeKinds[id] != want {
+			t.Fatalf("expected %s to be %s, got nodes %#v", id, want, nodeKinds)
+		}
+	}
+	edgeKinds := map[string]string{}
+	for _, edge := range result.Edges {
+		edgeKinds[edge.ID] = edge.Kind
+	}
+	for _, id := range []string{"router_to_switch:lr-1:ls-1", "switch_to_port:ls-1:lsp-r", "switch_to_port:ls-1:lsp-pod"} {
+		if edgeKinds[id] == "" {
+			t.Fatalf("expected edge %s, got %#v", id, edgeKinds)
+		}
+	}
+
+	// Optional tables the dump did not capture are read as empty, so the snapshot stays clean.
+	if result.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected healthy source health for a core-table dump, got %q", result.Metadata.SourceHealth)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("expected no warnings for a core-table dump, got %#v", result.Warnings)
+	}
+}
+
+func TestFileRunnerDegradesWhenACoreTableIsMissing(t *testing.T) {
+	dir := t.TempDir()
+	writeDump(t, dir, "Logical_Router", `{"headings":["_uuid","name","ports"],"data":[]}`)
+	writeDump(t, dir, "Logical_Router_Port", `{"headings":["_uuid","name"],"data":[]}`)
+	writeDump(t, dir, "Logical_Switch", `{"headings":["_uuid","name","ports"],"data":[]}`)
+
+	result, err := CollectSnapshotWithOptions(context.Background(), NewFileRunner(dir), "worker-a",
+		time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC), CollectOptions{})
+	if err != nil {
+		t.Fatalf("collect snapshot from dump failed: %v", err)
+	}
+	if result.Metadata.SourceHealth != "degraded" {
+		t.Fatalf("expected degraded source health without a switch port dump, got %q", result.Metadata.SourceHealth)
+	}
+	found := false
+	for _, warning := range result.Warnings {
+		if strings.HasPrefix(warning.Message, "Logical_Switch_Port command failed") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a warning for the missing switch port dump, got %#v", result.Warnings)
+	}
+}
+
+func TestFileRunnerRejectsCommandsWithoutATable(t *testing.T) {
+	runner := NewFileRunner(t.TempDir())
+	for _, command := range [][]string{
+		{"ovn-nbctl", "show"},
+		{"ovn-nbctl", "list"},
+		{"ovn-nbctl", "list", "../Logical_Router"},
+	} {
+		if _, err := runner.Run(context.Background(), command); err == nil {
+			t.Fatalf("expected %v to fail", command)
+		}
+	}
+
+	_, err := runner.Run(context.Background(), logicalRouterCommand)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a missing core dump to report os.ErrNotExist, got %v", err)
+	}
+	if raw, err := runner.Run(context.Background(), natCommand); err != nil || raw != emptyTableDump {
+		t.Fatalf("expected a missing NAT dump to read as an empty table, got %q, %v", raw, err)
+	}
+}
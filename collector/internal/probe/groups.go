@@ -0,0 +1,99 @@
+package probe
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// unscopedGroupID is the group for switch ports whose name does not encode a namespace.
+const unscopedGroupID = "unscoped"
+
+// namespaceGroupPrefix prefixes the ID of every per-namespace group.
+const namespaceGroupPrefix = "namespace:"
+
+// portNamespace returns the Kubernetes namespace encoded in an ovn-kubernetes pod port name,
+// which has the form <namespace>_<pod>. Ports of another type, such as router ports, and names
+// whose prefix is not a valid namespace name report false.
+func portNamespace(port LogicalSwitchPort) (string, bool) {
+	if port.Type != "" {
+		return "", false
+	}
+	namespace, pod, ok := strings.Cut(port.Name, "_")
+	if !ok || pod == "" || !isDNSLabel(namespace) {
+		return "", false
+	}
+	return namespace, true
+}
+
+// isDNSLabel reports whether s is a valid RFC 1123 label, the format of namespace names.
+func isDNSLabel(s string) bool {
+	if s == "" || len(s) > 63 || s[0] == '-' || s[len(s)-1] == '-' {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+// namespaceGroups groups switch ports by the namespace encoded in their name, adding each
+// port's switch to the same group. A switch serving several namespaces is a member of each of
+// their groups. Ports without a namespace, and switches with only such ports, go to the
+// unscoped group. Groups are ordered by namespace with the unscoped group last, and member IDs
+// are sorted.
+func namespaceGroups(ports []LogicalSwitchPort, switchIDByPortUUID map[string]string) []snapshot.Group {
+	members := map[string]map[string]bool{}
+	add := func(groupID, nodeID string) {
+		if members[groupID] == nil {
+			members[groupID] = map[string]bool{}
+		}
+		members[groupID][nodeID] = true
+	}
+
+	scopedSwitches := map[string]bool{}
+	var unscopedSwitches []string
+	for _, port := range ports {
+		switchNodeID, hasSwitch := switchIDByPortUUID[port.UUID]
+		namespace, ok := portNamespace(port)
+		if !ok {
+			add(unscopedGroupID, switchPortNodeID(port))
+			if hasSwitch {
+				unscopedSwitches = append(unscopedSwitches, switchNodeID)
+			}
+			continue
+		}
+		add(namespaceGroupPrefix+namespace, switchPortNodeID(port))
+		if hasSwitch {
+			add(namespaceGroupPrefix+namespace, switchNodeID)
+			scopedSwitches[switchNodeID] = true
+		}
+	}
+	for _, switchNodeID := range unscopedSwitches {
+		if !scopedSwitches[switchNodeID] {
+			add(unscopedGroupID, switchNodeID)
+		}
+	}
+
+	groups := make([]snapshot.Group, 0, len(members))
+	for groupID, nodeIDs := range members {
+		label := strings.TrimPrefix(groupID, namespaceGroupPrefix)
+		group := snapshot.Group{ID: groupID, Label: label, NodeIDs: make([]string, 0, len(nodeIDs))}
+		for nodeID := range nodeIDs {
+			group.NodeIDs = append(group.NodeIDs, nodeID)
+		}
+		sort.Strings(group.NodeIDs)
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if (groups[i].ID == unscopedGroupID) != (groups[j].ID == unscopedGroupID) {
+			return groups[j].ID == unscopedGroupID
+		}
+		return groups[i].ID < groups[j].ID
+	})
+	return groups
+}
@@ -3,11 +3,19 @@ package probe
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"slices"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -15,13 +23,28 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
 )
 
+// Exec retry defaults. A retryable exec failure is retried up to DefaultExecMaxRetries times
+// on the same container, waiting DefaultExecRetryBaseDelay and doubling the wait each time.
+const (
+	DefaultExecMaxRetries     = 2
+	DefaultExecRetryBaseDelay = 250 * time.Millisecond
+)
+
+// DefaultProbeContainers lists the container names tried first when probing a pod, in order.
+var DefaultProbeContainers = []string{"nbdb", "northd", "ovnkube-node"}
+
 // KubernetesExecRunnerFactory creates node-scoped runners that execute probe commands in-cluster.
 type KubernetesExecRunnerFactory struct {
 	clientset        kubernetes.Interface
 	restConfig       *rest.Config
 	targetNamespaces []string
+	probeContainers  []string
+	podSelector      string
+	execMaxRetries   int
+	execRetryDelay   time.Duration
 	logger           *slog.Logger
 }
 
@@ -39,10 +62,33 @@ func NewKubernetesExecRunnerFactory(
 		clientset:        clientset,
 		restConfig:       restConfig,
 		targetNamespaces: targetNamespaces,
+		probeContainers:  slices.Clone(DefaultProbeContainers),
+		execMaxRetries:   DefaultExecMaxRetries,
+		execRetryDelay:   DefaultExecRetryBaseDelay,
 		logger:           logger,
 	}
 }
 
+// SetExecRetry sets how many times a retryable exec failure is retried on the same container
+// and the delay before the first retry, which doubles on each further retry. Zero retries
+// disables retrying.
+func (f *KubernetesExecRunnerFactory) SetExecRetry(maxRetries int, baseDelay time.Duration) {
+	f.execMaxRetries = max(maxRetries, 0)
+	f.execRetryDelay = max(baseDelay, 0)
+}
+
+// SetProbeContainers sets the container names tried first, in order, on every probed pod.
+// Containers not in the list are still tried afterwards.
+func (f *KubernetesExecRunnerFactory) SetProbeContainers(names []string) {
+	f.probeContainers = slices.Clone(names)
+}
+
+// SetPodSelector restricts probed pods to those matching a Kubernetes label selector such as
+// "app=ovnkube-node". An empty selector considers every running pod in the target namespaces.
+func (f *KubernetesExecRunnerFactory) SetPodSelector(selector string) {
+	f.podSelector = strings.TrimSpace(selector)
+}
+
 // RunnerForNode returns a runner that prefers pods scheduled on the target node.
 func (f *KubernetesExecRunnerFactory) RunnerForNode(nodeName string) (Runner, error) {
 	if f.clientset == nil || f.restConfig == nil {
@@ -55,19 +101,137 @@ func (f *KubernetesExecRunnerFactory) RunnerForNode(nodeName string) (Runner, er
 		clientset:        f.clientset,
 		restConfig:       f.restConfig,
 		targetNamespaces: slices.Clone(f.targetNamespaces),
+		probeContainers:  slices.Clone(f.probeContainers),
+		podSelector:      f.podSelector,
+		execMaxRetries:   f.execMaxRetries,
+		execRetryDelay:   f.execRetryDelay,
 		nodeName:         nodeName,
 		logger:           f.logger.With("node", nodeName),
 	}, nil
 }
 
+// ListNodes returns the sorted names of nodes running OVN probe pods in the target namespaces.
+func (f *KubernetesExecRunnerFactory) ListNodes(ctx context.Context) ([]string, error) {
+	if f.clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is not configured")
+	}
+
+	seen := map[string]bool{}
+	nodes := []string{}
+	for _, namespace := range f.targetNamespaces {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		podList, err := f.clientset.CoreV1().Pods(namespace).List(ctx, runningPodListOptions(f.podSelector))
+		if err != nil {
+			f.logger.Warn("failed to list pods for node discovery; skipping", "namespace", namespace, "error", err)
+			continue
+		}
+		for _, pod := range podList.Items {
+			nodeName := strings.TrimSpace(pod.Spec.NodeName)
+			if nodeName == "" || seen[nodeName] {
+				continue
+			}
+			seen[nodeName] = true
+			nodes = append(nodes, nodeName)
+		}
+	}
+	slices.Sort(nodes)
+	return nodes, nil
+}
+
+// NodeAddresses returns the host IPs reported by running OVN probe pods on nodeName, in the
+// order the pods report them, so a dual-stack node yields both its IPv4 and IPv6 address.
+func (f *KubernetesExecRunnerFactory) NodeAddresses(ctx context.Context, nodeName string) ([]string, error) {
+	if f.clientset == nil {
+		return nil, fmt.Errorf("kubernetes client is not configured")
+	}
+
+	addresses := []string{}
+	for _, namespace := range f.targetNamespaces {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		podList, err := f.clientset.CoreV1().Pods(namespace).List(ctx, runningPodListOptions(f.podSelector))
+		if err != nil {
+			f.logger.Warn("failed to list pods for node addresses; skipping", "namespace", namespace, "error", err)
+			continue
+		}
+		for _, pod := range podList.Items {
+			if pod.Spec.NodeName != nodeName {
+				continue
+			}
+			for _, address := range podHostIPs(&pod) {
+				if !slices.Contains(addresses, address) {
+					addresses = append(addresses, address)
+				}
+			}
+		}
+	}
+	return addresses, nil
+}
+
+// podHostIPs returns the pod's host IPs, falling back to the single HostIP reported by clusters
+// that predate dual-stack HostIPs.
+func podHostIPs(pod *corev1.Pod) []string {
+	ips := []string{}
+	for _, hostIP := range pod.Status.HostIPs {
+		if ip := strings.TrimSpace(hostIP.IP); ip != "" {
+			ips = append(ips, ip)
+		}
+	}
+	if len(ips) == 0 && strings.TrimSpace(pod.Status.HostIP) != "" {
+		ips = append(ips, strings.TrimSpace(pod.Status.HostIP))
+	}
+	return ips
+}
+
+// Ready verifies the Kubernetes API server answers pod list requests in the target namespaces.
+// A missing namespace is not an error, nor is one the collector may not read: the operator only
+// grants access to probe namespaces that exist, and collection skips both. Any other list
+// failure is returned.
+func (f *KubernetesExecRunnerFactory) Ready(ctx context.Context) error {
+	if f.clientset == nil {
+		return fmt.Errorf("kubernetes client is not configured")
+	}
+
+	for _, namespace := range f.targetNamespaces {
+		namespace = strings.TrimSpace(namespace)
+		if namespace == "" {
+			continue
+		}
+		_, err := f.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+		if err != nil && !apierrors.IsNotFound(err) && !apierrors.IsForbidden(err) {
+			return fmt.Errorf("list pods in namespace %q: %w", namespace, err)
+		}
+	}
+	return nil
+}
+
 // KubernetesExecRunner executes OVN commands inside a selected pod/container.
 type KubernetesExecRunner struct {
 	clientset        kubernetes.Interface
 	restConfig       *rest.Config
 	targetNamespaces []string
+	probeContainers  []string
+	podSelector      string
+	execMaxRetries   int
+	execRetryDelay   time.Duration
 	nodeName         string
 	logger           *slog.Logger
 	execPod          podExecFunc
+
+	// missingBinaries remembers containers that lack a command's binary so later commands in
+	// the same collection skip them. A runner lives for one collection.
+	missingBinariesMu sync.Mutex
+	missingBinaries   map[missingBinaryKey]bool
+}
+
+type missingBinaryKey struct {
+	target execTarget
+	binary string
 }
 
 // Run executes a command in a target pod and returns stdout.
@@ -81,15 +245,19 @@ func (r *KubernetesExecRunner) Run(ctx context.Context, command []string) (strin
 		return "", err
 	}
 
+	logger := requestid.Logger(ctx, r.logger)
 	var lastErr error
 	for _, target := range targets {
+		if r.lacksBinary(target, command[0]) {
+			continue
+		}
 		execPod := r.execInPod
 		if r.execPod != nil {
 			execPod = r.execPod
 		}
-		stdout, stderr, execErr := execPod(ctx, target.namespace, target.podName, target.containerName, command)
+		stdout, stderr, execErr := r.execWithRetry(ctx, execPod, target, command)
 		if execErr == nil {
-			r.logger.Debug(
+			logger.Debug(
 				"probe command executed successfully",
 				"namespace", target.namespace,
 				"pod", target.podName,
@@ -101,7 +269,10 @@ func (r *KubernetesExecRunner) Run(ctx context.Context, command []string) (strin
 		}
 
 		lastErr = fmt.Errorf("%w; stderr=%s", execErr, strings.TrimSpace(stderr))
-		r.logger.Debug(
+		if isMissingBinaryError(execErr, stderr) {
+			r.rememberMissingBinary(target, command[0])
+		}
+		logger.Debug(
 			"probe command execution attempt failed",
 			"namespace", target.namespace,
 			"pod", target.podName,
@@ -118,12 +289,132 @@ func (r *KubernetesExecRunner) Run(ctx context.Context, command []string) (strin
 	return "", fmt.Errorf("probe exec failed on all targets: %w", lastErr)
 }
 
+func (r *KubernetesExecRunner) lacksBinary(target execTarget, binary string) bool {
+	r.missingBinariesMu.Lock()
+	defer r.missingBinariesMu.Unlock()
+	return r.missingBinaries[missingBinaryKey{target: target, binary: binary}]
+}
+
+func (r *KubernetesExecRunner) rememberMissingBinary(target execTarget, binary string) {
+	r.missingBinariesMu.Lock()
+	defer r.missingBinariesMu.Unlock()
+	if r.missingBinaries == nil {
+		r.missingBinaries = map[missingBinaryKey]bool{}
+	}
+	r.missingBinaries[missingBinaryKey{target: target, binary: binary}] = true
+}
+
+// isMissingBinaryError reports whether an exec failed because the container has no such
+// command, as opposed to the command itself failing.
+func isMissingBinaryError(err error, stderr string) bool {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && (exitErr.ExitStatus() == 126 || exitErr.ExitStatus() == 127) {
+		return true
+	}
+	message := strings.ToLower(err.Error() + " " + stderr)
+	for _, marker := range []string{"executable file not found", "command not found"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// execWithRetry runs the command on one target, retrying transient failures with exponential
+// backoff. It gives up early when ctx is done.
+func (r *KubernetesExecRunner) execWithRetry(
+	ctx context.Context,
+	execPod podExecFunc,
+	target execTarget,
+	command []string,
+) (string, string, error) {
+	delay := r.execRetryDelay
+	for attempt := 0; ; attempt++ {
+		stdout, stderr, err := r.tracedExec(ctx, execPod, target, command)
+		if err == nil || attempt >= r.execMaxRetries || !isRetryableExecError(err, stderr) {
+			return stdout, stderr, err
+		}
+
+		r.logger.Debug(
+			"retrying probe command after transient exec failure",
+			"namespace", target.namespace,
+			"pod", target.podName,
+			"container", target.containerName,
+			"attempt", attempt+1,
+			"delay", delay.String(),
+			"error", err,
+		)
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return stdout, stderr, fmt.Errorf("%w (retry abandoned: %v)", err, ctx.Err())
+		case <-timer.C:
+		}
+		delay *= 2
+	}
+}
+
+// isRetryableExecError reports whether an exec failure looks transient: a dropped connection
+// or an API server error that may clear on its own. A command that ran and exited non-zero, a
+// missing binary and authorization failures are never retried.
+func isRetryableExecError(err error, stderr string) bool {
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) || isMissingBinaryError(err, stderr) {
+		return false
+	}
+	if apierrors.IsForbidden(err) || apierrors.IsUnauthorized(err) {
+		return false
+	}
+	if apierrors.IsInternalError(err) || apierrors.IsServerTimeout(err) || apierrors.IsTimeout(err) ||
+		apierrors.IsTooManyRequests(err) || apierrors.IsServiceUnavailable(err) {
+		return true
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	message := strings.ToLower(err.Error())
+	for _, marker := range []string{"connection reset by peer", "connection refused", "broken pipe", "unexpected eof", "tls handshake timeout"} {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// tracedExec runs one exec attempt inside a span describing the target container and command.
+func (r *KubernetesExecRunner) tracedExec(
+	ctx context.Context,
+	execPod podExecFunc,
+	target execTarget,
+	command []string,
+) (stdout string, stderr string, err error) {
+	ctx, span := tracer.Start(ctx, "probe.exec", trace.WithAttributes(
+		attribute.String("ovn.node", r.nodeName),
+		attribute.String("k8s.namespace.name", target.namespace),
+		attribute.String("k8s.pod.name", target.podName),
+		attribute.String("k8s.container.name", target.containerName),
+		attribute.String("ovn.command", strings.Join(command, " ")),
+	))
+	defer func() { endSpan(span, err) }()
+	return execPod(ctx, target.namespace, target.podName, target.containerName, command)
+}
+
 type execTarget struct {
 	namespace     string
 	podName       string
 	containerName string
 }
 
+// runningPodListOptions lists running pods, narrowed by labelSelector when one is set.
+func runningPodListOptions(labelSelector string) metav1.ListOptions {
+	return metav1.ListOptions{
+		FieldSelector: "status.phase=Running",
+		LabelSelector: labelSelector,
+	}
+}
+
 type podExecFunc func(context.Context, string, string, string, []string) (string, string, error)
 
 func (r *KubernetesExecRunner) resolveExecTargets(ctx context.Context) ([]execTarget, error) {
@@ -136,15 +427,13 @@ func (r *KubernetesExecRunner) resolveExecTargets(ctx context.Context) ([]execTa
 			continue
 		}
 
-		podList, err := r.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
-			FieldSelector: "status.phase=Running",
-		})
+		podList, err := r.clientset.CoreV1().Pods(namespace).List(ctx, runningPodListOptions(r.podSelector))
 		if err != nil {
 			r.logProbeNamespaceListError(namespace, err)
 			continue
 		}
 		if len(podList.Items) == 0 {
-			r.logger.Warn("probe namespace has no running pods; skipping", "namespace", namespace)
+			r.logger.Warn("probe namespace has no running pods; skipping", "namespace", namespace, "podSelector", r.podSelector)
 			continue
 		}
 
@@ -170,12 +459,31 @@ func (r *KubernetesExecRunner) resolveExecTargets(ctx context.Context) ([]execTa
 		)
 	}
 
+	r.sortByProbeContainer(preferred)
+	r.sortByProbeContainer(fallback)
 	if len(preferred) > 0 {
 		return append(preferred, fallback...), nil
 	}
 	return fallback, nil
 }
 
+// sortByProbeContainer moves targets whose container is in probeContainers to the front in
+// list order, keeping the original order otherwise.
+func (r *KubernetesExecRunner) sortByProbeContainer(targets []execTarget) {
+	if len(r.probeContainers) == 0 {
+		return
+	}
+	rank := func(target execTarget) int {
+		if index := slices.Index(r.probeContainers, target.containerName); index >= 0 {
+			return index
+		}
+		return len(r.probeContainers)
+	}
+	slices.SortStableFunc(targets, func(a, b execTarget) int {
+		return rank(a) - rank(b)
+	})
+}
+
 func (r *KubernetesExecRunner) logProbeNamespaceListError(namespace string, err error) {
 	switch {
 	case apierrors.IsNotFound(err):
@@ -3,11 +3,15 @@ package probe
 import (
 	"bytes"
 	"context"
+	"errors"
 	"log/slog"
 	"strings"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,6 +60,70 @@ func TestKubernetesExecRunnerResolveExecTargetsPrefersSameNode(t *testing.T) {
 	}
 }
 
+func TestKubernetesExecRunnerResolveExecTargetsPrefersProbeContainers(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"ovn-controller", "kube-rbac-proxy", "ovnkube-node", "nbdb"}),
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-b", "worker-b", []string{"kube-rbac-proxy", "nbdb"}),
+	)
+
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes"}, slog.Default())
+	runner, err := factory.RunnerForNode("worker-a")
+	if err != nil {
+		t.Fatalf("RunnerForNode returned error: %v", err)
+	}
+
+	targets, err := runner.(*KubernetesExecRunner).resolveExecTargets(context.Background())
+	if err != nil {
+		t.Fatalf("resolveExecTargets returned error: %v", err)
+	}
+	got := make([]string, 0, len(targets))
+	for _, target := range targets {
+		got = append(got, target.podName+"/"+target.containerName)
+	}
+	want := []string{
+		"ovnkube-node-a/nbdb",
+		"ovnkube-node-a/ovnkube-node",
+		"ovnkube-node-a/ovn-controller",
+		"ovnkube-node-a/kube-rbac-proxy",
+		"ovnkube-node-b/nbdb",
+		"ovnkube-node-b/kube-rbac-proxy",
+	}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Fatalf("expected probe containers first on each node, got %v", got)
+	}
+}
+
+func TestKubernetesExecRunnerSkipsContainersMissingBinary(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"kube-rbac-proxy", "nbdb"}),
+	)
+
+	attempts := map[string]int{}
+	runner := &KubernetesExecRunner{
+		clientset:        clientset,
+		restConfig:       &rest.Config{Host: "https://example.invalid"},
+		targetNamespaces: []string{"openshift-ovn-kubernetes"},
+		nodeName:         "worker-a",
+		logger:           slog.Default(),
+		execPod: func(_ context.Context, _, _, container string, _ []string) (string, string, error) {
+			attempts[container]++
+			if container == "kube-rbac-proxy" {
+				return "", "", errors.New(`exec: "ovn-nbctl": executable file not found in $PATH`)
+			}
+			return "ok", "", nil
+		},
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := runner.Run(context.Background(), []string{"ovn-nbctl", "show"}); err != nil {
+			t.Fatalf("Run returned error: %v", err)
+		}
+	}
+	if attempts["kube-rbac-proxy"] != 1 || attempts["nbdb"] != 3 {
+		t.Fatalf("expected container without ovn-nbctl to be tried once, got %v", attempts)
+	}
+}
+
 func TestKubernetesExecRunnerResolveExecTargetsReturnsErrorWhenNoPods(t *testing.T) {
 	runner := &KubernetesExecRunner{
 		clientset:        fake.NewSimpleClientset(),
@@ -125,6 +193,15 @@ func TestKubernetesExecRunnerCollectsSnapshotWhenFRRNamespaceIsMissing(t *testin
 		strings.Join(logicalRouterPortCommand, " "): `{"headings":["_uuid","name"],"data":[[["uuid","lrp-1"],"rtos-red"]]}`,
 		strings.Join(logicalSwitchCommand, " "):     `{"headings":["_uuid","name","ports"],"data":[[["uuid","ls-1"],"red-net",["set",[["uuid","lsp-r"]]]]]}`,
 		strings.Join(logicalSwitchPortCommand, " "): `{"headings":["_uuid","name","type","options"],"data":[[["uuid","lsp-r"],"red-router-port","router",["map",[["router-port","rtos-red"]]]]]}`,
+		strings.Join(loadBalancerCommand, " "):      `{"headings":["_uuid","name","vips","protocol"],"data":[]}`,
+		strings.Join(natCommand, " "):               `{"headings":["_uuid","type","external_ip","logical_ip","logical_port"],"data":[]}`,
+		strings.Join(dhcpOptionsCommand, " "):       `{"headings":["_uuid","cidr","options"],"data":[]}`,
+		strings.Join(portGroupCommand, " "):         `{"headings":["_uuid","name","ports","acls"],"data":[]}`,
+		strings.Join(gatewayChassisCommand, " "):    `{"headings":["_uuid","name","chassis_name","priority"],"data":[]}`,
+		strings.Join(haChassisGroupCommand, " "):    `{"headings":["_uuid","name","ha_chassis"],"data":[]}`,
+		strings.Join(staticRouteCommand, " "):       `{"headings":["_uuid","ip_prefix","nexthop","output_port"],"data":[]}`,
+		strings.Join(aclCommand, " "):               `{"headings":["_uuid","name","priority","direction","match","action"],"data":[]}`,
+		strings.Join(qosCommand, " "):               `{"headings":["_uuid","priority","direction","match","action","bandwidth"],"data":[]}`,
 	}
 
 	var buf bytes.Buffer
@@ -196,6 +273,138 @@ func TestKubernetesExecRunnerResolveExecTargetsSkipsEmptyFRRNamespace(t *testing
 	}
 }
 
+func TestKubernetesExecRunnerFactoryListNodes(t *testing.T) {
+	clientset := fake.NewSimpleClientset(
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-b", "worker-b", []string{"nbdb"}),
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"nbdb"}),
+		newRunningPod("openshift-frr-k8s", "frr-k8s-a", "worker-a", []string{"frr"}),
+	)
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"}, slog.Default())
+
+	nodes, err := factory.ListNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ListNodes returned error: %v", err)
+	}
+	if strings.Join(nodes, ",") != "worker-a,worker-b" {
+		t.Fatalf("expected [worker-a worker-b], got %v", nodes)
+	}
+}
+
+func TestKubernetesExecRunnerFactoryNodeAddresses(t *testing.T) {
+	dualStack := newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"nbdb"})
+	dualStack.Status.HostIP = "10.0.0.11"
+	dualStack.Status.HostIPs = []corev1.HostIP{{IP: "10.0.0.11"}, {IP: "fd00:10::11"}}
+	legacy := newRunningPod("openshift-frr-k8s", "frr-k8s-a", "worker-a", []string{"frr"})
+	legacy.Status.HostIP = "10.0.0.11"
+	other := newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-b", "worker-b", []string{"nbdb"})
+	other.Status.HostIPs = []corev1.HostIP{{IP: "10.0.0.12"}}
+	clientset := fake.NewSimpleClientset(dualStack, legacy, other)
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"}, slog.Default())
+
+	addresses, err := factory.NodeAddresses(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("NodeAddresses returned error: %v", err)
+	}
+	if !equalStrings(addresses, []string{"10.0.0.11", "fd00:10::11"}) {
+		t.Fatalf("expected [10.0.0.11 fd00:10::11], got %v", addresses)
+	}
+
+	collector := NewSnapshotCollector(nodeAddressRunnerFactory{factory: factory, runner: &fakeRunner{}}, slog.Default(), false)
+	payload, err := collector.Collect(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if !equalStrings(payload.Metadata.NodeAddresses, []string{"10.0.0.11", "fd00:10::11"}) {
+		t.Fatalf("expected node addresses in snapshot metadata, got %v", payload.Metadata.NodeAddresses)
+	}
+}
+
+// nodeAddressRunnerFactory resolves addresses through a Kubernetes factory but runs probe
+// commands with a fake runner.
+type nodeAddressRunnerFactory struct {
+	factory *KubernetesExecRunnerFactory
+	runner  Runner
+}
+
+func (f nodeAddressRunnerFactory) RunnerForNode(string) (Runner, error) {
+	return f.runner, nil
+}
+
+func (f nodeAddressRunnerFactory) NodeAddresses(ctx context.Context, nodeName string) ([]string, error) {
+	return f.factory.NodeAddresses(ctx, nodeName)
+}
+
+func TestKubernetesExecRunnerPassesPodSelectorToPodList(t *testing.T) {
+	ovnPod := newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"nbdb"})
+	ovnPod.Labels = map[string]string{"app": "ovnkube-node"}
+	clientset := fake.NewSimpleClientset(
+		ovnPod,
+		newRunningPod("openshift-ovn-kubernetes", "unrelated-a", "worker-a", []string{"nbdb"}),
+	)
+	var selectors []string
+	clientset.Fake.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if listAction, ok := action.(k8stesting.ListActionImpl); ok {
+			selectors = append(selectors, listAction.GetListRestrictions().Labels.String())
+		}
+		return false, nil, nil
+	})
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes"}, slog.Default())
+	factory.SetPodSelector("app=ovnkube-node")
+
+	if _, err := factory.ListNodes(context.Background()); err != nil {
+		t.Fatalf("ListNodes returned error: %v", err)
+	}
+	runner, err := factory.RunnerForNode("worker-a")
+	if err != nil {
+		t.Fatalf("RunnerForNode returned error: %v", err)
+	}
+	targets, err := runner.(*KubernetesExecRunner).resolveExecTargets(context.Background())
+	if err != nil {
+		t.Fatalf("resolveExecTargets returned error: %v", err)
+	}
+	if len(targets) != 1 || targets[0].podName != "ovnkube-node-a" {
+		t.Fatalf("expected only the selected pod as a target, got %#v", targets)
+	}
+
+	if strings.Join(selectors, ";") != "app=ovnkube-node;app=ovnkube-node" {
+		t.Fatalf("expected pod selector on every pod list, got %q", selectors)
+	}
+}
+
+func TestKubernetesExecRunnerFactoryReady(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes"}, slog.Default())
+	if err := factory.Ready(context.Background()); err != nil {
+		t.Fatalf("expected reachable API server to be ready, got %v", err)
+	}
+
+	clientset.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+	err := factory.Ready(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "openshift-ovn-kubernetes") {
+		t.Fatalf("expected readiness error naming the namespace, got %v", err)
+	}
+	if err := NewSnapshotCollector(factory, slog.Default(), false).Ready(context.Background()); err == nil {
+		t.Fatalf("expected SnapshotCollector to surface the factory readiness error")
+	}
+}
+
+func TestKubernetesExecRunnerFactoryReadyToleratesForbiddenNamespace(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	clientset.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() != "openshift-frr-k8s" {
+			return false, nil, nil
+		}
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "", errors.New("no RoleBinding"))
+	})
+	factory := NewKubernetesExecRunnerFactory(clientset, &rest.Config{Host: "https://example.invalid"}, []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"}, slog.Default())
+
+	if err := factory.Ready(context.Background()); err != nil {
+		t.Fatalf("expected a forbidden probe namespace to be skipped, got %v", err)
+	}
+}
+
 func newRunningPod(namespace, name, nodeName string, containers []string) *corev1.Pod {
 	podContainers := make([]corev1.Container, 0, len(containers))
 	for _, container := range containers {
@@ -216,3 +425,163 @@ func newRunningPod(namespace, name, nodeName string, containers []string) *corev
 		},
 	}
 }
+
+func TestKubernetesExecRunnerRecordsExecSpans(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	clientset := fake.NewSimpleClientset(
+		newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"nbdb"}),
+	)
+	runner := &KubernetesExecRunner{
+		clientset:        clientset,
+		restConfig:       &rest.Config{Host: "https://example.invalid"},
+		targetNamespaces: []string{"openshift-ovn-kubernetes"},
+		nodeName:         "worker-a",
+		logger:           slog.Default(),
+		execPod: func(context.Context, string, string, string, []string) (string, string, error) {
+			return "", "", nil
+		},
+	}
+	collector := NewSnapshotCollector(StaticRunnerFactory{Runner: runner}, slog.Default(), false)
+	if _, err := collector.Collect(context.Background(), "worker-a"); err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+
+	var collectSpan sdktrace.ReadOnlySpan
+	var execSpans []sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		switch span.Name() {
+		case "probe.Collect":
+			collectSpan = span
+		case "probe.exec":
+			execSpans = append(execSpans, span)
+		}
+	}
+	if collectSpan == nil {
+		t.Fatalf("expected a probe.Collect span")
+	}
+	if len(execSpans) == 0 {
+		t.Fatalf("expected probe.exec spans")
+	}
+
+	exec := execSpans[0]
+	if exec.Parent().SpanID() != collectSpan.SpanContext().SpanID() {
+		t.Fatalf("expected exec span to be a child of the collect span")
+	}
+	attrs := map[string]string{}
+	for _, kv := range exec.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+	}
+	want := map[string]string{
+		"ovn.node":           "worker-a",
+		"k8s.namespace.name": "openshift-ovn-kubernetes",
+		"k8s.pod.name":       "ovnkube-node-a",
+		"k8s.container.name": "nbdb",
+	}
+	for key, value := range want {
+		if attrs[key] != value {
+			t.Fatalf("expected exec span attribute %s=%q, got %q", key, value, attrs[key])
+		}
+	}
+	if !strings.HasPrefix(attrs["ovn.command"], "ovn-nbctl") {
+		t.Fatalf("expected exec span command attribute, got %q", attrs["ovn.command"])
+	}
+}
+
+func newRetryTestRunner(execPod podExecFunc, maxRetries int, delay time.Duration) *KubernetesExecRunner {
+	return &KubernetesExecRunner{
+		clientset: fake.NewSimpleClientset(
+			newRunningPod("openshift-ovn-kubernetes", "ovnkube-node-a", "worker-a", []string{"nbdb"}),
+		),
+		restConfig:       &rest.Config{Host: "https://example.invalid"},
+		targetNamespaces: []string{"openshift-ovn-kubernetes"},
+		execMaxRetries:   maxRetries,
+		execRetryDelay:   delay,
+		nodeName:         "worker-a",
+		logger:           slog.Default(),
+		execPod:          execPod,
+	}
+}
+
+func TestKubernetesExecRunnerRetriesTransientExecFailures(t *testing.T) {
+	calls := 0
+	runner := newRetryTestRunner(func(context.Context, string, string, string, []string) (string, string, error) {
+		calls++
+		if calls <= 2 {
+			return "", "", errors.New("error dialing backend: read tcp 10.0.0.1:443: connection reset by peer")
+		}
+		return "ok", "", nil
+	}, 2, time.Millisecond)
+
+	stdout, err := runner.Run(context.Background(), []string{"ovn-nbctl", "show"})
+	if err != nil {
+		t.Fatalf("expected the third attempt to succeed, got %v", err)
+	}
+	if stdout != "ok" || calls != 3 {
+		t.Fatalf("expected stdout ok after 3 attempts, got %q after %d", stdout, calls)
+	}
+
+	calls = 0
+	runner = newRetryTestRunner(func(context.Context, string, string, string, []string) (string, string, error) {
+		calls++
+		return "", "", apierrors.NewInternalError(errors.New("etcd leader changed"))
+	}, 2, time.Millisecond)
+	if _, err := runner.Run(context.Background(), []string{"ovn-nbctl", "show"}); err == nil {
+		t.Fatalf("expected an error once retries are exhausted")
+	}
+	if calls != 3 {
+		t.Fatalf("expected one attempt plus two retries, got %d", calls)
+	}
+}
+
+func TestKubernetesExecRunnerDoesNotRetryPermanentExecFailures(t *testing.T) {
+	for name, execErr := range map[string]error{
+		"missing binary": errors.New(`exec: "ovn-nbctl": executable file not found in $PATH`),
+		"forbidden":      apierrors.NewForbidden(schema.GroupResource{Resource: "pods/exec"}, "ovnkube-node-a", errors.New("denied")),
+	} {
+		t.Run(name, func(t *testing.T) {
+			calls := 0
+			runner := newRetryTestRunner(func(context.Context, string, string, string, []string) (string, string, error) {
+				calls++
+				return "", "", execErr
+			}, 3, time.Millisecond)
+			if _, err := runner.Run(context.Background(), []string{"ovn-nbctl", "show"}); err == nil {
+				t.Fatalf("expected the exec error to be returned")
+			}
+			if calls != 1 {
+				t.Fatalf("expected a permanent failure not to be retried, got %d attempts", calls)
+			}
+		})
+	}
+}
+
+func TestKubernetesExecRunnerRetryBackoffStopsOnContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	runner := newRetryTestRunner(func(context.Context, string, string, string, []string) (string, string, error) {
+		calls++
+		cancel()
+		return "", "", errors.New("connection refused")
+	}, 5, time.Hour)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := runner.Run(ctx, []string{"ovn-nbctl", "show"})
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil || !strings.Contains(err.Error(), "context canceled") {
+			t.Fatalf("expected the cancellation to end the backoff, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected the backoff to stop when the context was canceled")
+	}
+	if calls != 1 {
+		t.Fatalf("expected no retry after cancellation, got %d attempts", calls)
+	}
+}
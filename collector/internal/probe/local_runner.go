@@ -0,0 +1,44 @@
+package probe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CommandRunner runs probe commands as local processes instead of exec'ing into cluster pods,
+// for a dev VM or a replay host that can reach the OVN databases directly. Pair it with
+// StaticRunnerFactory, since every node name resolves to the same local databases.
+type CommandRunner struct {
+	// NbctlPath is the ovn-nbctl executable to run in place of the bare "ovn-nbctl" in each NB
+	// command. Empty looks ovn-nbctl up on PATH. Other commands, such as ovn-sbctl, always do.
+	NbctlPath string
+}
+
+// NewCommandRunner returns a CommandRunner that runs ovn-nbctl from nbctlPath.
+func NewCommandRunner(nbctlPath string) *CommandRunner {
+	return &CommandRunner{NbctlPath: strings.TrimSpace(nbctlPath)}
+}
+
+// Run implements Runner. It returns the command's stdout, or an error carrying its stderr when
+// the command cannot start or exits non-zero.
+func (r *CommandRunner) Run(ctx context.Context, command []string) (string, error) {
+	if len(command) == 0 {
+		return "", fmt.Errorf("empty command")
+	}
+	name := command[0]
+	if name == "ovn-nbctl" && r.NbctlPath != "" {
+		name = r.NbctlPath
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("run %s: %w; stderr=%s", strings.Join(command, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
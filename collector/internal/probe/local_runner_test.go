@@ -0,0 +1,59 @@
+package probe
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeStubNbctl writes a shell script that echoes its arguments, or fails when the first one
+// is "fail".
+func writeStubNbctl(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ovn-nbctl")
+	script := "#!/bin/sh\n" +
+		"if [ \"$1\" = fail ]; then echo 'database unreachable' >&2; exit 1; fi\n" +
+		"echo \"args: $*\"\n"
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatalf("write stub binary: %v", err)
+	}
+	return path
+}
+
+func TestCommandRunnerRunsConfiguredNbctl(t *testing.T) {
+	runner := NewCommandRunner(writeStubNbctl(t))
+	command := withNbctlArgs(logicalSwitchCommand, []string{"--db=unix:/tmp/ovnnb_db.sock"})
+
+	out, err := runner.Run(context.Background(), command)
+	if err != nil {
+		t.Fatalf("run stub nbctl: %v", err)
+	}
+	if want := "args: --db=unix:/tmp/ovnnb_db.sock --format=json list Logical_Switch\n"; out != want {
+		t.Fatalf("expected output %q, got %q", want, out)
+	}
+}
+
+func TestCommandRunnerReportsStderrOnFailure(t *testing.T) {
+	runner := NewCommandRunner(writeStubNbctl(t))
+
+	_, err := runner.Run(context.Background(), []string{"ovn-nbctl", "fail"})
+	if err == nil {
+		t.Fatalf("expected failing command to return an error")
+	}
+	if !strings.Contains(err.Error(), "database unreachable") {
+		t.Fatalf("expected stderr in error, got %v", err)
+	}
+}
+
+func TestStaticRunnerFactoryServesCommandRunnerForEveryNode(t *testing.T) {
+	runner := NewCommandRunner(writeStubNbctl(t))
+	factory := StaticRunnerFactory{Runner: runner}
+	for _, node := range []string{"worker-a", "worker-b"} {
+		got, err := factory.RunnerForNode(node)
+		if err != nil || got != runner {
+			t.Fatalf("expected the command runner for %s, got %v, %v", node, got, err)
+		}
+	}
+}
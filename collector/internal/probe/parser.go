@@ -3,37 +3,169 @@ package probe
 import (
 	"encoding/json"
 	"fmt"
+	"net"
+	"net/netip"
+	"slices"
+	"strconv"
 	"strings"
 )
 
 // LogicalRouter models the minimum fields needed for logical topology assembly.
 type LogicalRouter struct {
-	UUID      string
-	Name      string
-	PortUUIDs []string
+	UUID              string
+	Name              string
+	PortUUIDs         []string
+	LoadBalancerUUIDs []string
+	NATUUIDs          []string
+	StaticRouteUUIDs  []string
 }
 
 // LogicalRouterPort models the minimum fields needed for logical topology assembly.
+// Peer names the router port on another logical router this port is directly connected to.
+// GatewayChassisUUIDs and HAChassisGroupUUID are only set on distributed gateway ports.
 type LogicalRouterPort struct {
-	UUID string
-	Name string
+	UUID                string
+	Name                string
+	MAC                 string
+	Networks            []string
+	Peer                string
+	GatewayChassisUUIDs []string
+	HAChassisGroupUUID  string
 }
 
 // LogicalSwitch models the minimum fields needed for logical topology assembly.
 type LogicalSwitch struct {
-	UUID      string
-	Name      string
-	PortUUIDs []string
+	UUID              string
+	Name              string
+	PortUUIDs         []string
+	LoadBalancerUUIDs []string
+	ACLUUIDs          []string
+	QoSUUIDs          []string
 }
 
 // LogicalSwitchPort models the minimum fields needed for logical topology assembly.
+// DHCPv4OptionsUUID and DHCPv6OptionsUUID reference DHCP_Options rows and are empty when unset.
 type LogicalSwitchPort struct {
+	UUID              string
+	Name              string
+	Type              string
+	Options           map[string]string
+	DHCPv4OptionsUUID string
+	DHCPv6OptionsUUID string
+	// MACs and IPs are the static addresses listed in the addresses column. Keyword entries such
+	// as "dynamic", "router" and "unknown" contribute nothing.
+	MACs []string
+	IPs  []string
+}
+
+// DHCPOptions models the minimum OVN NB DHCP_Options fields needed for logical topology assembly.
+// The same table holds both DHCPv4 and DHCPv6 options; CIDR tells them apart.
+type DHCPOptions struct {
 	UUID    string
-	Name    string
-	Type    string
+	CIDR    string
 	Options map[string]string
 }
 
+// StaticRoute models the minimum OVN NB Logical_Router_Static_Route fields needed for logical
+// topology assembly. OutputPort names a Logical_Router_Port and is empty when unset.
+type StaticRoute struct {
+	UUID       string
+	IPPrefix   string
+	Nexthop    string
+	OutputPort string
+}
+
+// LogicalLoadBalancer models the minimum fields needed for logical topology assembly.
+// VIPs maps each virtual "ip:port" to its comma-separated backend list.
+type LogicalLoadBalancer struct {
+	UUID     string
+	Name     string
+	VIPs     map[string]string
+	Protocol string
+}
+
+// LogicalNAT models the minimum fields needed for logical topology assembly.
+// Type is one of snat, dnat, or dnat_and_snat.
+type LogicalNAT struct {
+	UUID        string
+	Type        string
+	ExternalIP  string
+	LogicalIP   string
+	LogicalPort string
+}
+
+// LogicalACL models the minimum fields needed for logical topology assembly.
+type LogicalACL struct {
+	UUID      string
+	Name      string
+	Priority  int
+	Direction string
+	Match     string
+	Action    string
+}
+
+// Stateful reports whether the ACL commits matching traffic to connection tracking.
+// allow-related is stateful; allow-stateless, drop, reject, and pass are not.
+func (acl LogicalACL) Stateful() bool {
+	return acl.Action == "allow-related"
+}
+
+// LogicalQoS models the minimum OVN NB QoS fields needed for logical topology assembly.
+// Bandwidth holds the policing "rate" (kbps) and "burst" (kb) and Action the DSCP marking, keyed
+// as in the OVN columns; either map is empty when the rule does not police or mark.
+type LogicalQoS struct {
+	UUID      string
+	Priority  int
+	Direction string
+	Match     string
+	Action    map[string]int
+	Bandwidth map[string]int
+}
+
+// PortGroup models the minimum OVN NB Port_Group fields needed for logical topology assembly.
+// Network policies are implemented as port groups, so Name usually encodes the namespace.
+// Ports and ACLs hold Logical_Switch_Port and ACL UUIDs.
+type PortGroup struct {
+	UUID  string
+	Name  string
+	Ports []string
+	ACLs  []string
+}
+
+// GatewayChassis models the minimum OVN NB Gateway_Chassis fields needed for logical topology
+// assembly. ChassisName names the SB Chassis that may host the gateway; the highest Priority
+// among a router port's gateway chassis is active.
+type GatewayChassis struct {
+	UUID        string
+	Name        string
+	ChassisName string
+	Priority    int
+}
+
+// HAChassisGroup models the minimum OVN NB HA_Chassis_Group fields needed for logical topology
+// assembly. HAChassisUUIDs reference HA_Chassis rows, which are not collected.
+type HAChassisGroup struct {
+	UUID           string
+	Name           string
+	HAChassisUUIDs []string
+}
+
+// Chassis models the minimum OVN SB Chassis fields needed for physical topology assembly.
+type Chassis struct {
+	UUID     string
+	Name     string
+	Hostname string
+}
+
+// PortBinding models the minimum OVN SB Port_Binding fields needed for physical topology assembly.
+// ChassisUUID is empty when the logical port is not bound to any chassis.
+type PortBinding struct {
+	UUID        string
+	LogicalPort string
+	Type        string
+	ChassisUUID string
+}
+
 type tablePayload struct {
 	Headings []string `json:"headings"`
 	Data     [][]any  `json:"data"`
@@ -61,6 +193,44 @@ func parseTableRows(raw string) ([]map[string]any, bool, error) {
 	return rows, normalized, nil
 }
 
+// applyColumnAliases rewrites table headings that older OVN releases name differently to the
+// headings the parsers expect. aliases maps an alternate heading to its current name; a heading
+// is left alone when the payload already has a column with the current name. The boolean
+// reports whether decoding required normalization, since the rewritten payload no longer does.
+func applyColumnAliases(raw string, aliases map[string]string) (string, bool, error) {
+	if len(aliases) == 0 {
+		return raw, false, nil
+	}
+	payload, normalized, err := decodeTablePayload(raw)
+	if err != nil {
+		return raw, false, err
+	}
+
+	present := make(map[string]bool, len(payload.Headings))
+	for _, heading := range payload.Headings {
+		present[heading] = true
+	}
+	renamed := false
+	for i, heading := range payload.Headings {
+		current, ok := aliases[heading]
+		if !ok || current == heading || present[current] {
+			continue
+		}
+		payload.Headings[i] = current
+		present[current] = true
+		renamed = true
+	}
+	if !renamed {
+		return raw, false, nil
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return raw, false, fmt.Errorf("encode aliased table payload: %w", err)
+	}
+	return string(encoded), normalized, nil
+}
+
 func decodeTablePayload(raw string) (tablePayload, bool, error) {
 	var payload tablePayload
 	if err := json.Unmarshal([]byte(raw), &payload); err == nil {
@@ -80,6 +250,10 @@ func decodeTablePayload(raw string) (tablePayload, bool, error) {
 	return payload, true, nil
 }
 
+// decodeOVSValue unwraps OVSDB's tagged JSON values. OVSDB encodes a set with exactly one
+// member as the bare atom, so a single-port ports column arrives as ["uuid","x"] rather than
+// ["set",[["uuid","x"]]]; both decode to the same atom, which stringSliceField then wraps as a
+// one-element slice. Without this, the tag and the UUID would be read as two stray strings.
 func decodeOVSValue(value any) any {
 	switch typed := value.(type) {
 	case []any:
@@ -87,12 +261,15 @@ func decodeOVSValue(value any) any {
 			tag, ok := typed[0].(string)
 			if ok {
 				switch tag {
-				case "uuid":
+				case "uuid", "named-uuid":
 					return asString(typed[1])
 				case "set":
 					items, ok := typed[1].([]any)
 					if !ok {
-						return []any{}
+						if typed[1] == nil {
+							return []any{}
+						}
+						return []any{decodeOVSValue(typed[1])}
 					}
 					decoded := make([]any, 0, len(items))
 					for _, item := range items {
@@ -143,9 +320,12 @@ func ParseLogicalRouters(raw string) ([]LogicalRouter, bool, error) {
 	routers := make([]LogicalRouter, 0, len(rows))
 	for _, row := range rows {
 		routers = append(routers, LogicalRouter{
-			UUID:      stringField(row, "_uuid"),
-			Name:      stringField(row, "name"),
-			PortUUIDs: stringSliceField(row, "ports"),
+			UUID:              stringField(row, "_uuid"),
+			Name:              stringField(row, "name"),
+			PortUUIDs:         stringSliceField(row, "ports"),
+			LoadBalancerUUIDs: stringSliceField(row, "load_balancer"),
+			NATUUIDs:          stringSliceField(row, "nat"),
+			StaticRouteUUIDs:  stringSliceField(row, "static_routes"),
 		})
 	}
 	return routers, normalized, nil
@@ -160,8 +340,13 @@ func ParseLogicalRouterPorts(raw string) ([]LogicalRouterPort, bool, error) {
 	ports := make([]LogicalRouterPort, 0, len(rows))
 	for _, row := range rows {
 		ports = append(ports, LogicalRouterPort{
-			UUID: stringField(row, "_uuid"),
-			Name: stringField(row, "name"),
+			UUID:                stringField(row, "_uuid"),
+			Name:                stringField(row, "name"),
+			MAC:                 stringField(row, "mac"),
+			Networks:            stringSliceField(row, "networks"),
+			Peer:                optionalStringField(row, "peer"),
+			GatewayChassisUUIDs: stringSliceField(row, "gateway_chassis"),
+			HAChassisGroupUUID:  optionalStringField(row, "ha_chassis_group"),
 		})
 	}
 	return ports, normalized, nil
@@ -176,9 +361,12 @@ func ParseLogicalSwitches(raw string) ([]LogicalSwitch, bool, error) {
 	switches := make([]LogicalSwitch, 0, len(rows))
 	for _, row := range rows {
 		switches = append(switches, LogicalSwitch{
-			UUID:      stringField(row, "_uuid"),
-			Name:      stringField(row, "name"),
-			PortUUIDs: stringSliceField(row, "ports"),
+			UUID:              stringField(row, "_uuid"),
+			Name:              stringField(row, "name"),
+			PortUUIDs:         stringSliceField(row, "ports"),
+			LoadBalancerUUIDs: stringSliceField(row, "load_balancer"),
+			ACLUUIDs:          stringSliceField(row, "acls"),
+			QoSUUIDs:          stringSliceField(row, "qos_rules"),
 		})
 	}
 	return switches, normalized, nil
@@ -192,25 +380,285 @@ func ParseLogicalSwitchPorts(raw string) ([]LogicalSwitchPort, bool, error) {
 
 	ports := make([]LogicalSwitchPort, 0, len(rows))
 	for _, row := range rows {
+		macs, ips := parsePortAddresses(stringSliceField(row, "addresses"))
 		ports = append(ports, LogicalSwitchPort{
+			UUID:              stringField(row, "_uuid"),
+			Name:              stringField(row, "name"),
+			Type:              stringField(row, "type"),
+			Options:           stringMapField(row, "options"),
+			DHCPv4OptionsUUID: optionalStringField(row, "dhcpv4_options"),
+			DHCPv6OptionsUUID: optionalStringField(row, "dhcpv6_options"),
+			MACs:              macs,
+			IPs:               ips,
+		})
+	}
+	return ports, normalized, nil
+}
+
+// parsePortAddresses splits Logical_Switch_Port.addresses entries such as
+// "0a:58:0a:80:00:05 10.128.0.5 fd01::5" into MAC and IP lists. Entries may also be the keywords
+// "dynamic", "router" or "unknown", or mix a keyword with static values ("0a:58:0a:80:00:05
+// dynamic", "dynamic 10.128.0.5"); keywords are skipped and the static values kept. Duplicates
+// across entries are listed once.
+func parsePortAddresses(entries []string) ([]string, []string) {
+	macs := []string{}
+	ips := []string{}
+	for _, entry := range entries {
+		for _, token := range strings.Fields(entry) {
+			if hw, err := net.ParseMAC(token); err == nil && len(hw) == 6 {
+				if mac := hw.String(); !slices.Contains(macs, mac) {
+					macs = append(macs, mac)
+				}
+				continue
+			}
+			if addr, err := netip.ParseAddr(token); err == nil {
+				if ip := addr.String(); !slices.Contains(ips, ip) {
+					ips = append(ips, ip)
+				}
+			}
+		}
+	}
+	return macs, ips
+}
+
+func ParseDHCPOptions(raw string) ([]DHCPOptions, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	options := make([]DHCPOptions, 0, len(rows))
+	for _, row := range rows {
+		options = append(options, DHCPOptions{
 			UUID:    stringField(row, "_uuid"),
-			Name:    stringField(row, "name"),
-			Type:    stringField(row, "type"),
+			CIDR:    stringField(row, "cidr"),
 			Options: stringMapField(row, "options"),
 		})
 	}
-	return ports, normalized, nil
+	return options, normalized, nil
+}
+
+func ParseStaticRoutes(raw string) ([]StaticRoute, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	routes := make([]StaticRoute, 0, len(rows))
+	for _, row := range rows {
+		routes = append(routes, StaticRoute{
+			UUID:       stringField(row, "_uuid"),
+			IPPrefix:   stringField(row, "ip_prefix"),
+			Nexthop:    stringField(row, "nexthop"),
+			OutputPort: optionalStringField(row, "output_port"),
+		})
+	}
+	return routes, normalized, nil
+}
+
+func ParseLoadBalancers(raw string) ([]LogicalLoadBalancer, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	loadBalancers := make([]LogicalLoadBalancer, 0, len(rows))
+	for _, row := range rows {
+		loadBalancers = append(loadBalancers, LogicalLoadBalancer{
+			UUID:     stringField(row, "_uuid"),
+			Name:     stringField(row, "name"),
+			VIPs:     stringMapField(row, "vips"),
+			Protocol: optionalStringField(row, "protocol"),
+		})
+	}
+	return loadBalancers, normalized, nil
+}
+
+func ParseNATs(raw string) ([]LogicalNAT, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	nats := make([]LogicalNAT, 0, len(rows))
+	for _, row := range rows {
+		nats = append(nats, LogicalNAT{
+			UUID:        stringField(row, "_uuid"),
+			Type:        stringField(row, "type"),
+			ExternalIP:  stringField(row, "external_ip"),
+			LogicalIP:   stringField(row, "logical_ip"),
+			LogicalPort: optionalStringField(row, "logical_port"),
+		})
+	}
+	return nats, normalized, nil
+}
+
+func ParseACLs(raw string) ([]LogicalACL, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	acls := make([]LogicalACL, 0, len(rows))
+	for _, row := range rows {
+		acls = append(acls, LogicalACL{
+			UUID:      stringField(row, "_uuid"),
+			Name:      optionalStringField(row, "name"),
+			Priority:  intField(row, "priority"),
+			Direction: stringField(row, "direction"),
+			Match:     stringField(row, "match"),
+			Action:    stringField(row, "action"),
+		})
+	}
+	return acls, normalized, nil
+}
+
+func ParseQoS(raw string) ([]LogicalQoS, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	rules := make([]LogicalQoS, 0, len(rows))
+	for _, row := range rows {
+		rules = append(rules, LogicalQoS{
+			UUID:      stringField(row, "_uuid"),
+			Priority:  intField(row, "priority"),
+			Direction: stringField(row, "direction"),
+			Match:     stringField(row, "match"),
+			Action:    intMapField(row, "action"),
+			Bandwidth: intMapField(row, "bandwidth"),
+		})
+	}
+	return rules, normalized, nil
+}
+
+func ParsePortGroups(raw string) ([]PortGroup, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	portGroups := make([]PortGroup, 0, len(rows))
+	for _, row := range rows {
+		portGroups = append(portGroups, PortGroup{
+			UUID:  stringField(row, "_uuid"),
+			Name:  stringField(row, "name"),
+			Ports: stringSliceField(row, "ports"),
+			ACLs:  stringSliceField(row, "acls"),
+		})
+	}
+	return portGroups, normalized, nil
+}
+
+func ParseGatewayChassis(raw string) ([]GatewayChassis, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	gateways := make([]GatewayChassis, 0, len(rows))
+	for _, row := range rows {
+		gateways = append(gateways, GatewayChassis{
+			UUID:        stringField(row, "_uuid"),
+			Name:        stringField(row, "name"),
+			ChassisName: stringField(row, "chassis_name"),
+			Priority:    intField(row, "priority"),
+		})
+	}
+	return gateways, normalized, nil
+}
+
+func ParseHAChassisGroups(raw string) ([]HAChassisGroup, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	groups := make([]HAChassisGroup, 0, len(rows))
+	for _, row := range rows {
+		groups = append(groups, HAChassisGroup{
+			UUID:           stringField(row, "_uuid"),
+			Name:           stringField(row, "name"),
+			HAChassisUUIDs: stringSliceField(row, "ha_chassis"),
+		})
+	}
+	return groups, normalized, nil
+}
+
+func ParseChassis(raw string) ([]Chassis, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	chassis := make([]Chassis, 0, len(rows))
+	for _, row := range rows {
+		chassis = append(chassis, Chassis{
+			UUID:     stringField(row, "_uuid"),
+			Name:     stringField(row, "name"),
+			Hostname: stringField(row, "hostname"),
+		})
+	}
+	return chassis, normalized, nil
+}
+
+func ParsePortBindings(raw string) ([]PortBinding, bool, error) {
+	rows, normalized, err := parseTableRows(raw)
+	if err != nil {
+		return nil, false, err
+	}
+
+	bindings := make([]PortBinding, 0, len(rows))
+	for _, row := range rows {
+		bindings = append(bindings, PortBinding{
+			UUID:        stringField(row, "_uuid"),
+			LogicalPort: stringField(row, "logical_port"),
+			Type:        stringField(row, "type"),
+			ChassisUUID: optionalStringField(row, "chassis"),
+		})
+	}
+	return bindings, normalized, nil
 }
 
 func stringField(row map[string]any, key string) string {
 	return asString(row[key])
 }
 
+// optionalStringField reads an OVSDB optional column, which is encoded as an empty set when
+// unset and as a bare scalar when set.
+func optionalStringField(row map[string]any, key string) string {
+	values := stringSliceField(row, key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func intField(row map[string]any, key string) int {
+	switch typed := row[key].(type) {
+	case float64:
+		return int(typed)
+	case string:
+		value, err := strconv.Atoi(typed)
+		if err != nil {
+			return 0
+		}
+		return value
+	default:
+		return 0
+	}
+}
+
 func stringSliceField(row map[string]any, key string) []string {
 	raw, ok := row[key]
 	if !ok {
 		return []string{}
 	}
+	return stringSlice(raw)
+}
+
+func stringSlice(raw any) []string {
 	items, ok := raw.([]any)
 	if !ok {
 		if asString(raw) == "" {
@@ -221,6 +669,11 @@ func stringSliceField(row map[string]any, key string) []string {
 
 	out := make([]string, 0, len(items))
 	for _, item := range items {
+		// A set nested in a set is not valid OVSDB, but flatten it rather than emit "[a b]".
+		if _, ok := item.([]any); ok {
+			out = append(out, stringSlice(item)...)
+			continue
+		}
 		value := asString(item)
 		if value == "" {
 			continue
@@ -247,6 +700,27 @@ func stringMapField(row map[string]any, key string) map[string]string {
 	return out
 }
 
+// intMapField reads an OVSDB map column with integer values, such as QoS.bandwidth. Values
+// that are not integers are skipped.
+func intMapField(row map[string]any, key string) map[string]int {
+	out := map[string]int{}
+	mapped, ok := row[key].(map[string]any)
+	if !ok {
+		return out
+	}
+	for mapKey, mapValue := range mapped {
+		switch typed := mapValue.(type) {
+		case float64:
+			out[mapKey] = int(typed)
+		case string:
+			if value, err := strconv.Atoi(typed); err == nil {
+				out[mapKey] = value
+			}
+		}
+	}
+	return out
+}
+
 func asString(value any) string {
 	switch typed := value.(type) {
 	case string:
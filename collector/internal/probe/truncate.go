@@ -0,0 +1,138 @@
+package probe
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// GraphLimits caps the number of nodes and edges in a collected snapshot. Zero disables a limit.
+type GraphLimits struct {
+	MaxNodes int
+	MaxEdges int
+}
+
+// truncateSnapshot trims payload to limits and appends a SNAPSHOT_TRUNCATED warning with the
+// original counts. It reports whether anything was dropped.
+//
+// Truncation is deterministic. Routers and switches are kept ahead of every other kind, then
+// nodes with more edges ahead of less connected ones, with ties broken by ID. Edges to dropped
+// nodes are removed before the edge limit applies, which likewise keeps edges between routers
+// and switches first. Kept nodes and edges stay in their original order, groups lose dropped
+// members (and are dropped once empty), and the kind counts are recomputed for what remains.
+func truncateSnapshot(payload *snapshot.LogicalTopologySnapshot, limits GraphLimits) bool {
+	totalNodes, totalEdges := len(payload.Nodes), len(payload.Edges)
+	if (limits.MaxNodes <= 0 || totalNodes <= limits.MaxNodes) && (limits.MaxEdges <= 0 || totalEdges <= limits.MaxEdges) {
+		return false
+	}
+
+	kindByID := make(map[string]string, totalNodes)
+	for _, node := range payload.Nodes {
+		kindByID[node.ID] = node.Kind
+	}
+	nodes := payload.Nodes
+	if limits.MaxNodes > 0 && totalNodes > limits.MaxNodes {
+		nodes = keepNodes(payload.Nodes, payload.Edges, limits.MaxNodes)
+	}
+	keptNodes := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		keptNodes[node.ID] = true
+	}
+	edges := make([]snapshot.Edge, 0, len(payload.Edges))
+	for _, edge := range payload.Edges {
+		if keptNodes[edge.Source] && keptNodes[edge.Target] {
+			edges = append(edges, edge)
+		}
+	}
+	if limits.MaxEdges > 0 && len(edges) > limits.MaxEdges {
+		edges = keepEdges(edges, kindByID, limits.MaxEdges)
+	}
+
+	groups := make([]snapshot.Group, 0, len(payload.Groups))
+	for _, group := range payload.Groups {
+		nodeIDs := []string{}
+		for _, id := range group.NodeIDs {
+			if keptNodes[id] {
+				nodeIDs = append(nodeIDs, id)
+			}
+		}
+		if len(nodeIDs) > 0 {
+			group.NodeIDs = nodeIDs
+			groups = append(groups, group)
+		}
+	}
+
+	payload.Nodes = nodes
+	payload.Edges = edges
+	payload.Groups = groups
+	payload.Metadata.KindCounts = map[string]int{}
+	for _, node := range nodes {
+		payload.Metadata.KindCounts[node.Kind]++
+	}
+	payload.Metadata.EdgeKindCounts = map[string]int{}
+	for _, edge := range edges {
+		payload.Metadata.EdgeKindCounts[edge.Kind]++
+	}
+	payload.Warnings = append(payload.Warnings, snapshot.NewWarning(
+		snapshot.WarningSnapshotTruncated,
+		fmt.Sprintf("snapshot truncated from %d nodes and %d edges to %d nodes and %d edges", totalNodes, totalEdges, len(nodes), len(edges)),
+	))
+	return true
+}
+
+// keepNodes returns the max highest-ranked nodes in their original order.
+func keepNodes(nodes []snapshot.Node, edges []snapshot.Edge, max int) []snapshot.Node {
+	degree := make(map[string]int, len(nodes))
+	for _, edge := range edges {
+		degree[edge.Source]++
+		if edge.Target != edge.Source {
+			degree[edge.Target]++
+		}
+	}
+
+	ranked := make([]int, len(nodes))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := nodes[ranked[i]], nodes[ranked[j]]
+		if routingKinds[a.Kind] != routingKinds[b.Kind] {
+			return routingKinds[a.Kind]
+		}
+		if degree[a.ID] != degree[b.ID] {
+			return degree[a.ID] > degree[b.ID]
+		}
+		return a.ID < b.ID
+	})
+	return keepIndexes(nodes, ranked[:max])
+}
+
+// keepEdges returns the max highest-ranked edges in their original order.
+func keepEdges(edges []snapshot.Edge, kindByID map[string]string, max int) []snapshot.Edge {
+	routing := func(edge snapshot.Edge) bool {
+		return routingKinds[kindByID[edge.Source]] && routingKinds[kindByID[edge.Target]]
+	}
+
+	ranked := make([]int, len(edges))
+	for i := range ranked {
+		ranked[i] = i
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := edges[ranked[i]], edges[ranked[j]]
+		if routing(a) != routing(b) {
+			return routing(a)
+		}
+		return a.ID < b.ID
+	})
+	return keepIndexes(edges, ranked[:max])
+}
+
+func keepIndexes[T any](items []T, indexes []int) []T {
+	sort.Ints(indexes)
+	kept := make([]T, 0, len(indexes))
+	for _, i := range indexes {
+		kept = append(kept, items[i])
+	}
+	return kept
+}
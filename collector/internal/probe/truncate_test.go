@@ -0,0 +1,89 @@
+package probe
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+func truncateTestSnapshot() snapshot.LogicalTopologySnapshot {
+	return snapshot.LogicalTopologySnapshot{
+		Nodes: []snapshot.Node{
+			{ID: "lr-a", Kind: "logical_router"},
+			{ID: "ls-1", Kind: "logical_switch"},
+			{ID: "lsp-busy", Kind: "logical_switch_port"},
+			{ID: "lsp-idle", Kind: "logical_switch_port"},
+			{ID: "lsp-quiet", Kind: "logical_switch_port"},
+			{ID: "nat-1", Kind: "nat"},
+		},
+		Edges: []snapshot.Edge{
+			{ID: "e1", Source: "lr-a", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "e2", Source: "ls-1", Target: "lsp-busy", Kind: "switch_to_port"},
+			{ID: "e3", Source: "lsp-busy", Target: "nat-1", Kind: "port_to_nat"},
+			{ID: "e4", Source: "ls-1", Target: "lsp-quiet", Kind: "switch_to_port"},
+			{ID: "e5", Source: "lr-a", Target: "nat-1", Kind: "router_to_nat"},
+		},
+	}
+}
+
+func nodeIDs(nodes []snapshot.Node) []string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	return ids
+}
+
+func edgeIDs(edges []snapshot.Edge) []string {
+	ids := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		ids = append(ids, edge.ID)
+	}
+	return ids
+}
+
+func TestTruncateSnapshotLeavesGraphsWithinLimits(t *testing.T) {
+	for _, limits := range []GraphLimits{{}, {MaxNodes: 6, MaxEdges: 5}, {MaxNodes: 100}} {
+		payload := truncateTestSnapshot()
+		if truncateSnapshot(&payload, limits) {
+			t.Fatalf("expected no truncation with limits %+v", limits)
+		}
+		if !reflect.DeepEqual(payload, truncateTestSnapshot()) || len(payload.Warnings) != 0 {
+			t.Fatalf("expected snapshot unchanged with limits %+v, got %#v", limits, payload)
+		}
+	}
+}
+
+func TestTruncateSnapshotKeepsRoutingAndBestConnectedNodes(t *testing.T) {
+	payload := truncateTestSnapshot()
+	if !truncateSnapshot(&payload, GraphLimits{MaxNodes: 4, MaxEdges: 2}) {
+		t.Fatal("expected the snapshot to be truncated")
+	}
+
+	// Routers and switches first, then the ports and NAT with the most edges.
+	if got, want := nodeIDs(payload.Nodes), []string{"lr-a", "ls-1", "lsp-busy", "nat-1"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected nodes %v, got %v", want, got)
+	}
+	// e4 leads to a dropped node; of the rest, the router-switch edge ranks first.
+	if got, want := edgeIDs(payload.Edges), []string{"e1", "e2"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected edges %v, got %v", want, got)
+	}
+	if want := map[string]int{"logical_router": 1, "logical_switch": 1, "logical_switch_port": 1, "nat": 1}; !reflect.DeepEqual(payload.Metadata.KindCounts, want) {
+		t.Fatalf("expected kind counts %v, got %v", want, payload.Metadata.KindCounts)
+	}
+	if want := map[string]int{"router_to_switch": 1, "switch_to_port": 1}; !reflect.DeepEqual(payload.Metadata.EdgeKindCounts, want) {
+		t.Fatalf("expected edge kind counts %v, got %v", want, payload.Metadata.EdgeKindCounts)
+	}
+	if len(payload.Warnings) != 1 || payload.Warnings[0].Code != snapshot.WarningSnapshotTruncated ||
+		!strings.Contains(payload.Warnings[0].Message, "from 6 nodes and 5 edges to 4 nodes and 2 edges") {
+		t.Fatalf("expected one SNAPSHOT_TRUNCATED warning with the original counts, got %#v", payload.Warnings)
+	}
+
+	again := truncateTestSnapshot()
+	truncateSnapshot(&again, GraphLimits{MaxNodes: 4, MaxEdges: 2})
+	if !reflect.DeepEqual(again, payload) {
+		t.Fatalf("expected deterministic truncation, got %#v and %#v", payload, again)
+	}
+}
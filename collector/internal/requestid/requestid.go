@@ -0,0 +1,58 @@
+// Package requestid carries the per-request correlation ID from the HTTP layer to the probe
+// runners so their log lines can be tied back to one API request.
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+)
+
+// Header is the HTTP header that carries the request ID in both directions.
+const Header = "X-Request-ID"
+
+// maxLength bounds caller-supplied IDs so they cannot bloat every log line.
+const maxLength = 128
+
+type contextKey struct{}
+
+// New returns a random 32-character hex request ID.
+func New() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// Valid reports whether id is safe to reuse from a caller: non-empty, at most 128 characters,
+// and printable ASCII without spaces.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// WithID returns a copy of ctx carrying id.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID in ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Logger returns logger with a requestId attribute when ctx carries a request ID.
+func Logger(ctx context.Context, logger *slog.Logger) *slog.Logger {
+	if id := FromContext(ctx); id != "" {
+		return logger.With("requestId", id)
+	}
+	return logger
+}
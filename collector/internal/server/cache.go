@@ -0,0 +1,89 @@
+package server
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+	"golang.org/x/sync/singleflight"
+)
+
+// snapshotCache keeps live snapshots per node for a fixed TTL and coalesces concurrent
+// collections for the same node into a single probe run.
+type snapshotCache struct {
+	ttl   time.Duration
+	clock clock.Clock
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	payload   snapshot.LogicalTopologySnapshot
+	expiresAt time.Time
+}
+
+func newSnapshotCache(ttl time.Duration, clk clock.Clock) *snapshotCache {
+	return &snapshotCache{
+		ttl:     ttl,
+		clock:   clk,
+		entries: map[string]cacheEntry{},
+	}
+}
+
+// collect returns a cached snapshot for the node when one is still fresh, otherwise it runs
+// collectFn once for all concurrent callers and caches a successful result. bypass skips the
+// cached entry but still refreshes it. The boolean reports whether the payload was served from
+// the cache.
+func (c *snapshotCache) collect(ctx context.Context, nodeName string, bypass bool, collectFn func(context.Context, string) (snapshot.LogicalTopologySnapshot, error)) (snapshot.LogicalTopologySnapshot, bool, error) {
+	if !bypass {
+		if payload, ok := c.get(nodeName); ok {
+			return payload, true, nil
+		}
+	}
+
+	// The collection is shared by every coalesced caller, so it runs detached from the
+	// cancellation of whichever request started it and stays bounded by the probe timeout.
+	// Each caller still stops waiting when its own context ends.
+	results := c.group.DoChan(nodeName, func() (interface{}, error) {
+		payload, err := collectFn(context.WithoutCancel(ctx), nodeName)
+		if err != nil {
+			return nil, err
+		}
+		c.put(nodeName, payload)
+		return payload, nil
+	})
+	select {
+	case <-ctx.Done():
+		return snapshot.LogicalTopologySnapshot{}, false, ctx.Err()
+	case result := <-results:
+		if result.Err != nil {
+			return snapshot.LogicalTopologySnapshot{}, false, result.Err
+		}
+		return result.Val.(snapshot.LogicalTopologySnapshot), false, nil
+	}
+}
+
+func (c *snapshotCache) get(nodeName string) (snapshot.LogicalTopologySnapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[nodeName]
+	if !ok {
+		return snapshot.LogicalTopologySnapshot{}, false
+	}
+	if !c.clock.Now().Before(entry.expiresAt) {
+		delete(c.entries, nodeName)
+		return snapshot.LogicalTopologySnapshot{}, false
+	}
+	return entry.payload, true
+}
+
+func (c *snapshotCache) put(nodeName string, payload snapshot.LogicalTopologySnapshot) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[nodeName] = cacheEntry{payload: payload, expiresAt: c.clock.Now().Add(c.ttl)}
+}
@@ -0,0 +1,56 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// handleCaptureSnapshot serves POST /api/v1/snapshots/{node}: it forces a live collection,
+// bypassing the snapshot cache, stores the result and returns it with 201 Created. Without a
+// live collector there is nothing to capture, so the request conflicts with the server mode.
+func (s *Server) handleCaptureSnapshot(w http.ResponseWriter, r *http.Request, nodeName string) {
+	if strings.HasPrefix(nodeName, reservedNodePrefix) {
+		http.Error(w, fmt.Sprintf("node name %q is reserved", nodeName), http.StatusNotFound)
+		return
+	}
+	if s.liveCollector == nil {
+		http.Error(w, "live collection is not configured; snapshots cannot be captured", http.StatusConflict)
+		return
+	}
+
+	logger := s.logger.With("node", nodeName)
+	logger.Info("logical topology snapshot capture requested")
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	payload, _, err := s.collectLive(ctx, nodeName, true)
+	if err != nil {
+		logger.Warn("live OVN probe failed; snapshot not captured", "error", err)
+		http.Error(w, fmt.Sprintf("live collection failed: %v", err), http.StatusBadGateway)
+		return
+	}
+	payload = s.decorateMetadata(payload, nodeName)
+
+	if err := s.store.Put(r.Context(), nodeName, payload); err != nil {
+		logger.Error("failed to store captured snapshot", "error", err)
+		http.Error(w, fmt.Sprintf("failed to store snapshot: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		logger.Error("failed to encode captured snapshot", "error", err)
+		http.Error(w, fmt.Sprintf("failed to encode payload: %v", err), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Location", s.snapshotsPrefix+nodeName)
+	setSnapshotHeaders(w, payload)
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(append(body, '\n')); err != nil {
+		logger.Error("failed to write captured snapshot", "error", err)
+	}
+}
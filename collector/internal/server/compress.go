@@ -0,0 +1,76 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// compressionThreshold is the smallest response body, in bytes, worth compressing.
+const compressionThreshold = 1024
+
+const (
+	encodingGzip    = "gzip"
+	encodingDeflate = "deflate"
+)
+
+// negotiateEncoding picks gzip or deflate from an Accept-Encoding header, preferring gzip.
+// It returns an empty string when the client accepts neither.
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := map[string]bool{}
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		fields := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(fields[0]))
+		if coding == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.ToLower(strings.TrimSpace(name)) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = parsed
+			}
+		}
+		accepted[coding] = quality > 0
+	}
+
+	for _, coding := range []string{encodingGzip, encodingDeflate} {
+		if enabled, ok := accepted[coding]; ok {
+			if enabled {
+				return coding
+			}
+			continue
+		}
+		if accepted["*"] {
+			return coding
+		}
+	}
+	return ""
+}
+
+// compressBody encodes body with the given content coding.
+func compressBody(body []byte, encoding string) ([]byte, error) {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	switch encoding {
+	case encodingGzip:
+		writer = gzip.NewWriter(&buf)
+	case encodingDeflate:
+		writer = zlib.NewWriter(&buf)
+	default:
+		return body, nil
+	}
+	if _, err := writer.Write(body); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// handleSnapshotDiff serves /api/v1/snapshots/{node}/diff?against={otherNode}, comparing the
+// snapshot for nodeName with the one for otherNode. Either snapshot missing yields 404.
+func (s *Server) handleSnapshotDiff(w http.ResponseWriter, r *http.Request, nodeName string) {
+	against := strings.TrimSpace(r.URL.Query().Get("against"))
+	if against == "" || strings.Contains(against, "/") {
+		http.Error(w, "missing or invalid against node name", http.StatusBadRequest)
+		return
+	}
+
+	base, ok := s.loadSnapshot(w, r, nodeName)
+	if !ok {
+		return
+	}
+	other, ok := s.loadSnapshot(w, r, against)
+	if !ok {
+		return
+	}
+	if base.Metadata.NodeName == "" {
+		base.Metadata.NodeName = nodeName
+	}
+	if other.Metadata.NodeName == "" {
+		other.Metadata.NodeName = against
+	}
+
+	// Cache state is per snapshot and would be ambiguous for a pair.
+	w.Header().Del(headerSnapshotCache)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(snapshot.DiffSnapshots(base, other)); err != nil {
+		slog.Error("failed to encode snapshot diff", "node", nodeName, "against", against, "error", err)
+	}
+}
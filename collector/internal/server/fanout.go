@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// snapshotsPath serves every discovered node's snapshot at once; the trailing-slash prefix
+// still serves a single node.
+const snapshotsPath = "/api/v1/snapshots"
+
+// DefaultFanoutConcurrency bounds how many nodes a fan-out request probes at the same time.
+const DefaultFanoutConcurrency = 4
+
+// fanoutError is the entry returned in place of a snapshot for a node whose collection failed.
+type fanoutError struct {
+	Error string `json:"error"`
+}
+
+// SetFanoutConcurrency bounds the live collections run at once by GET /api/v1/snapshots. A
+// non-positive limit restores DefaultFanoutConcurrency.
+func (s *Server) SetFanoutConcurrency(limit int) {
+	if limit <= 0 {
+		limit = DefaultFanoutConcurrency
+	}
+	s.fanoutConcurrency = limit
+}
+
+// handleSnapshotFanout serves GET /api/v1/snapshots: a map of every live-discovered node to its
+// snapshot, or to an error object when that node's collection failed. Without node discovery
+// there is nothing to fan out over, so the request conflicts with the server mode.
+func (s *Server) handleSnapshotFanout(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	lister, ok := s.liveCollector.(NodeLister)
+	if !ok {
+		http.Error(w, "live node discovery is not configured; request a single node instead", http.StatusConflict)
+		return
+	}
+
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	nodeNames, err := lister.ListNodes(ctx)
+	if err != nil {
+		s.logger.Warn("live node discovery failed; snapshots not collected", "error", err)
+		http.Error(w, fmt.Sprintf("live node discovery failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	results := s.collectFanout(ctx, nodeNames, requestsNoCache(r))
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(results); err != nil {
+		s.logger.Error("failed to encode fan-out snapshots", "error", err)
+	}
+}
+
+// collectFanout collects every node with at most s.fanoutConcurrency collections in flight.
+func (s *Server) collectFanout(ctx context.Context, nodeNames []string, bypassCache bool) map[string]any {
+	limit := s.fanoutConcurrency
+	if limit <= 0 {
+		limit = DefaultFanoutConcurrency
+	}
+
+	results := make(map[string]any, len(nodeNames))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, limit)
+	for _, nodeName := range nodeNames {
+		wg.Add(1)
+		go func(nodeName string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			var result any
+			payload, _, err := s.collectLive(ctx, nodeName, bypassCache)
+			if err != nil {
+				s.logger.Warn("live OVN probe failed during fan-out", "node", nodeName, "error", err)
+				result = fanoutError{Error: err.Error()}
+			} else {
+				result = s.decorateMetadata(payload, nodeName)
+			}
+			mu.Lock()
+			results[nodeName] = result
+			mu.Unlock()
+		}(nodeName)
+	}
+	wg.Wait()
+	return results
+}
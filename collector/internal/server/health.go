@@ -0,0 +1,40 @@
+package server
+
+import (
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+const (
+	// reservedNodePrefix marks node names served by the collector itself rather than looked up
+	// in OVN or the snapshot store. Kubernetes node names cannot start with it.
+	reservedNodePrefix = "_"
+	// healthSnapshotNode serves a fixed synthetic topology for end-to-end smoke tests.
+	healthSnapshotNode = "_health"
+)
+
+// healthSnapshot returns a tiny topology that is valid without OVN: one router, one switch, one
+// switch port and a single router-to-switch edge.
+func healthSnapshot(now time.Time) snapshot.LogicalTopologySnapshot {
+	return snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{
+			SchemaVersion:  snapshot.SchemaVersion,
+			GeneratedAt:    now.UTC(),
+			SourceHealth:   "healthy",
+			NodeName:       healthSnapshotNode,
+			KindCounts:     map[string]int{"logical_router": 1, "logical_switch": 1, "logical_switch_port": 1},
+			EdgeKindCounts: map[string]int{"router_to_switch": 1},
+		},
+		Nodes: []snapshot.Node{
+			{ID: "health-router", Kind: "logical_router", Label: "health-router"},
+			{ID: "health-switch", Kind: "logical_switch", Label: "health-switch"},
+			{ID: "health-switch-port", Kind: "logical_switch_port", Label: "health-switch-port"},
+		},
+		Edges: []snapshot.Edge{
+			{ID: "router_to_switch:health-router:health-switch", Source: "health-router", Target: "health-switch", Kind: "router_to_switch"},
+		},
+		Groups:   []snapshot.Group{},
+		Warnings: []snapshot.Warning{},
+	}
+}
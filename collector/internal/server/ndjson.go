@@ -0,0 +1,90 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+const (
+	formatJSON   = "json"
+	formatNDJSON = "ndjson"
+
+	contentTypeNDJSON = "application/x-ndjson"
+)
+
+// parseFormatQuery reads ?format=json|ndjson. An empty value selects JSON.
+func parseFormatQuery(r *http.Request) (string, error) {
+	format := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("format")))
+	switch format {
+	case "", formatJSON:
+		return formatJSON, nil
+	case formatNDJSON:
+		return formatNDJSON, nil
+	default:
+		return "", fmt.Errorf("format must be %q or %q", formatJSON, formatNDJSON)
+	}
+}
+
+// ndjsonRecord is one line of an NDJSON snapshot stream. Type names which of the other fields
+// is set: metadata first, then every node, edge, group and warning in that order.
+type ndjsonRecord struct {
+	Type     string             `json:"type"`
+	Metadata *snapshot.Metadata `json:"metadata,omitempty"`
+	Node     *snapshot.Node     `json:"node,omitempty"`
+	Edge     *snapshot.Edge     `json:"edge,omitempty"`
+	Group    *snapshot.Group    `json:"group,omitempty"`
+	Warning  *snapshot.Warning  `json:"warning,omitempty"`
+}
+
+// writeSnapshotNDJSON streams the snapshot one record per line, flushing after each record so
+// clients can start rendering before the whole graph arrives. Streams are not compressed and
+// are not subject to the maximum snapshot size, which only bounds buffered JSON responses.
+func (s *Server) writeSnapshotNDJSON(w http.ResponseWriter, r *http.Request, payload snapshot.LogicalTopologySnapshot, nodeName string) {
+	payload = s.decorateMetadata(payload, nodeName)
+
+	w.Header().Set("Content-Type", contentTypeNDJSON)
+	setSnapshotHeaders(w, payload)
+	w.WriteHeader(snapshotStatus(w, r, payload))
+
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+	write := func(record ndjsonRecord) bool {
+		if err := encoder.Encode(record); err != nil {
+			slog.Error("failed to write snapshot stream", "node", nodeName, "type", record.Type, "error", err)
+			return false
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return true
+	}
+
+	if !write(ndjsonRecord{Type: "metadata", Metadata: &payload.Metadata}) {
+		return
+	}
+	for i := range payload.Nodes {
+		if !write(ndjsonRecord{Type: "node", Node: &payload.Nodes[i]}) {
+			return
+		}
+	}
+	for i := range payload.Edges {
+		if !write(ndjsonRecord{Type: "edge", Edge: &payload.Edges[i]}) {
+			return
+		}
+	}
+	for i := range payload.Groups {
+		if !write(ndjsonRecord{Type: "group", Group: &payload.Groups[i]}) {
+			return
+		}
+	}
+	for i := range payload.Warnings {
+		if !write(ndjsonRecord{Type: "warning", Warning: &payload.Warnings[i]}) {
+			return
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// Vendor media types select a snapshot wire schema version, e.g.
+// application/vnd.ovn-recon.snapshot.v1alpha1+json.
+const (
+	snapshotMediaTypePrefix = "application/vnd.ovn-recon.snapshot."
+	snapshotMediaTypeSuffix = "+json"
+)
+
+// negotiateSchemaVersion picks the wire schema version of a JSON snapshot response and the
+// Content-Type to answer with. ?schema=<version> wins over Accept and keeps application/json.
+// Otherwise the highest-quality supported vendor media type in Accept is used, unless
+// application/json or a wildcard is preferred over it. Clients that send no vendor media type get
+// the current version as application/json. An error means the client asked only for versions
+// the collector cannot produce and should be answered with 406.
+func negotiateSchemaVersion(r *http.Request) (string, string, error) {
+	if requested := strings.TrimSpace(r.URL.Query().Get("schema")); requested != "" {
+		if !slices.Contains(snapshot.SupportedSchemaVersions(), requested) {
+			return "", "", unsupportedSchemaError(requested)
+		}
+		return requested, "application/json", nil
+	}
+
+	bestVersion, bestQuality := "", 0.0
+	genericQuality := -1.0
+	unsupported := ""
+	for _, part := range strings.Split(r.Header.Get("Accept"), ",") {
+		fields := strings.Split(part, ";")
+		mediaType := strings.ToLower(strings.TrimSpace(fields[0]))
+		if mediaType == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.ToLower(strings.TrimSpace(name)) != "q" {
+				continue
+			}
+			if parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+				quality = parsed
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(mediaType, snapshotMediaTypePrefix) && strings.HasSuffix(mediaType, snapshotMediaTypeSuffix):
+			version := strings.TrimSuffix(strings.TrimPrefix(mediaType, snapshotMediaTypePrefix), snapshotMediaTypeSuffix)
+			if !slices.Contains(snapshot.SupportedSchemaVersions(), version) {
+				if unsupported == "" {
+					unsupported = version
+				}
+				continue
+			}
+			if quality > bestQuality {
+				bestVersion, bestQuality = version, quality
+			}
+		case mediaType == "application/json", mediaType == "application/*", mediaType == "*/*":
+			genericQuality = max(genericQuality, quality)
+		}
+	}
+
+	switch {
+	case bestVersion != "" && bestQuality >= genericQuality:
+		return bestVersion, snapshotMediaTypePrefix + bestVersion + snapshotMediaTypeSuffix, nil
+	case unsupported != "" && genericQuality < 0:
+		return "", "", unsupportedSchemaError(unsupported)
+	default:
+		return snapshot.SchemaVersion, "application/json", nil
+	}
+}
+
+func unsupportedSchemaError(version string) error {
+	return fmt.Errorf("snapshot schema version %q is not supported (supported: %s)",
+		version, strings.Join(snapshot.SupportedSchemaVersions(), ", "))
+}
@@ -0,0 +1,32 @@
+package server
+
+import (
+	"net/http"
+	"net/http/pprof"
+)
+
+// pprofPath is where the runtime profiling endpoints are mounted when enabled.
+const pprofPath = "/debug/pprof/"
+
+// SetPprofEnabled mounts the net/http/pprof endpoints under /debug/pprof/ on the API handler,
+// behind the same bearer token as the API. They are never served unless enabled.
+func (s *Server) SetPprofEnabled(enabled bool) {
+	s.pprofEnabled = enabled
+}
+
+// PprofHandler returns a handler serving only the net/http/pprof endpoints, for a dedicated
+// profiling listener that is kept off the API port.
+func PprofHandler() http.Handler {
+	mux := http.NewServeMux()
+	registerPprof(mux, func(next http.HandlerFunc) http.HandlerFunc { return next })
+	return mux
+}
+
+// registerPprof adds the pprof routes to mux, wrapping each with wrap.
+func registerPprof(mux *http.ServeMux, wrap func(http.HandlerFunc) http.HandlerFunc) {
+	mux.HandleFunc(pprofPath, wrap(pprof.Index))
+	mux.HandleFunc(pprofPath+"cmdline", wrap(pprof.Cmdline))
+	mux.HandleFunc(pprofPath+"profile", wrap(pprof.Profile))
+	mux.HandleFunc(pprofPath+"symbol", wrap(pprof.Symbol))
+	mux.HandleFunc(pprofPath+"trace", wrap(pprof.Trace))
+}
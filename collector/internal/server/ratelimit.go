@@ -0,0 +1,133 @@
+package server
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterIdleTTL is how long a client's bucket is kept after its last request. An idle
+// bucket has refilled long before then, so dropping it changes nothing for the client.
+const rateLimiterIdleTTL = 10 * time.Minute
+
+// clientRateLimiter keeps a token bucket per client so one misbehaving client cannot drive
+// probes against the OVN pods on behalf of everyone else.
+type clientRateLimiter struct {
+	limit     rate.Limit
+	burst     int
+	keyHeader string
+	clock     clock.Clock
+
+	mu        sync.Mutex
+	buckets   map[string]*clientBucket
+	lastSweep time.Time
+}
+
+type clientBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newClientRateLimiter(rps float64, burst int, keyHeader string, clk clock.Clock) *clientRateLimiter {
+	return &clientRateLimiter{
+		limit:     rate.Limit(rps),
+		burst:     burst,
+		keyHeader: keyHeader,
+		clock:     clk,
+		buckets:   map[string]*clientBucket{},
+	}
+}
+
+// reserve takes a token from key's bucket. When none is available it returns how long the
+// client should wait before retrying, without consuming anything.
+func (l *clientRateLimiter) reserve(key string) (time.Duration, bool) {
+	now := l.clock.Now()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if now.Sub(l.lastSweep) >= rateLimiterIdleTTL {
+		for bucketKey, bucket := range l.buckets {
+			if now.Sub(bucket.lastSeen) >= rateLimiterIdleTTL {
+				delete(l.buckets, bucketKey)
+			}
+		}
+		l.lastSweep = now
+	}
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &clientBucket{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.buckets[key] = bucket
+	}
+	bucket.lastSeen = now
+
+	reservation := bucket.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return rateLimiterIdleTTL, false
+	}
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
+
+// clientKey identifies the caller by the configured header when it is present, otherwise by
+// the remote IP. For X-Forwarded-For style lists only the first (originating) entry counts.
+func (l *clientRateLimiter) clientKey(r *http.Request) string {
+	if l.keyHeader != "" {
+		if value := r.Header.Get(l.keyHeader); value != "" {
+			first, _, _ := strings.Cut(value, ",")
+			if key := strings.TrimSpace(first); key != "" {
+				return key
+			}
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// SetRateLimit limits each client to rps requests per second with bursts of up to burst
+// requests, answering 429 once the bucket is empty. Clients are told apart by keyHeader when
+// the request carries it, otherwise by remote IP. A non-positive rps disables the limit; a
+// non-positive burst defaults to rps rounded up.
+func (s *Server) SetRateLimit(rps float64, burst int, keyHeader string) {
+	if rps <= 0 {
+		s.rateLimiter = nil
+		return
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(rps))
+	}
+	s.rateLimiter = newClientRateLimiter(rps, burst, strings.TrimSpace(keyHeader), s.clock)
+}
+
+// limitRate applies the per-client rate limit to every route except the health endpoints,
+// which kubelet probes must always reach.
+func (s *Server) limitRate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limiter := s.rateLimiter
+		if limiter == nil || r.URL.Path == "/healthz" || r.URL.Path == "/readyz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		key := limiter.clientKey(r)
+		retryAfter, ok := limiter.reserve(key)
+		if !ok {
+			s.logger.Warn("client rate limit exceeded", "client", key, "path", r.URL.Path)
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			http.Error(w, "too many requests", http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
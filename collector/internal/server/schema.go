@@ -0,0 +1,26 @@
+package server
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/dlbewley/ovn-recon/collector/api"
+)
+
+// handleSchema serves the JSON Schema describing the snapshot payload.
+func (s *Server) handleSchema(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodHead:
+		w = headResponseWriter{ResponseWriter: w}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/schema+json")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(api.LogicalTopologySnapshotSchema); err != nil {
+		slog.Error("failed to write snapshot schema", "error", err)
+	}
+}
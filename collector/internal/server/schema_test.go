@@ -0,0 +1,115 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+func TestSchemaEndpointValidatesServedSnapshots(t *testing.T) {
+	s := New(snapshot.NewFileStore("../../fixtures/snapshots", "default.json"))
+	s.SetClusterID("cluster-a")
+
+	schemaRR := httptest.NewRecorder()
+	s.Handler().ServeHTTP(schemaRR, httptest.NewRequest(http.MethodGet, schemaPath, nil))
+	if schemaRR.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", schemaRR.Code)
+	}
+	if got := schemaRR.Header().Get("Content-Type"); got != "application/schema+json" {
+		t.Fatalf("expected schema content type, got %q", got)
+	}
+	var schema map[string]any
+	if err := json.Unmarshal(schemaRR.Body.Bytes(), &schema); err != nil {
+		t.Fatalf("served schema is not valid JSON: %v", err)
+	}
+	if got := schema["x-schema-version"]; got != snapshot.SchemaVersion {
+		t.Fatalf("expected schema version %q, got %v", snapshot.SchemaVersion, got)
+	}
+
+	for _, node := range []string{"worker-a", "worker-dense", "worker-parse-edge"} {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, s.snapshotsPrefix+node, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", node, rr.Code)
+		}
+		var payload any
+		if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+			t.Fatalf("invalid snapshot JSON for %s: %v", node, err)
+		}
+		if problems := validateJSONSchema(schema, payload, "$"); len(problems) > 0 {
+			t.Fatalf("snapshot %s does not match the served schema: %v", node, problems)
+		}
+	}
+
+	invalid := map[string]any{"metadata": map[string]any{}, "nodes": []any{}}
+	if problems := validateJSONSchema(schema, invalid, "$"); len(problems) == 0 {
+		t.Fatalf("expected an incomplete snapshot to fail validation")
+	}
+}
+
+// validateJSONSchema checks value against the subset of JSON Schema the snapshot schema uses:
+// type, required, properties, additionalProperties, items, enum and minimum.
+func validateJSONSchema(schema map[string]any, value any, path string) []string {
+	problems := []string{}
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, value) {
+		problems = append(problems, fmt.Sprintf("%s: %v is not one of %v", path, value, enum))
+	}
+
+	switch schema["type"] {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: expected object", path))
+		}
+		properties, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, name := range required {
+				if _, present := object[name.(string)]; !present {
+					problems = append(problems, fmt.Sprintf("%s: missing required %q", path, name))
+				}
+			}
+		}
+		for name, field := range object {
+			if property, ok := properties[name].(map[string]any); ok {
+				problems = append(problems, validateJSONSchema(property, field, path+"."+name)...)
+				continue
+			}
+			switch additional := schema["additionalProperties"].(type) {
+			case bool:
+				if !additional {
+					problems = append(problems, fmt.Sprintf("%s: unexpected property %q", path, name))
+				}
+			case map[string]any:
+				problems = append(problems, validateJSONSchema(additional, field, path+"."+name)...)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			return append(problems, fmt.Sprintf("%s: expected array", path))
+		}
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range array {
+				problems = append(problems, validateJSONSchema(items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case "string":
+		if _, ok := value.(string); !ok {
+			problems = append(problems, fmt.Sprintf("%s: expected string", path))
+		}
+	case "integer":
+		number, ok := value.(float64)
+		if !ok || number != float64(int64(number)) {
+			return append(problems, fmt.Sprintf("%s: expected integer", path))
+		}
+		if minimum, ok := schema["minimum"].(float64); ok && number < minimum {
+			problems = append(problems, fmt.Sprintf("%s: %v is below minimum %v", path, number, minimum))
+		}
+	}
+	return problems
+}
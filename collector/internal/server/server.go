@@ -2,21 +2,38 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
 	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 )
 
-const snapshotsPrefix = "/api/v1/snapshots/"
+const summarySuffix = "/summary"
+const statsSuffix = "/stats"
+const diffSuffix = "/diff"
+const nodesPath = "/api/v1/nodes"
+const schemaPath = "/api/v1/schema"
 const (
 	headerSnapshotGeneratedAt  = "X-OVN-Recon-Snapshot-Generated-At"
 	headerSnapshotSourceHealth = "X-OVN-Recon-Snapshot-Source-Health"
 	headerSnapshotNodeName     = "X-OVN-Recon-Snapshot-Node-Name"
+	headerSnapshotCache        = "X-OVN-Recon-Snapshot-Cache"
+	headerSnapshotWarnings     = "X-OVN-Recon-Snapshot-Warning-Count"
+	// headerSignalDegraded is the request header that opts into 203 for degraded snapshots.
+	headerSignalDegraded = "X-OVN-Recon-Signal-Degraded"
 )
 
 // LiveCollector builds node-scoped snapshots by interrogating OVN at request time.
@@ -24,18 +41,48 @@ type LiveCollector interface {
 	Collect(ctx context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error)
 }
 
+// NodeLister is implemented by live collectors that can discover probe-able nodes.
+type NodeLister interface {
+	ListNodes(ctx context.Context) ([]string, error)
+}
+
+// ReadinessChecker is implemented by live collectors that can verify their backend is reachable.
+type ReadinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+// readinessTimeout bounds the backend check behind /readyz so a hung API server fails the probe
+// instead of stalling it.
+const readinessTimeout = 5 * time.Second
+
 // Server wraps HTTP handlers for the OVN collector.
 type Server struct {
 	store         snapshot.Store
 	liveCollector LiveCollector
 	logger        *slog.Logger
+	clusterID     string
+	cache         *snapshotCache
+	maxBytes      int
+	authToken     string
+	defaultNode   string
+	clock         clock.Clock
+	rateLimiter   *clientRateLimiter
+	// basePath prefixes every API route; snapshotsPrefix is the per-node snapshot route under it.
+	basePath        string
+	snapshotsPrefix string
+	pprofEnabled    bool
+
+	fanoutConcurrency int
 }
 
 // New creates a collector HTTP server.
 func New(store snapshot.Store) *Server {
 	return &Server{
-		store:  store,
-		logger: slog.Default(),
+		store:             store,
+		logger:            slog.Default(),
+		clock:             clock.Real{},
+		snapshotsPrefix:   snapshotsPath + "/",
+		fanoutConcurrency: DefaultFanoutConcurrency,
 	}
 }
 
@@ -46,13 +93,82 @@ func NewWithLiveCollector(store snapshot.Store, collector LiveCollector) *Server
 	return s
 }
 
+// SetClusterID sets the cluster identifier stamped into every served snapshot's metadata.
+func (s *Server) SetClusterID(clusterID string) {
+	s.clusterID = strings.TrimSpace(clusterID)
+}
+
+// SetSnapshotCacheTTL caches live snapshots per node for ttl. A non-positive ttl disables caching.
+func (s *Server) SetSnapshotCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		s.cache = nil
+		return
+	}
+	s.cache = newSnapshotCache(ttl, s.clock)
+}
+
+// SetClock replaces the time source used for cache expiry and rate limiting. A nil clock restores the wall clock.
+func (s *Server) SetClock(clk clock.Clock) {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	s.clock = clk
+	if s.cache != nil {
+		s.cache.clock = clk
+	}
+	if s.rateLimiter != nil {
+		s.rateLimiter.clock = clk
+	}
+}
+
+// SetMaxSnapshotBytes rejects snapshots whose serialized JSON exceeds maxBytes with 413.
+// A non-positive maxBytes disables the limit.
+func (s *Server) SetMaxSnapshotBytes(maxBytes int) {
+	if maxBytes < 0 {
+		maxBytes = 0
+	}
+	s.maxBytes = maxBytes
+}
+
+// SetAuthToken requires API requests to send "Authorization: Bearer <token>". An empty token
+// disables authentication. Health endpoints never require a token.
+func (s *Server) SetAuthToken(token string) {
+	s.authToken = strings.TrimSpace(token)
+}
+
+// SetDefaultNode serves nodeName's snapshot for "/" and the bare snapshots prefix, which
+// otherwise require an explicit node name. An empty nodeName keeps that requirement.
+func (s *Server) SetDefaultNode(nodeName string) {
+	s.defaultNode = strings.TrimSpace(nodeName)
+}
+
+// SetBasePath mounts every API route, including "/", under basePath (e.g. "/collector") for
+// serving behind a reverse proxy at a sub-path. /healthz and /readyz stay at the root for
+// kubelet probes. An empty basePath or "/" mounts the routes at the root.
+func (s *Server) SetBasePath(basePath string) {
+	basePath = strings.TrimRight(strings.TrimSpace(basePath), "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	s.basePath = basePath
+	s.snapshotsPrefix = basePath + snapshotsPath + "/"
+}
+
 // Handler returns the collector HTTP handler.
 func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/healthz", s.handleHealth)
 	mux.HandleFunc("/readyz", s.handleReady)
-	mux.HandleFunc(snapshotsPrefix, s.handleSnapshotByNode)
-	return mux
+	mux.HandleFunc(s.snapshotsPrefix, s.requireBearerToken(s.handleSnapshotByNode))
+	mux.HandleFunc(s.basePath+snapshotsPath, s.requireBearerToken(s.handleSnapshotFanout))
+	mux.HandleFunc(s.basePath+"/{$}", s.requireBearerToken(s.handleRoot))
+	mux.HandleFunc(s.basePath+nodesPath, s.requireBearerToken(s.handleListNodes))
+	mux.HandleFunc(s.basePath+schemaPath, s.requireBearerToken(s.handleSchema))
+	mux.HandleFunc(s.basePath+statusPath, s.requireBearerToken(s.handleStatus))
+	if s.pprofEnabled {
+		registerPprof(mux, s.requireBearerToken)
+	}
+	return s.withRequestID(s.limitRate(mux))
 }
 
 func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
@@ -60,63 +176,269 @@ func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
 	_, _ = w.Write([]byte("ok"))
 }
 
-func (s *Server) handleReady(w http.ResponseWriter, _ *http.Request) {
+// handleReady reports readiness. With a live collector that can check its backend, the
+// collector is only ready while that check passes; /healthz stays a pure liveness check.
+func (s *Server) handleReady(w http.ResponseWriter, r *http.Request) {
+	if checker, ok := s.liveCollector.(ReadinessChecker); ok {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		defer cancel()
+		if err := checker.Ready(ctx); err != nil {
+			s.logger.Warn("readiness check failed", "error", err)
+			w.Header().Set("Content-Type", "application/json")
+			w.Header().Set("Cache-Control", "no-store")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(readinessFailure{Status: "unavailable", Error: err.Error()})
+			return
+		}
+	}
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("ok"))
 }
 
+// readinessFailure is the /readyz body returned when the live collector backend is unreachable.
+type readinessFailure struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+// handleRoot serves "/" as the bare snapshots prefix, so it answers with the default node's
+// snapshot when one is configured. Without a default node "/" stays a plain 404.
+func (s *Server) handleRoot(w http.ResponseWriter, r *http.Request) {
+	if s.defaultNode == "" {
+		http.NotFound(w, r)
+		return
+	}
+	r = r.Clone(r.Context())
+	r.URL.Path = s.snapshotsPrefix
+	s.handleSnapshotByNode(w, r)
+}
+
 func (s *Server) handleSnapshotByNode(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodGet {
+	switch r.Method {
+	case http.MethodGet, http.MethodPost:
+	case http.MethodHead:
+		// HEAD shares the GET path so status codes and snapshot headers match; only the body is dropped.
+		w = headResponseWriter{ResponseWriter: w}
+	default:
 		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	nodeName := strings.TrimPrefix(r.URL.Path, snapshotsPrefix)
+	nodeName := strings.TrimPrefix(r.URL.Path, s.snapshotsPrefix)
+	nodeName, summaryOnly := strings.CutSuffix(nodeName, summarySuffix)
+	nodeName, statsOnly := strings.CutSuffix(nodeName, statsSuffix)
+	nodeName, diffRequested := strings.CutSuffix(nodeName, diffSuffix)
 	nodeName = strings.TrimSpace(nodeName)
+	if nodeName == "" {
+		nodeName = s.defaultNode
+	}
 	if nodeName == "" || strings.Contains(nodeName, "/") {
 		http.Error(w, "missing or invalid node name", http.StatusBadRequest)
 		return
 	}
+	if r.Method == http.MethodPost {
+		if summaryOnly || statsOnly || diffRequested {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		s.handleCaptureSnapshot(w, r, nodeName)
+		return
+	}
+	if diffRequested {
+		s.handleSnapshotDiff(w, r, nodeName)
+		return
+	}
+	root, depth, err := parseSubgraphQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	format, err := parseFormatQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	since, err := parseSinceQuery(r, s.clock.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if !since.IsZero() && format == formatNDJSON {
+		http.Error(w, "since is not supported with format=ndjson", http.StatusBadRequest)
+		return
+	}
+
+	payload, ok := s.loadSnapshot(w, r, nodeName)
+	if !ok {
+		return
+	}
+	var changes *snapshot.ChangeSummary
+	if !since.IsZero() {
+		// Compare whole snapshots so a subgraph does not report everything outside it as removed.
+		summary, ok := s.changedSince(w, r, nodeName, payload, since)
+		if !ok {
+			return
+		}
+		changes = &summary
+	}
+	if root != "" {
+		subgraph, found := snapshot.Subgraph(payload, root, depth)
+		if !found {
+			http.Error(w, fmt.Sprintf("root node %q not found in snapshot", root), http.StatusNotFound)
+			return
+		}
+		payload = subgraph
+	}
+	payload.ChangedSince = changes
+	if summaryOnly {
+		s.writeSummary(w, payload, nodeName)
+		return
+	}
+	if statsOnly {
+		s.writeStats(w, payload, nodeName)
+		return
+	}
+	if format == formatNDJSON {
+		s.writeSnapshotNDJSON(w, r, payload, nodeName)
+		return
+	}
+	s.writeSnapshot(w, r, payload, nodeName)
+}
+
+// loadSnapshot resolves the snapshot for nodeName from the live collector, falling back to the
+// store. It writes the error response itself and reports false when no snapshot is available.
+func (s *Server) loadSnapshot(w http.ResponseWriter, r *http.Request, nodeName string) (snapshot.LogicalTopologySnapshot, bool) {
+	if strings.HasPrefix(nodeName, reservedNodePrefix) {
+		if nodeName == healthSnapshotNode {
+			return healthSnapshot(s.clock.Now()), true
+		}
+		http.Error(w, fmt.Sprintf("node name %q is reserved", nodeName), http.StatusNotFound)
+		return snapshot.LogicalTopologySnapshot{}, false
+	}
 
-	logger := s.logger.With("node", nodeName)
+	logger := requestid.Logger(r.Context(), s.logger).With("node", nodeName)
 
 	if s.liveCollector != nil {
 		logger.Info("logical topology snapshot requested")
-		payload, probeErr := s.liveCollector.Collect(r.Context(), nodeName)
+		// Continue any trace the caller started so probe spans join it.
+		ctx := otel.GetTextMapPropagator().Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+		payload, cacheHit, probeErr := s.collectLive(ctx, nodeName, requestsNoCache(r))
 		if probeErr == nil {
-			s.writeSnapshot(w, payload, nodeName)
-			return
+			if s.cache != nil {
+				if cacheHit {
+					w.Header().Set(headerSnapshotCache, "hit")
+				} else {
+					w.Header().Set(headerSnapshotCache, "miss")
+				}
+			}
+			return payload, true
 		}
 
 		logger.Warn("live OVN probe failed; falling back to file snapshot", "error", probeErr)
 		payload, err := s.store.GetByNode(r.Context(), nodeName)
 		if err != nil {
 			s.writeStoreError(w, nodeName, err)
-			return
+			return snapshot.LogicalTopologySnapshot{}, false
 		}
 		payload = appendFallbackWarning(payload, nodeName, probeErr)
 		if payload.Metadata.SourceHealth == "" || payload.Metadata.SourceHealth == "healthy" {
 			payload.Metadata.SourceHealth = "degraded"
 		}
-		s.writeSnapshot(w, payload, nodeName)
-		return
+		return payload, true
 	}
 
 	payload, err := s.store.GetByNode(r.Context(), nodeName)
 	if err != nil {
 		s.writeStoreError(w, nodeName, err)
+		return snapshot.LogicalTopologySnapshot{}, false
+	}
+	return payload, true
+}
+
+func (s *Server) collectLive(ctx context.Context, nodeName string, bypassCache bool) (snapshot.LogicalTopologySnapshot, bool, error) {
+	if s.cache == nil {
+		payload, err := s.liveCollector.Collect(ctx, nodeName)
+		return payload, false, err
+	}
+	return s.cache.collect(ctx, nodeName, bypassCache, s.liveCollector.Collect)
+}
+
+// requestsNoCache reports whether the request carries a Cache-Control no-cache directive, which
+// forces a fresh live probe instead of a cached snapshot.
+func requestsNoCache(r *http.Request) bool {
+	for _, value := range r.Header.Values("Cache-Control") {
+		for _, directive := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(directive), "no-cache") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (s *Server) handleListNodes(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	s.writeSnapshot(w, payload, nodeName)
+	summaries, err := s.store.ListNodes(r.Context())
+	if err != nil {
+		s.logger.Error("failed to list snapshot nodes", "error", err)
+		http.Error(w, fmt.Sprintf("failed to list nodes: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if lister, ok := s.liveCollector.(NodeLister); ok {
+		liveNodes, err := lister.ListNodes(r.Context())
+		if err != nil {
+			s.logger.Warn("live node discovery failed; listing file snapshots only", "error", err)
+		} else {
+			summaries = mergeLiveNodes(summaries, liveNodes)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(summaries); err != nil {
+		s.logger.Error("failed to encode node list", "error", err)
+	}
+}
+
+func mergeLiveNodes(summaries []snapshot.NodeSummary, liveNodes []string) []snapshot.NodeSummary {
+	indexByName := make(map[string]int, len(summaries))
+	for i, summary := range summaries {
+		indexByName[summary.NodeName] = i
+	}
+	for _, nodeName := range liveNodes {
+		if i, ok := indexByName[nodeName]; ok {
+			summaries[i].Live = true
+			continue
+		}
+		indexByName[nodeName] = len(summaries)
+		summaries = append(summaries, snapshot.NodeSummary{NodeName: nodeName, Live: true})
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].NodeName < summaries[j].NodeName
+	})
+	return summaries
+}
+
+// headResponseWriter discards the response body for HEAD requests.
+type headResponseWriter struct {
+	http.ResponseWriter
+}
+
+func (w headResponseWriter) Write(b []byte) (int, error) {
+	return len(b), nil
 }
 
 func appendFallbackWarning(payload snapshot.LogicalTopologySnapshot, nodeName string, probeErr error) snapshot.LogicalTopologySnapshot {
 	message := fmt.Sprintf("Live probe collection failed for node %s: %v", nodeName, probeErr)
-	warning := snapshot.Warning{
-		Code:    "LIVE_PROBE_FAILED",
-		Message: message,
+	if errors.Is(probeErr, context.DeadlineExceeded) {
+		message = fmt.Sprintf("Live probe collection timed out for node %s: %v", nodeName, probeErr)
 	}
+	warning := snapshot.NewWarning(snapshot.WarningLiveProbeFailed, message)
 	for _, existing := range payload.Warnings {
 		if existing.Code == warning.Code && existing.Message == warning.Message {
 			return payload
@@ -131,15 +453,149 @@ func (s *Server) writeStoreError(w http.ResponseWriter, nodeName string, err err
 		http.Error(w, "snapshot not found", http.StatusNotFound)
 		return
 	}
+	var ambiguous *snapshot.AmbiguousNodeError
+	if errors.As(err, &ambiguous) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(http.StatusMultipleChoices)
+		_ = json.NewEncoder(w).Encode(ambiguousNodeResponse{Error: ambiguous.Error(), Candidates: ambiguous.Candidates})
+		return
+	}
 	slog.Error("failed to read snapshot", "node", nodeName, "error", err)
 	http.Error(w, fmt.Sprintf("failed to load snapshot: %v", err), http.StatusInternalServerError)
 }
 
-func (s *Server) writeSnapshot(w http.ResponseWriter, payload snapshot.LogicalTopologySnapshot, nodeName string) {
+// ambiguousNodeResponse lists the snapshots a fuzzy node name matched so the client can retry
+// with one of them.
+type ambiguousNodeResponse struct {
+	Error      string   `json:"error"`
+	Candidates []string `json:"candidates"`
+}
+
+// decorateMetadata fills in the requested node name when the snapshot lacks one and stamps the
+// configured cluster ID.
+func (s *Server) decorateMetadata(payload snapshot.LogicalTopologySnapshot, nodeName string) snapshot.LogicalTopologySnapshot {
 	if payload.Metadata.NodeName == "" {
 		payload.Metadata.NodeName = nodeName
 	}
-	w.Header().Set("Content-Type", "application/json")
+	if s.clusterID != "" {
+		payload.Metadata.ClusterID = s.clusterID
+	}
+	return payload
+}
+
+func (s *Server) writeSnapshot(w http.ResponseWriter, r *http.Request, payload snapshot.LogicalTopologySnapshot, nodeName string) {
+	version, contentType, err := negotiateSchemaVersion(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+	payload, err = snapshot.ToSchemaVersion(s.decorateMetadata(payload, nodeName), version)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotAcceptable)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("failed to encode snapshot payload", "node", nodeName, "error", err)
+		http.Error(w, fmt.Sprintf("failed to encode payload: %v", err), http.StatusInternalServerError)
+		return
+	}
+	body = append(body, '\n')
+
+	if s.maxBytes > 0 && len(body) > s.maxBytes {
+		slog.Warn("snapshot payload exceeds maximum response size", "node", nodeName, "bytes", len(body), "maxBytes", s.maxBytes)
+		http.Error(w, fmt.Sprintf(
+			"snapshot payload is %d bytes, exceeding the %d byte limit; narrow the request with filtering or pagination instead of fetching the full snapshot",
+			len(body), s.maxBytes,
+		), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// Hash the uncompressed body so every encoding of the same snapshot shares one ETag; that is
+	// also why the tag is weak.
+	etag := snapshotETag(body)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.Header().Add("Vary", "Accept")
+		w.Header().Add("Vary", headerSignalDegraded)
+		setSnapshotHeaders(w, payload)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	// Compress large payloads before any header is sent so a compression failure can
+	// still be reported as an error.
+	encoding := ""
+	if len(body) >= compressionThreshold {
+		encoding = negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding != "" {
+			compressed, err := compressBody(body, encoding)
+			if err != nil {
+				slog.Error("failed to compress snapshot payload", "node", nodeName, "encoding", encoding, "error", err)
+				http.Error(w, fmt.Sprintf("failed to compress payload: %v", err), http.StatusInternalServerError)
+				return
+			}
+			body = compressed
+		}
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept-Encoding")
+	w.Header().Add("Vary", "Accept")
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+	}
+	setSnapshotHeaders(w, payload)
+	// Clients may keep the JSON snapshot but must revalidate it with If-None-Match before reuse.
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(snapshotStatus(w, r, payload))
+	if _, err := w.Write(body); err != nil {
+		slog.Error("failed to write snapshot payload", "node", nodeName, "error", err)
+	}
+}
+
+// snapshotETag returns a weak entity tag derived from the encoded snapshot body.
+func snapshotETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `W/"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag using the weak
+// comparison RFC 9110 requires for If-None-Match.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// snapshotStatus returns 203 Non-Authoritative Information for a degraded snapshot when the
+// client opted in with the X-OVN-Recon-Signal-Degraded request header, so caches and clients
+// can tell partial data apart by status alone. Everyone else keeps getting 200.
+func snapshotStatus(w http.ResponseWriter, r *http.Request, payload snapshot.LogicalTopologySnapshot) int {
+	w.Header().Add("Vary", headerSignalDegraded)
+	if payload.Metadata.SourceHealth != "degraded" {
+		return http.StatusOK
+	}
+	if signal, err := strconv.ParseBool(strings.TrimSpace(r.Header.Get(headerSignalDegraded))); err != nil || !signal {
+		return http.StatusOK
+	}
+	return http.StatusNonAuthoritativeInfo
+}
+
+// setSnapshotHeaders sets the caching and X-OVN-Recon-Snapshot-* headers shared by every
+// snapshot response format.
+func setSnapshotHeaders(w http.ResponseWriter, payload snapshot.LogicalTopologySnapshot) {
 	w.Header().Set("Cache-Control", "no-store")
 	if !payload.Metadata.GeneratedAt.IsZero() {
 		w.Header().Set(headerSnapshotGeneratedAt, payload.Metadata.GeneratedAt.UTC().Format("2006-01-02T15:04:05Z07:00"))
@@ -150,9 +606,5 @@ func (s *Server) writeSnapshot(w http.ResponseWriter, payload snapshot.LogicalTo
 	if payload.Metadata.NodeName != "" {
 		w.Header().Set(headerSnapshotNodeName, payload.Metadata.NodeName)
 	}
-	if err := json.NewEncoder(w).Encode(payload); err != nil {
-		slog.Error("failed to encode snapshot payload", "node", nodeName, "error", err)
-		http.Error(w, fmt.Sprintf("failed to encode payload: %v", err), http.StatusInternalServerError)
-		return
-	}
+	w.Header().Set(headerSnapshotWarnings, strconv.Itoa(len(payload.Warnings)))
 }
@@ -1,16 +1,29 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
 	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
 )
 
@@ -34,8 +47,8 @@ func TestSnapshotEndpointReturnsNodeScopedPayload(t *testing.T) {
 	if rr.Code != http.StatusOK {
 		t.Fatalf("expected 200, got %d", rr.Code)
 	}
-	if got := rr.Header().Get("Cache-Control"); got != "no-store" {
-		t.Fatalf("expected Cache-Control=no-store, got %q", got)
+	if got := rr.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Fatalf("expected Cache-Control=no-cache, got %q", got)
 	}
 	if got := rr.Header().Get(headerSnapshotSourceHealth); got != "healthy" {
 		t.Fatalf("expected %s=healthy, got %q", headerSnapshotSourceHealth, got)
@@ -105,6 +118,33 @@ func TestSnapshotEndpointUsesLiveCollectorPayload(t *testing.T) {
 	}
 }
 
+func TestSnapshotEndpointReportsLiveProbeTimeoutAndFallsBack(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "default.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", SourceHealth: "healthy"},
+	})
+	collector := &fakeLiveCollector{
+		err: fmt.Errorf("live probe collection timed out after 15s: %w", context.DeadlineExceeded),
+	}
+	s := NewWithLiveCollector(snapshot.NewFileStore(tmpDir, "default.json"), collector)
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected fallback snapshot with 200, got %d", rr.Code)
+	}
+	var payload snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(payload.Warnings) != 1 || payload.Warnings[0].Code != snapshot.WarningLiveProbeFailed {
+		t.Fatalf("expected a single LIVE_PROBE_FAILED warning, got %#v", payload.Warnings)
+	}
+	if !strings.Contains(payload.Warnings[0].Message, "timed out for node worker-a") {
+		t.Fatalf("expected timeout-specific warning message, got %q", payload.Warnings[0].Message)
+	}
+}
+
 func TestSnapshotEndpointFallsBackWhenLiveCollectorFails(t *testing.T) {
 	tmpDir := t.TempDir()
 	writeFixture(t, filepath.Join(tmpDir, "default.json"), snapshot.LogicalTopologySnapshot{
@@ -155,6 +195,315 @@ func TestSnapshotEndpointFallsBackWhenLiveCollectorFails(t *testing.T) {
 	}
 }
 
+func TestSnapshotSummaryEndpointReturnsCounts(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{
+			SchemaVersion: "v1alpha1",
+			NodeName:      "worker-a",
+			SourceHealth:  "degraded",
+			GeneratedAt:   time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC),
+		},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"},
+		},
+		Edges: []snapshot.Edge{
+			{ID: "router_to_switch:lr-1:ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+		},
+		Warnings: []snapshot.Warning{
+			{Code: "COMMAND_FAILED", Message: "NAT command failed"},
+			{Code: "PARSER_NORMALIZED", Message: "normalized"},
+			{Code: "PARSER_FAILED", Message: "ACL parse failed"},
+		},
+	})
+
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	s.SetClusterID("east-prod")
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a/summary", nil)
+	rr := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var summary map[string]any
+	if err := json.Unmarshal(rr.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("failed to parse summary: %v", err)
+	}
+	want := map[string]any{
+		"nodeName":     "worker-a",
+		"clusterID":    "east-prod",
+		"generatedAt":  "2026-02-14T12:00:00Z",
+		"sourceHealth": "degraded",
+		"nodeCount":    float64(2),
+		"edgeCount":    float64(1),
+		"warningCount": float64(3),
+	}
+	for key, value := range want {
+		if summary[key] != value {
+			t.Fatalf("expected summary %s=%v, got %v (%s)", key, value, summary[key], rr.Body.String())
+		}
+	}
+	if _, ok := summary["nodes"]; ok {
+		t.Fatalf("expected summary to omit the full graph")
+	}
+
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/missing/summary", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for missing node summary, got %d", rr.Code)
+	}
+}
+
+func TestSnapshotStatsEndpointCountsLiveAndFallbackSnapshots(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"},
+			{ID: "ls-2", Kind: "logical_switch", Label: "join"},
+		},
+		Edges: []snapshot.Edge{
+			{ID: "router_to_switch:lr-1:ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "router_to_switch:lr-1:ls-2", Source: "lr-1", Target: "ls-2", Kind: "router_to_switch"},
+		},
+		Warnings: []snapshot.Warning{{Code: "PARSER_FAILED", Message: "ACL parse failed"}},
+	})
+
+	collector := &fakeLiveCollector{payload: snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes:    []snapshot.Node{{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"}},
+	}}
+	s := NewWithLiveCollector(snapshot.NewFileStore(tmpDir, "default.json"), collector)
+
+	get := func() snapshot.Stats {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a/stats", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		var stats snapshot.Stats
+		if err := json.Unmarshal(rr.Body.Bytes(), &stats); err != nil {
+			t.Fatalf("failed to parse stats: %v", err)
+		}
+		if strings.Contains(rr.Body.String(), `"nodes"`) {
+			t.Fatalf("expected stats to omit the full graph: %s", rr.Body.String())
+		}
+		return stats
+	}
+
+	live := get()
+	if live.NodeCounts["logical_switch"] != 1 || len(live.NodeCounts) != 1 || len(live.EdgeCounts) != 0 {
+		t.Fatalf("expected live snapshot stats, got %#v", live)
+	}
+
+	collector.err = errors.New("ovn-nbctl unavailable")
+	fallback := get()
+	if fallback.NodeCounts["logical_router"] != 1 || fallback.NodeCounts["logical_switch"] != 2 {
+		t.Fatalf("unexpected fallback node counts: %v", fallback.NodeCounts)
+	}
+	if fallback.EdgeCounts["router_to_switch"] != 2 {
+		t.Fatalf("unexpected fallback edge counts: %v", fallback.EdgeCounts)
+	}
+	// The file store's warning plus the live probe failure added by the fallback.
+	if fallback.WarningCounts[snapshot.SeverityError] != 1 || fallback.WarningCounts[snapshot.SeverityWarning] != 1 {
+		t.Fatalf("unexpected fallback warning counts: %v", fallback.WarningCounts)
+	}
+}
+
+func TestSnapshotDiffEndpointComparesTwoNodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-a", Kind: "logical_switch", Label: "worker-a"},
+		},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-b.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", SourceHealth: "healthy"},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-b", Kind: "logical_switch", Label: "worker-b"},
+		},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a/diff?against=worker-b", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d (%s)", rr.Code, rr.Body.String())
+	}
+	var diff snapshot.SnapshotDiff
+	if err := json.Unmarshal(rr.Body.Bytes(), &diff); err != nil {
+		t.Fatalf("failed to parse diff: %v", err)
+	}
+	if diff.Base != "worker-a" || diff.Against != "worker-b" {
+		t.Fatalf("expected worker-a against worker-b, got %q/%q", diff.Base, diff.Against)
+	}
+	if len(diff.NodesAdded) != 1 || diff.NodesAdded[0].ID != "ls-b" {
+		t.Fatalf("expected ls-b added, got %#v", diff.NodesAdded)
+	}
+	if len(diff.NodesRemoved) != 1 || diff.NodesRemoved[0].ID != "ls-a" {
+		t.Fatalf("expected ls-a removed, got %#v", diff.NodesRemoved)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "missing against", path: "/api/v1/snapshots/worker-a/diff", wantStatus: http.StatusBadRequest},
+		{name: "missing base", path: "/api/v1/snapshots/worker-missing/diff?against=worker-b", wantStatus: http.StatusNotFound},
+		{name: "missing other", path: "/api/v1/snapshots/worker-a/diff?against=worker-missing", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d", tc.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
+func TestSnapshotEndpointSummarizesChangesSinceStoredVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	store := snapshot.NewFileStore(tmpDir, "default.json")
+	store.SetHistoryDir(filepath.Join(tmpDir, "history"))
+	earlier := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+	for _, payload := range []snapshot.LogicalTopologySnapshot{
+		{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", GeneratedAt: earlier},
+			Nodes: []snapshot.Node{
+				{ID: "lr-1", Kind: "logical_router"},
+				{ID: "ls-a", Kind: "logical_switch"},
+			},
+		},
+		{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", GeneratedAt: later},
+			Nodes: []snapshot.Node{
+				{ID: "lr-1", Kind: "logical_router"},
+				{ID: "ls-b", Kind: "logical_switch"},
+			},
+			Edges: []snapshot.Edge{{ID: "router_to_switch:lr-1:ls-b", Source: "lr-1", Target: "ls-b", Kind: "router_to_switch"}},
+		},
+	} {
+		if err := store.Put(context.Background(), "worker-a", payload); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	s := New(store)
+	s.SetClock(clock.NewFake(later.Add(time.Hour)))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a?since=2026-02-16T08:30:00Z", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d (%s)", rr.Code, rr.Body.String())
+	}
+	var payload snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse snapshot: %v", err)
+	}
+	changes := payload.ChangedSince
+	if changes == nil {
+		t.Fatalf("expected a changedSince summary, got none")
+	}
+	if !changes.BaselineGeneratedAt.Equal(earlier) {
+		t.Fatalf("expected the earlier version as baseline, got %s", changes.BaselineGeneratedAt)
+	}
+	if len(changes.NodesAdded) != 1 || changes.NodesAdded[0] != "ls-b" || len(changes.NodesRemoved) != 1 || changes.NodesRemoved[0] != "ls-a" {
+		t.Fatalf("expected ls-b added and ls-a removed, got %#v", changes)
+	}
+	if len(changes.EdgesAdded) != 1 || len(changes.EdgesRemoved) != 0 {
+		t.Fatalf("expected one edge added, got %#v", changes)
+	}
+
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+	if strings.Contains(rr.Body.String(), "changedSince") {
+		t.Fatalf("expected no changedSince without the since parameter, got %s", rr.Body.String())
+	}
+
+	tests := []struct {
+		name       string
+		server     *Server
+		path       string
+		wantStatus int
+	}{
+		{name: "malformed", server: s, path: "/api/v1/snapshots/worker-a?since=yesterday", wantStatus: http.StatusBadRequest},
+		{name: "future", server: s, path: "/api/v1/snapshots/worker-a?since=2027-01-01T00:00:00Z", wantStatus: http.StatusBadRequest},
+		{name: "ndjson", server: s, path: "/api/v1/snapshots/worker-a?since=2026-02-16T08:30:00Z&format=ndjson", wantStatus: http.StatusBadRequest},
+		{name: "before history", server: s, path: "/api/v1/snapshots/worker-a?since=2026-02-16T07:00:00Z", wantStatus: http.StatusNotFound},
+		{name: "history disabled", server: New(snapshot.NewFileStore(tmpDir, "default.json")), path: "/api/v1/snapshots/worker-a?since=2026-02-16T08:30:00Z", wantStatus: http.StatusConflict},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			tc.server.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d (%s)", tc.wantStatus, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestSnapshotEndpointServesSubgraphAroundRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router"},
+			{ID: "ls-1", Kind: "logical_switch"},
+			{ID: "lsp-1", Kind: "logical_switch_port"},
+		},
+		Edges: []snapshot.Edge{
+			{ID: "router_to_switch:lr-1:ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "switch_to_port:ls-1:lsp-1", Source: "ls-1", Target: "lsp-1", Kind: "switch_to_port"},
+		},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a?root=lr-1&depth=1", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d (%s)", rr.Code, rr.Body.String())
+	}
+	var payload snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(payload.Nodes) != 2 || len(payload.Edges) != 1 {
+		t.Fatalf("expected router and switch only, got nodes=%#v edges=%#v", payload.Nodes, payload.Edges)
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "negative depth", path: "/api/v1/snapshots/worker-a?root=lr-1&depth=-1", wantStatus: http.StatusBadRequest},
+		{name: "depth too large", path: "/api/v1/snapshots/worker-a?root=lr-1&depth=99", wantStatus: http.StatusBadRequest},
+		{name: "depth without root", path: "/api/v1/snapshots/worker-a?depth=1", wantStatus: http.StatusBadRequest},
+		{name: "unknown root", path: "/api/v1/snapshots/worker-a?root=missing", wantStatus: http.StatusNotFound},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+			if rr.Code != tc.wantStatus {
+				t.Fatalf("expected %d, got %d", tc.wantStatus, rr.Code)
+			}
+		})
+	}
+}
+
 func TestSnapshotEndpointFallsBackToDefault(t *testing.T) {
 	tmpDir := t.TempDir()
 	writeFixture(t, filepath.Join(tmpDir, "default.json"), snapshot.LogicalTopologySnapshot{
@@ -179,6 +528,217 @@ func TestSnapshotEndpointFallsBackToDefault(t *testing.T) {
 	}
 }
 
+func TestSnapshotEndpointAnswersConditionalRequestsWithNotModified(t *testing.T) {
+	tmpDir := t.TempDir()
+	fixture := snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{
+			SchemaVersion: "v1alpha1",
+			NodeName:      "worker-file",
+			SourceHealth:  "healthy",
+			GeneratedAt:   time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC),
+		},
+	}
+	writeFixture(t, filepath.Join(tmpDir, "worker-file.json"), fixture)
+
+	fileServer := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	liveServer := NewWithLiveCollector(snapshot.NewFileStore(tmpDir, "default.json"), &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-live", SourceHealth: "healthy"},
+		},
+	})
+
+	for name, tc := range map[string]struct {
+		server *Server
+		path   string
+	}{
+		"file": {server: fileServer, path: "/api/v1/snapshots/worker-file"},
+		"live": {server: liveServer, path: "/api/v1/snapshots/worker-live"},
+	} {
+		rr := httptest.NewRecorder()
+		tc.server.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, tc.path, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", name, rr.Code)
+		}
+		etag := rr.Header().Get("ETag")
+		if !strings.HasPrefix(etag, `W/"`) {
+			t.Fatalf("%s: expected a weak ETag, got %q", name, etag)
+		}
+
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		req.Header.Set("If-None-Match", `"stale", `+etag)
+		rr = httptest.NewRecorder()
+		tc.server.Handler().ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotModified {
+			t.Fatalf("%s: expected 304, got %d", name, rr.Code)
+		}
+		if rr.Body.Len() != 0 {
+			t.Fatalf("%s: expected empty 304 body, got %q", name, rr.Body.String())
+		}
+		if got := rr.Header().Get("ETag"); got != etag {
+			t.Fatalf("%s: expected ETag %q on 304, got %q", name, etag, got)
+		}
+		if got := rr.Header().Get("Cache-Control"); got != "no-cache" {
+			t.Fatalf("%s: expected Cache-Control=no-cache, got %q", name, got)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	fileServer.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-file", nil))
+	previous := rr.Header().Get("ETag")
+	fixture.Metadata.GeneratedAt = fixture.Metadata.GeneratedAt.Add(time.Minute)
+	writeFixture(t, filepath.Join(tmpDir, "worker-file.json"), fixture)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-file", nil)
+	req.Header.Set("If-None-Match", previous)
+	rr = httptest.NewRecorder()
+	fileServer.Handler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 for a changed snapshot, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("ETag"); got == previous {
+		t.Fatalf("expected a new ETag for a changed snapshot, got %q", got)
+	}
+}
+
+func TestSnapshotEndpointNegotiatesSchemaVersion(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	get := func(path, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		return rr
+	}
+
+	for name, tc := range map[string]struct {
+		path, accept    string
+		wantContentType string
+	}{
+		"default":       {path: "/api/v1/snapshots/worker-a", wantContentType: "application/json"},
+		"vendor type":   {path: "/api/v1/snapshots/worker-a", accept: "application/vnd.ovn-recon.snapshot.v1alpha1+json", wantContentType: "application/vnd.ovn-recon.snapshot.v1alpha1+json"},
+		"fallback json": {path: "/api/v1/snapshots/worker-a", accept: "application/vnd.ovn-recon.snapshot.v2+json, application/json;q=0.5", wantContentType: "application/json"},
+		"query":         {path: "/api/v1/snapshots/worker-a?schema=v1alpha1", wantContentType: "application/json"},
+	} {
+		rr := get(tc.path, tc.accept)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", name, rr.Code, rr.Body.String())
+		}
+		if got := rr.Header().Get("Content-Type"); got != tc.wantContentType {
+			t.Fatalf("%s: expected Content-Type %q, got %q", name, tc.wantContentType, got)
+		}
+		if !slices.Contains(rr.Header().Values("Vary"), "Accept") {
+			t.Fatalf("%s: expected Vary to include Accept, got %v", name, rr.Header().Values("Vary"))
+		}
+		var payload snapshot.LogicalTopologySnapshot
+		if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+			t.Fatalf("%s: decode response: %v", name, err)
+		}
+		if payload.Metadata.SchemaVersion != snapshot.SchemaVersion {
+			t.Fatalf("%s: expected schemaVersion %s, got %q", name, snapshot.SchemaVersion, payload.Metadata.SchemaVersion)
+		}
+	}
+
+	for name, tc := range map[string]struct{ path, accept string }{
+		"vendor type": {path: "/api/v1/snapshots/worker-a", accept: "application/vnd.ovn-recon.snapshot.v1+json"},
+		"query":       {path: "/api/v1/snapshots/worker-a?schema=v1", accept: "application/json"},
+	} {
+		rr := get(tc.path, tc.accept)
+		if rr.Code != http.StatusNotAcceptable {
+			t.Fatalf("%s: expected 406 for an unsupported schema version, got %d", name, rr.Code)
+		}
+		if !strings.Contains(rr.Body.String(), `"v1"`) || !strings.Contains(rr.Body.String(), snapshot.SchemaVersion) {
+			t.Fatalf("%s: expected the error to name the requested and supported versions, got %q", name, rr.Body.String())
+		}
+	}
+}
+
+func TestSnapshotEndpointSignalsDegradedSnapshotsOnRequest(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-degraded.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-degraded", SourceHealth: "degraded"},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-healthy.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-healthy", SourceHealth: "healthy"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	tests := []struct {
+		name   string
+		path   string
+		signal string
+		want   int
+	}{
+		{name: "degraded without opt-in", path: "/api/v1/snapshots/worker-degraded", want: http.StatusOK},
+		{name: "degraded with opt-in", path: "/api/v1/snapshots/worker-degraded", signal: "true", want: http.StatusNonAuthoritativeInfo},
+		{name: "degraded ndjson with opt-in", path: "/api/v1/snapshots/worker-degraded?format=ndjson", signal: "true", want: http.StatusNonAuthoritativeInfo},
+		{name: "degraded with opt-out", path: "/api/v1/snapshots/worker-degraded", signal: "false", want: http.StatusOK},
+		{name: "healthy with opt-in", path: "/api/v1/snapshots/worker-healthy", signal: "true", want: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.signal != "" {
+				req.Header.Set(headerSignalDegraded, tt.signal)
+			}
+			rr := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rr, req)
+
+			if rr.Code != tt.want {
+				t.Fatalf("expected %d, got %d: %s", tt.want, rr.Code, rr.Body.String())
+			}
+			if !strings.Contains(strings.Join(rr.Header().Values("Vary"), ","), headerSignalDegraded) {
+				t.Fatalf("expected Vary to include %s, got %v", headerSignalDegraded, rr.Header().Values("Vary"))
+			}
+		})
+	}
+}
+
+func TestSnapshotEndpointStampsClusterIDOnLiveAndFileSnapshots(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-file.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-file", SourceHealth: "healthy", ClusterID: "stale"},
+	})
+
+	fileServer := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	fileServer.SetClusterID("east-prod")
+	liveServer := NewWithLiveCollector(snapshot.NewFileStore(tmpDir, "default.json"), &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-live", SourceHealth: "healthy"},
+		},
+	})
+	liveServer.SetClusterID("east-prod")
+
+	for name, tc := range map[string]struct {
+		server *Server
+		path   string
+	}{
+		"file": {server: fileServer, path: "/api/v1/snapshots/worker-file"},
+		"live": {server: liveServer, path: "/api/v1/snapshots/worker-live"},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		rr := httptest.NewRecorder()
+		tc.server.Handler().ServeHTTP(rr, req)
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", name, rr.Code)
+		}
+		var payload snapshot.LogicalTopologySnapshot
+		if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+			t.Fatalf("%s: failed to parse response: %v", name, err)
+		}
+		if payload.Metadata.ClusterID != "east-prod" {
+			t.Fatalf("%s: expected clusterID east-prod, got %q", name, payload.Metadata.ClusterID)
+		}
+	}
+}
+
 func TestSnapshotEndpointRejectsMissingNode(t *testing.T) {
 	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/", nil)
@@ -191,6 +751,65 @@ func TestSnapshotEndpointRejectsMissingNode(t *testing.T) {
 	}
 }
 
+func TestSnapshotEndpointServesDefaultNodeForBarePaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "demo-node.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "demo-node", SourceHealth: "healthy"},
+	})
+
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	for path, want := range map[string]int{"/": http.StatusNotFound, "/api/v1/snapshots/": http.StatusBadRequest} {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != want {
+			t.Fatalf("expected %d for %s without a default node, got %d", want, path, rr.Code)
+		}
+	}
+
+	s.SetDefaultNode("demo-node")
+	for _, path := range []string{"/", "/api/v1/snapshots/"} {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s with a default node, got %d", path, rr.Code)
+		}
+		if got := rr.Header().Get(headerSnapshotNodeName); got != "demo-node" {
+			t.Fatalf("expected %s to serve demo-node, got %q", path, got)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected unrelated paths to stay 404, got %d", rr.Code)
+	}
+}
+
+func TestSnapshotEndpointReportsAmbiguousFuzzyNode(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"worker-a.east.example.com", "worker-a.west.example.com"} {
+		writeFixture(t, filepath.Join(tmpDir, name+".json"), snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: name},
+		})
+	}
+	store := snapshot.NewFileStore(tmpDir, "default.json")
+	store.SetNodeMatch(snapshot.NodeMatchFuzzy)
+
+	rr := httptest.NewRecorder()
+	New(store).Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+
+	if rr.Code != http.StatusMultipleChoices {
+		t.Fatalf("expected 300, got %d", rr.Code)
+	}
+	var body ambiguousNodeResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(body.Candidates) != 2 {
+		t.Fatalf("expected two candidates, got %#v", body)
+	}
+}
+
 func TestSnapshotEndpointReturnsNotFound(t *testing.T) {
 	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
 	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-missing", nil)
@@ -203,27 +822,1082 @@ func TestSnapshotEndpointReturnsNotFound(t *testing.T) {
 	}
 }
 
-func writeFixture(t *testing.T, path string, payload snapshot.LogicalTopologySnapshot) {
-	t.Helper()
-	bytes, err := json.Marshal(payload)
-	if err != nil {
-		t.Fatalf("marshal fixture: %v", err)
-	}
-	if err := os.WriteFile(path, bytes, 0o600); err != nil {
-		t.Fatalf("write fixture: %v", err)
+func TestSnapshotEndpointServesCachedLiveSnapshotWithinTTL(t *testing.T) {
+	collector := &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		},
 	}
-}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+	fakeClock := clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC))
+	s.SetClock(fakeClock)
+	s.SetSnapshotCacheTTL(time.Minute)
 
-type fakeLiveCollector struct {
-	payload snapshot.LogicalTopologySnapshot
-	err     error
-	calls   int
-}
+	get := func() *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		return rr
+	}
 
-func (f *fakeLiveCollector) Collect(_ context.Context, _ string) (snapshot.LogicalTopologySnapshot, error) {
+	if got := get().Header().Get(headerSnapshotCache); got != "miss" {
+		t.Fatalf("expected first request cache miss, got %q", got)
+	}
+	if got := get().Header().Get(headerSnapshotCache); got != "hit" {
+		t.Fatalf("expected second request cache hit, got %q", got)
+	}
+	if collector.calls != 1 {
+		t.Fatalf("expected one live collection within TTL, got %d", collector.calls)
+	}
+
+	fakeClock.Step(time.Minute)
+	if got := get().Header().Get(headerSnapshotCache); got != "miss" {
+		t.Fatalf("expected cache miss after TTL expiry, got %q", got)
+	}
+	if collector.calls != 2 {
+		t.Fatalf("expected a second live collection after TTL expiry, got %d", collector.calls)
+	}
+}
+
+func TestSnapshotEndpointNoCacheHeaderForcesLiveProbe(t *testing.T) {
+	collector := &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		},
+	}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+	s.SetClock(clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)))
+	s.SetSnapshotCacheTTL(time.Minute)
+
+	get := func(cacheControl string) string {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil)
+		if cacheControl != "" {
+			req.Header.Set("Cache-Control", cacheControl)
+		}
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", rr.Code)
+		}
+		return rr.Header().Get(headerSnapshotCache)
+	}
+
+	if got := get(""); got != "miss" {
+		t.Fatalf("expected first request cache miss, got %q", got)
+	}
+	if got := get(""); got != "hit" || collector.calls != 1 {
+		t.Fatalf("expected normal request to use the cache, got %q after %d calls", got, collector.calls)
+	}
+	if got := get("max-age=0, No-Cache"); got != "miss" || collector.calls != 2 {
+		t.Fatalf("expected no-cache to re-probe, got %q after %d calls", got, collector.calls)
+	}
+	if got := get(""); got != "hit" || collector.calls != 2 {
+		t.Fatalf("expected re-probed snapshot to refresh the cache, got %q after %d calls", got, collector.calls)
+	}
+}
+
+func TestCaptureSnapshotForcesLiveProbeAndStoresResult(t *testing.T) {
+	collector := &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{
+			Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", SourceHealth: "healthy"},
+			Nodes:    []snapshot.Node{{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"}},
+		},
+	}
+	store := snapshot.NewFileStore(t.TempDir(), "default.json")
+	s := NewWithLiveCollector(store, collector)
+	s.SetClock(clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)))
+	s.SetSnapshotCacheTTL(time.Minute)
+	s.SetClusterID("prod-east")
+
+	get := httptest.NewRecorder()
+	s.Handler().ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+	if get.Code != http.StatusOK || collector.calls != 1 {
+		t.Fatalf("expected GET to probe once, got %d after %d calls", get.Code, collector.calls)
+	}
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/snapshots/worker-a", nil))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if collector.calls != 2 {
+		t.Fatalf("expected capture to bypass the cache, got %d calls", collector.calls)
+	}
+	if got := rr.Header().Get("Location"); got != "/api/v1/snapshots/worker-a" {
+		t.Fatalf("unexpected Location %q", got)
+	}
+	var returned snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &returned); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if returned.Metadata.NodeName != "worker-a" || returned.Metadata.ClusterID != "prod-east" || len(returned.Nodes) != 1 {
+		t.Fatalf("unexpected captured payload: %#v", returned)
+	}
+
+	stored, err := store.GetByNode(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("expected captured snapshot in the store: %v", err)
+	}
+	if stored.Metadata.NodeName != "worker-a" || len(stored.Nodes) != 1 || stored.Nodes[0].ID != "lr-1" {
+		t.Fatalf("unexpected stored snapshot: %#v", stored)
+	}
+}
+
+func TestCaptureSnapshotRequiresLiveCollector(t *testing.T) {
+	tmpDir := t.TempDir()
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/v1/snapshots/worker-a", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 without a live collector, got %d", rr.Code)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, "worker-a.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing stored, got err=%v", err)
+	}
+}
+
+func TestSnapshotCacheCoalescesConcurrentCollections(t *testing.T) {
+	cache := newSnapshotCache(time.Minute, clock.Real{})
+	release := make(chan struct{})
+	var calls atomic.Int32
+	collectFn := func(_ context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error) {
+		calls.Add(1)
+		<-release
+		return snapshot.LogicalTopologySnapshot{Metadata: snapshot.Metadata{NodeName: nodeName}}, nil
+	}
+
+	const callers = 8
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer done.Done()
+			started.Done()
+			payload, _, err := cache.collect(context.Background(), "worker-a", false, collectFn)
+			if err != nil || payload.Metadata.NodeName != "worker-a" {
+				t.Errorf("unexpected result: %+v, %v", payload.Metadata, err)
+			}
+		}()
+	}
+	started.Wait()
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected concurrent requests to coalesce into one collection, got %d", got)
+	}
+}
+
+func TestSnapshotCacheSharedCollectionSurvivesFirstCallerCancel(t *testing.T) {
+	cache := newSnapshotCache(time.Minute, clock.Real{})
+	started := make(chan struct{})
+	release := make(chan struct{})
+	collectFn := func(ctx context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error) {
+		close(started)
+		select {
+		case <-ctx.Done():
+			return snapshot.LogicalTopologySnapshot{}, ctx.Err()
+		case <-release:
+		}
+		return snapshot.LogicalTopologySnapshot{Metadata: snapshot.Metadata{NodeName: nodeName}}, nil
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, _, err := cache.collect(firstCtx, "worker-a", false, collectFn)
+		firstErr <- err
+	}()
+	<-started
+
+	secondResult := make(chan error, 1)
+	go func() {
+		payload, _, err := cache.collect(context.Background(), "worker-a", false, collectFn)
+		if err == nil && payload.Metadata.NodeName != "worker-a" {
+			err = fmt.Errorf("unexpected node %q", payload.Metadata.NodeName)
+		}
+		secondResult <- err
+	}()
+
+	cancelFirst()
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the first caller to stop with its own cancellation, got %v", err)
+	}
+	close(release)
+	if err := <-secondResult; err != nil {
+		t.Fatalf("expected the coalesced caller to get the shared snapshot, got %v", err)
+	}
+	if _, hit, err := cache.collect(context.Background(), "worker-a", false, collectFn); err != nil || !hit {
+		t.Fatalf("expected the shared snapshot to be cached, hit=%v err=%v", hit, err)
+	}
+}
+
+func TestSnapshotCacheExpiresExactlyAtTTL(t *testing.T) {
+	collector := &fakeLiveCollector{
+		payload: snapshot.LogicalTopologySnapshot{Metadata: snapshot.Metadata{NodeName: "worker-a"}},
+	}
+	fakeClock := clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC))
+	cache := newSnapshotCache(30*time.Second, fakeClock)
+
+	collect := func() bool {
+		_, hit, err := cache.collect(context.Background(), "worker-a", false, collector.Collect)
+		if err != nil {
+			t.Fatalf("collect failed: %v", err)
+		}
+		return hit
+	}
+
+	if collect() {
+		t.Fatalf("expected first collection to miss")
+	}
+	fakeClock.Step(30*time.Second - time.Nanosecond)
+	if !collect() {
+		t.Fatalf("expected cache hit one nanosecond before TTL expiry")
+	}
+	fakeClock.Step(time.Nanosecond)
+	if collect() {
+		t.Fatalf("expected cache miss at TTL expiry")
+	}
+	if collector.calls != 2 {
+		t.Fatalf("expected two live collections, got %d", collector.calls)
+	}
+}
+
+func TestServerSetClockAppliesToExistingCache(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+	s.SetSnapshotCacheTTL(time.Minute)
+	fakeClock := clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC))
+	s.SetClock(fakeClock)
+	if s.cache.clock != fakeClock {
+		t.Fatalf("expected cache configured before SetClock to use the new clock")
+	}
+}
+
+func TestSnapshotCacheDoesNotCacheFailures(t *testing.T) {
+	collector := &fakeLiveCollector{err: errors.New("exec failed")}
+	cache := newSnapshotCache(time.Minute, clock.Real{})
+
+	for i := 0; i < 2; i++ {
+		if _, _, err := cache.collect(context.Background(), "worker-a", false, collector.Collect); err == nil {
+			t.Fatalf("expected collection error")
+		}
+	}
+	if collector.calls != 2 {
+		t.Fatalf("expected failed collections to be retried, got %d calls", collector.calls)
+	}
+}
+
+func TestSnapshotEndpointHeadMatchesGetStatusWithoutBody(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{
+			SchemaVersion: "v1alpha1",
+			NodeName:      "worker-a",
+			SourceHealth:  "healthy",
+			GeneratedAt:   time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC),
+		},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+	}{
+		{name: "found", path: "/api/v1/snapshots/worker-a", wantStatus: http.StatusOK},
+		{name: "not found", path: "/api/v1/snapshots/worker-missing", wantStatus: http.StatusNotFound},
+		{name: "invalid node", path: "/api/v1/snapshots/worker-a/extra", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			getRR := httptest.NewRecorder()
+			s.Handler().ServeHTTP(getRR, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			headRR := httptest.NewRecorder()
+			s.Handler().ServeHTTP(headRR, httptest.NewRequest(http.MethodHead, tt.path, nil))
+
+			if getRR.Code != tt.wantStatus || headRR.Code != tt.wantStatus {
+				t.Fatalf("expected %d for GET and HEAD, got GET=%d HEAD=%d", tt.wantStatus, getRR.Code, headRR.Code)
+			}
+			if headRR.Body.Len() != 0 {
+				t.Fatalf("expected empty HEAD body, got %q", headRR.Body.String())
+			}
+			for _, header := range []string{headerSnapshotGeneratedAt, headerSnapshotSourceHealth, headerSnapshotNodeName, headerSnapshotWarnings, "Content-Type"} {
+				if got, want := headRR.Header().Get(header), getRR.Header().Get(header); got != want {
+					t.Fatalf("expected HEAD %s=%q to match GET, got %q", header, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSnapshotEndpointReportsWarningCountHeader(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "degraded"},
+		Warnings: []snapshot.Warning{
+			snapshot.NewWarning(snapshot.WarningCommandFailed, "NAT command failed"),
+			snapshot.NewWarning(snapshot.WarningParserFailed, "ACL parse failed"),
+		},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-b.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", SourceHealth: "healthy"},
+	})
+
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	for node, want := range map[string]string{"worker-a": "2", "worker-b": "0"} {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/"+node, nil))
+
+		if rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d", node, rr.Code)
+		}
+		if got := rr.Header().Get(headerSnapshotWarnings); got != want {
+			t.Fatalf("expected %s=%s for %s, got %q", headerSnapshotWarnings, want, node, got)
+		}
+	}
+}
+
+func TestSnapshotEndpointRejectsUnsupportedMethods(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+	for _, target := range []string{"/api/v1/snapshots/worker-a", "/api/v1/snapshots/worker-a/summary", "/api/v1/snapshots/worker-a/stats"} {
+		method := http.MethodDelete
+		if strings.HasSuffix(target, summarySuffix) || strings.HasSuffix(target, statsSuffix) {
+			method = http.MethodPost
+		}
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(method, target, nil))
+
+		if rr.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("expected 405 for %s %s, got %d", method, target, rr.Code)
+		}
+	}
+}
+
+func TestSnapshotEndpointCompressesLargePayloads(t *testing.T) {
+	tmpDir := t.TempDir()
+	nodes := make([]snapshot.Node, 0, 50)
+	for i := 0; i < 50; i++ {
+		id := fmt.Sprintf("lsp-%02d", i)
+		nodes = append(nodes, snapshot.Node{ID: id, Kind: "logical_switch_port", Label: id})
+	}
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes:    nodes,
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	tests := []struct {
+		acceptEncoding string
+		wantEncoding   string
+		decode         func(io.Reader) (io.Reader, error)
+	}{
+		{acceptEncoding: "gzip, deflate", wantEncoding: "gzip", decode: func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{acceptEncoding: "deflate", wantEncoding: "deflate", decode: func(r io.Reader) (io.Reader, error) { return zlib.NewReader(r) }},
+		{acceptEncoding: "gzip;q=0, identity", wantEncoding: ""},
+		{acceptEncoding: "", wantEncoding: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			rr := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d", rr.Code)
+			}
+			if got := rr.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("expected Content-Encoding %q, got %q", tt.wantEncoding, got)
+			}
+			if got := rr.Header().Get(headerSnapshotNodeName); got != "worker-a" {
+				t.Fatalf("expected %s=worker-a on compressed response, got %q", headerSnapshotNodeName, got)
+			}
+
+			var body io.Reader = rr.Body
+			if tt.decode != nil {
+				decoded, err := tt.decode(rr.Body)
+				if err != nil {
+					t.Fatalf("failed to open %s body: %v", tt.wantEncoding, err)
+				}
+				body = decoded
+			}
+			var payload snapshot.LogicalTopologySnapshot
+			if err := json.NewDecoder(body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(payload.Nodes) != 50 {
+				t.Fatalf("expected 50 nodes, got %d", len(payload.Nodes))
+			}
+		})
+	}
+}
+
+func TestSnapshotEndpointRejectsPayloadsOverMaxSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	nodes := make([]snapshot.Node, 0, 500)
+	for i := 0; i < 500; i++ {
+		id := fmt.Sprintf("lsp-%03d", i)
+		nodes = append(nodes, snapshot.Node{ID: id, Kind: "logical_switch_port", Label: id})
+	}
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+		Nodes:    nodes,
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	s.SetMaxSnapshotBytes(4096)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "filtering or pagination") {
+		t.Fatalf("expected filtering hint in body, got %q", rr.Body.String())
+	}
+	if got := rr.Header().Get(headerSnapshotNodeName); got != "" {
+		t.Fatalf("expected no snapshot headers on rejected response, got %q", got)
+	}
+
+	s.SetMaxSnapshotBytes(0)
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200 with limit disabled, got %d", rr.Code)
+	}
+}
+
+func TestSnapshotEndpointSkipsCompressionForSmallPayloads(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rr, req)
+
+	if got := rr.Header().Get("Content-Encoding"); got != "" {
+		t.Fatalf("expected small payload to be uncompressed, got Content-Encoding %q", got)
+	}
+	if got := rr.Header().Get("Vary"); got != "Accept-Encoding" {
+		t.Fatalf("expected Vary: Accept-Encoding, got %q", got)
+	}
+}
+
+func TestAPIEndpointsRequireBearerTokenWhenConfigured(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	s.SetAuthToken("s3cret")
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		wantStatus    int
+	}{
+		{name: "snapshot without token", path: "/api/v1/snapshots/worker-a", wantStatus: http.StatusUnauthorized},
+		{name: "snapshot with wrong token", path: "/api/v1/snapshots/worker-a", authorization: "Bearer nope", wantStatus: http.StatusUnauthorized},
+		{name: "snapshot with token", path: "/api/v1/snapshots/worker-a", authorization: "Bearer s3cret", wantStatus: http.StatusOK},
+		{name: "nodes without token", path: "/api/v1/nodes", wantStatus: http.StatusUnauthorized},
+		{name: "nodes with token", path: "/api/v1/nodes", authorization: "Bearer s3cret", wantStatus: http.StatusOK},
+		{name: "healthz without token", path: "/healthz", wantStatus: http.StatusOK},
+		{name: "readyz without token", path: "/readyz", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rr := httptest.NewRecorder()
+			s.Handler().ServeHTTP(rr, req)
+
+			if rr.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, rr.Code)
+			}
+			if tt.wantStatus == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+				t.Fatalf("expected WWW-Authenticate challenge on 401")
+			}
+		})
+	}
+}
+
+func TestRateLimitRejectsClientsOverTheLimit(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	fakeClock := clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC))
+	s.SetClock(fakeClock)
+	s.SetRateLimit(0.5, 2, "")
+
+	get := func(path, remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = remoteAddr
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		return rr
+	}
+
+	for i := 0; i < 2; i++ {
+		if rr := get("/api/v1/snapshots/worker-a", "10.0.0.1:40000"); rr.Code != http.StatusOK {
+			t.Fatalf("request %d within burst: expected 200, got %d", i+1, rr.Code)
+		}
+	}
+	rr := get("/api/v1/nodes", "10.0.0.1:40001")
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the burst is spent, got %d", rr.Code)
+	}
+	if got := rr.Header().Get("Retry-After"); got != "2" {
+		t.Fatalf("expected Retry-After=2, got %q", got)
+	}
+
+	if rr := get("/api/v1/snapshots/worker-a", "10.0.0.2:40000"); rr.Code != http.StatusOK {
+		t.Fatalf("expected another client to keep its own bucket, got %d", rr.Code)
+	}
+	for _, path := range []string{"/healthz", "/readyz"} {
+		if rr := get(path, "10.0.0.1:40000"); rr.Code != http.StatusOK {
+			t.Fatalf("expected %s to bypass the rate limit, got %d", path, rr.Code)
+		}
+	}
+
+	fakeClock.Step(2 * time.Second)
+	if rr := get("/api/v1/snapshots/worker-a", "10.0.0.1:40000"); rr.Code != http.StatusOK {
+		t.Fatalf("expected the bucket to refill, got %d", rr.Code)
+	}
+}
+
+func TestRateLimitKeysClientsByHeader(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+	s.SetClock(clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)))
+	s.SetRateLimit(1, 1, "X-Forwarded-For")
+
+	get := func(forwardedFor string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes", nil)
+		req.RemoteAddr = "10.0.0.1:40000"
+		if forwardedFor != "" {
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+		}
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := get("192.0.2.10, 10.0.0.1"); code != http.StatusOK {
+		t.Fatalf("expected first request for 192.0.2.10 to pass, got %d", code)
+	}
+	if code := get("192.0.2.10"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected second request for 192.0.2.10 to be limited, got %d", code)
+	}
+	if code := get("192.0.2.11, 10.0.0.1"); code != http.StatusOK {
+		t.Fatalf("expected a different forwarded client behind the same proxy to pass, got %d", code)
+	}
+	if code := get(""); code != http.StatusOK {
+		t.Fatalf("expected a request without the header to fall back to its remote IP, got %d", code)
+	}
+}
+
+func TestBasePathMountsAPIRoutesUnderPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{NodeName: "worker-a"},
+	})
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	s.SetDefaultNode("worker-a")
+	s.SetBasePath("collector/")
+
+	get := func(path string) *httptest.ResponseRecorder {
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr
+	}
+
+	for _, path := range []string{
+		"/collector/",
+		"/collector/api/v1/snapshots/worker-a",
+		"/collector/api/v1/snapshots/worker-a/summary",
+		"/collector/api/v1/nodes",
+		"/healthz",
+		"/readyz",
+	} {
+		if rr := get(path); rr.Code != http.StatusOK {
+			t.Fatalf("expected 200 for %s, got %d: %s", path, rr.Code, rr.Body.String())
+		}
+	}
+
+	rr := get("/collector/api/v1/snapshots/worker-a")
+	var payload snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if payload.Metadata.NodeName != "worker-a" {
+		t.Fatalf("expected worker-a snapshot under the base path, got %q", payload.Metadata.NodeName)
+	}
+
+	for _, path := range []string{"/", "/api/v1/snapshots/worker-a", "/api/v1/nodes", "/collector/healthz"} {
+		if rr := get(path); rr.Code != http.StatusNotFound {
+			t.Fatalf("expected 404 for %s outside the base path, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestReadyzReflectsLiveCollectorConnectivity(t *testing.T) {
+	collector := &fakeLiveCollector{}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rr.Code != http.StatusOK || rr.Body.String() != "ok" {
+		t.Fatalf("expected 200 ok while the backend is reachable, got %d %q", rr.Code, rr.Body.String())
+	}
+
+	collector.readyErr = errors.New("list pods in namespace \"openshift-ovn-kubernetes\": connection refused")
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503 when the backend is unreachable, got %d", rr.Code)
+	}
+	var body map[string]string
+	if err := json.Unmarshal(rr.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse readiness body: %v", err)
+	}
+	if body["status"] != "unavailable" || !strings.Contains(body["error"], "connection refused") {
+		t.Fatalf("unexpected readiness body: %#v", body)
+	}
+
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected /healthz to stay 200 while the backend is unreachable, got %d", rr.Code)
+	}
+}
+
+func TestNodesEndpointListsFileSnapshots(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-b.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", GeneratedAt: time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "default.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1"},
+	})
+
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes", nil)
+	rr := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var nodes []snapshot.NodeSummary
+	if err := json.Unmarshal(rr.Body.Bytes(), &nodes); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].NodeName != "worker-a" || nodes[1].NodeName != "worker-b" {
+		t.Fatalf("expected [worker-a worker-b], got %+v", nodes)
+	}
+	if nodes[0].GeneratedAt != nil {
+		t.Fatalf("expected no generatedAt for worker-a, got %v", nodes[0].GeneratedAt)
+	}
+	if nodes[1].GeneratedAt == nil || !nodes[1].GeneratedAt.Equal(time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected generatedAt for worker-b: %v", nodes[1].GeneratedAt)
+	}
+}
+
+func TestNodesEndpointMergesLiveNodes(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a"},
+	})
+
+	collector := &fakeLiveCollector{nodes: []string{"worker-c", "worker-a"}}
+	s := NewWithLiveCollector(snapshot.NewFileStore(tmpDir, "default.json"), collector)
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/nodes", nil)
+	rr := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var nodes []snapshot.NodeSummary
+	if err := json.Unmarshal(rr.Body.Bytes(), &nodes); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if len(nodes) != 2 {
+		t.Fatalf("expected 2 nodes, got %+v", nodes)
+	}
+	if nodes[0].NodeName != "worker-a" || !nodes[0].Live {
+		t.Fatalf("expected worker-a to be marked live, got %+v", nodes[0])
+	}
+	if nodes[1].NodeName != "worker-c" || !nodes[1].Live {
+		t.Fatalf("expected live-only worker-c, got %+v", nodes[1])
+	}
+}
+
+func TestNodesEndpointRejectsNonGet(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/nodes", nil)
+	rr := httptest.NewRecorder()
+
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusMethodNotAllowed {
+		t.Fatalf("expected 405, got %d", rr.Code)
+	}
+}
+
+func TestSnapshotEndpointStreamsNDJSON(t *testing.T) {
+	tmpDir := t.TempDir()
+	want := snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes: []snapshot.Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"},
+		},
+		Edges:    []snapshot.Edge{{ID: "router_to_switch:lr-1:ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"}},
+		Groups:   []snapshot.Group{},
+		Warnings: []snapshot.Warning{snapshot.NewWarning(snapshot.WarningParserNormalized, "normalized")},
+	}
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), want)
+	s := New(snapshot.NewFileStore(tmpDir, "default.json"))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a?format=ndjson", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := rr.Header().Get("Content-Type"); got != contentTypeNDJSON {
+		t.Fatalf("expected NDJSON content type, got %q", got)
+	}
+	if !rr.Flushed {
+		t.Fatalf("expected the stream to be flushed while writing")
+	}
+
+	got := snapshot.LogicalTopologySnapshot{Groups: []snapshot.Group{}}
+	var types []string
+	scanner := bufio.NewScanner(rr.Body)
+	for scanner.Scan() {
+		var record ndjsonRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+		}
+		types = append(types, record.Type)
+		switch record.Type {
+		case "metadata":
+			got.Metadata = *record.Metadata
+		case "node":
+			got.Nodes = append(got.Nodes, *record.Node)
+		case "edge":
+			got.Edges = append(got.Edges, *record.Edge)
+		case "warning":
+			got.Warnings = append(got.Warnings, *record.Warning)
+		default:
+			t.Fatalf("unexpected record type %q", record.Type)
+		}
+	}
+	if strings.Join(types, ",") != "metadata,node,node,edge,warning" {
+		t.Fatalf("expected metadata, nodes, edges then warnings, got %v", types)
+	}
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+	if string(gotJSON) != string(wantJSON) {
+		t.Fatalf("reconstructed graph mismatch:\n got %s\nwant %s", gotJSON, wantJSON)
+	}
+}
+
+func TestSnapshotEndpointRejectsUnknownFormat(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a?format=xml", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for unknown format, got %d", rr.Code)
+	}
+}
+
+func writeFixture(t *testing.T, path string, payload snapshot.LogicalTopologySnapshot) {
+	t.Helper()
+	bytes, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("marshal fixture: %v", err)
+	}
+	if err := os.WriteFile(path, bytes, 0o600); err != nil {
+		t.Fatalf("write fixture: %v", err)
+	}
+}
+
+type fakeLiveCollector struct {
+	payload  snapshot.LogicalTopologySnapshot
+	err      error
+	calls    int
+	nodes    []string
+	readyErr error
+}
+
+func (f *fakeLiveCollector) Collect(_ context.Context, _ string) (snapshot.LogicalTopologySnapshot, error) {
 	f.calls++
 	if f.err != nil {
 		return snapshot.LogicalTopologySnapshot{}, f.err
 	}
 	return f.payload, nil
 }
+
+func (f *fakeLiveCollector) ListNodes(_ context.Context) ([]string, error) {
+	return f.nodes, nil
+}
+
+func (f *fakeLiveCollector) Ready(_ context.Context) error {
+	return f.readyErr
+}
+
+func TestSnapshotEndpointServesSyntheticHealthSnapshot(t *testing.T) {
+	collector := &fakeLiveCollector{err: errors.New("live probe should not run")}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+	s.SetClock(clock.NewFake(time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/_health", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if collector.calls != 0 {
+		t.Fatalf("expected the health snapshot not to probe OVN, got %d calls", collector.calls)
+	}
+
+	var payload snapshot.LogicalTopologySnapshot
+	if err := json.Unmarshal(rr.Body.Bytes(), &payload); err != nil {
+		t.Fatalf("failed to decode health snapshot: %v", err)
+	}
+	if payload.Metadata.NodeName != "_health" || payload.Metadata.SourceHealth != "healthy" || payload.Metadata.SchemaVersion != snapshot.SchemaVersion {
+		t.Fatalf("unexpected health snapshot metadata: %#v", payload.Metadata)
+	}
+	kinds := map[string]int{}
+	nodeIDs := map[string]bool{}
+	for _, node := range payload.Nodes {
+		kinds[node.Kind]++
+		nodeIDs[node.ID] = true
+	}
+	if len(payload.Nodes) != 3 || kinds["logical_router"] != 1 || kinds["logical_switch"] != 1 || kinds["logical_switch_port"] != 1 {
+		t.Fatalf("expected one router, switch and port, got %#v", payload.Nodes)
+	}
+	if len(payload.Edges) != 1 || !nodeIDs[payload.Edges[0].Source] || !nodeIDs[payload.Edges[0].Target] {
+		t.Fatalf("expected one edge between snapshot nodes, got %#v", payload.Edges)
+	}
+	if len(payload.Warnings) != 0 {
+		t.Fatalf("expected no warnings, got %#v", payload.Warnings)
+	}
+
+	rr = httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/_other", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected other reserved node names to be 404, got %d", rr.Code)
+	}
+}
+
+// perNodeCollector fails for the nodes in errs and records the peak number of concurrent calls.
+type perNodeCollector struct {
+	nodes    []string
+	errs     map[string]error
+	inFlight atomic.Int32
+	peak     atomic.Int32
+}
+
+func (f *perNodeCollector) Collect(_ context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error) {
+	current := f.inFlight.Add(1)
+	defer f.inFlight.Add(-1)
+	for {
+		peak := f.peak.Load()
+		if current <= peak || f.peak.CompareAndSwap(peak, current) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	if err := f.errs[nodeName]; err != nil {
+		return snapshot.LogicalTopologySnapshot{}, err
+	}
+	return snapshot.LogicalTopologySnapshot{
+		Metadata: snapshot.Metadata{NodeName: nodeName, SourceHealth: "healthy"},
+		Nodes:    []snapshot.Node{{ID: "router-" + nodeName, Kind: "logical_router", Label: nodeName}},
+	}, nil
+}
+
+func (f *perNodeCollector) ListNodes(_ context.Context) ([]string, error) {
+	return f.nodes, nil
+}
+
+func TestSnapshotFanoutReturnsSnapshotsAndErrorsPerNode(t *testing.T) {
+	collector := &perNodeCollector{
+		nodes: []string{"worker-a", "worker-b", "worker-c", "worker-d", "worker-e"},
+		errs: map[string]error{
+			"worker-b": errors.New("exec failed"),
+			"worker-d": errors.New("pod not ready"),
+		},
+	}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+	s.SetClusterID("lab")
+	s.SetFanoutConcurrency(2)
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var results map[string]json.RawMessage
+	if err := json.Unmarshal(rr.Body.Bytes(), &results); err != nil {
+		t.Fatalf("failed to decode fan-out response: %v", err)
+	}
+	if len(results) != len(collector.nodes) {
+		t.Fatalf("expected %d entries, got %d: %s", len(collector.nodes), len(results), rr.Body.String())
+	}
+	for _, nodeName := range []string{"worker-a", "worker-c", "worker-e"} {
+		var payload snapshot.LogicalTopologySnapshot
+		if err := json.Unmarshal(results[nodeName], &payload); err != nil {
+			t.Fatalf("failed to decode %s snapshot: %v", nodeName, err)
+		}
+		if payload.Metadata.NodeName != nodeName || payload.Metadata.ClusterID != "lab" || len(payload.Nodes) != 1 {
+			t.Fatalf("unexpected %s snapshot: %#v", nodeName, payload)
+		}
+	}
+	for nodeName, want := range map[string]string{"worker-b": "exec failed", "worker-d": "pod not ready"} {
+		var failure struct {
+			Error string `json:"error"`
+		}
+		if err := json.Unmarshal(results[nodeName], &failure); err != nil {
+			t.Fatalf("failed to decode %s error: %v", nodeName, err)
+		}
+		if failure.Error != want {
+			t.Fatalf("expected %s error %q, got %q", nodeName, want, failure.Error)
+		}
+	}
+	if peak := collector.peak.Load(); peak > 2 {
+		t.Fatalf("expected at most 2 concurrent collections, got %d", peak)
+	}
+}
+
+func TestSnapshotFanoutRequiresLiveDiscovery(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/v1/snapshots", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 without live probing, got %d", rr.Code)
+	}
+}
+
+func TestPprofEndpointsAreServedOnlyWhenEnabled(t *testing.T) {
+	s := New(snapshot.NewFileStore(t.TempDir(), "default.json"))
+
+	get := func(handler http.Handler, path string) int {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		return rr.Code
+	}
+
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+		if got := get(s.Handler(), path); got != http.StatusNotFound {
+			t.Fatalf("expected %s to 404 by default, got %d", path, got)
+		}
+	}
+
+	s.SetPprofEnabled(true)
+	for _, path := range []string{"/debug/pprof/", "/debug/pprof/cmdline", "/debug/pprof/heap"} {
+		if got := get(s.Handler(), path); got != http.StatusOK {
+			t.Fatalf("expected %s to be served when enabled, got %d", path, got)
+		}
+		if got := get(PprofHandler(), path); got != http.StatusOK {
+			t.Fatalf("expected %s on the dedicated pprof handler, got %d", path, got)
+		}
+	}
+
+	s.SetAuthToken("s3cret")
+	if got := get(s.Handler(), "/debug/pprof/"); got != http.StatusUnauthorized {
+		t.Fatalf("expected pprof to require the bearer token, got %d", got)
+	}
+}
+
+// requestIDCollector records the request ID each live collection was called with.
+type requestIDCollector struct {
+	seen []string
+}
+
+func (c *requestIDCollector) Collect(ctx context.Context, nodeName string) (snapshot.LogicalTopologySnapshot, error) {
+	c.seen = append(c.seen, requestid.FromContext(ctx))
+	return snapshot.LogicalTopologySnapshot{Metadata: snapshot.Metadata{SchemaVersion: "v1alpha1", NodeName: nodeName}}, nil
+}
+
+func TestRequestIDRoundTripsAndIsLogged(t *testing.T) {
+	collector := &requestIDCollector{}
+	s := NewWithLiveCollector(snapshot.NewFileStore(t.TempDir(), "default.json"), collector)
+	var logs bytes.Buffer
+	s.logger = slog.New(slog.NewJSONHandler(&logs, nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/snapshots/worker-a", nil)
+	req.Header.Set(requestid.Header, "console-req-42")
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get(requestid.Header); got != "console-req-42" {
+		t.Fatalf("expected the caller's request ID to be echoed, got %q", got)
+	}
+	if !slices.Equal(collector.seen, []string{"console-req-42"}) {
+		t.Fatalf("expected the live collector to see the request ID, got %v", collector.seen)
+	}
+
+	var requested, access map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		switch record["msg"] {
+		case "logical topology snapshot requested":
+			requested = record
+		case "http request":
+			access = record
+		}
+	}
+	if requested["requestId"] != "console-req-42" {
+		t.Fatalf("expected the snapshot log line to carry the request ID, got %v", requested)
+	}
+	if access["requestId"] != "console-req-42" || access["method"] != "GET" || access["path"] != "/api/v1/snapshots/worker-a" ||
+		access["status"] != float64(http.StatusOK) || access["durationMs"] == nil {
+		t.Fatalf("unexpected access log line %v", access)
+	}
+
+	// Missing or unusable IDs are replaced with a generated one.
+	for _, incoming := range []string{"", "has spaces", strings.Repeat("x", 200)} {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		if incoming != "" {
+			req.Header.Set(requestid.Header, incoming)
+		}
+		rr := httptest.NewRecorder()
+		s.Handler().ServeHTTP(rr, req)
+		if got := rr.Header().Get(requestid.Header); len(got) != 32 || got == incoming {
+			t.Fatalf("expected a generated request ID for %q, got %q", incoming, got)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// parseSinceQuery reads ?since=<RFC 3339 time>. An absent value yields the zero time; a value
+// after now is rejected because no snapshot can have been stored then.
+func parseSinceQuery(r *http.Request, now time.Time) (time.Time, error) {
+	raw := strings.TrimSpace(r.URL.Query().Get("since"))
+	if raw == "" {
+		return time.Time{}, nil
+	}
+	since, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since must be an RFC 3339 timestamp such as 2026-01-02T15:04:05Z")
+	}
+	if since.After(now) {
+		return time.Time{}, fmt.Errorf("since must not be in the future")
+	}
+	return since, nil
+}
+
+// changedSince summarizes how payload differs from the node's stored snapshot in effect at
+// since. It writes the error response itself and reports false when no baseline is available.
+func (s *Server) changedSince(
+	w http.ResponseWriter,
+	r *http.Request,
+	nodeName string,
+	payload snapshot.LogicalTopologySnapshot,
+	since time.Time,
+) (snapshot.ChangeSummary, bool) {
+	history, ok := s.store.(snapshot.HistoryStore)
+	if !ok {
+		http.Error(w, snapshot.ErrNoHistory.Error(), http.StatusConflict)
+		return snapshot.ChangeSummary{}, false
+	}
+	baseline, err := history.GetByNodeAt(r.Context(), nodeName, since)
+	switch {
+	case errors.Is(err, snapshot.ErrNoHistory):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return snapshot.ChangeSummary{}, false
+	case errors.Is(err, snapshot.ErrNotFound):
+		http.Error(w, fmt.Sprintf("no snapshot stored at or before %s", since.UTC().Format(time.RFC3339)), http.StatusNotFound)
+		return snapshot.ChangeSummary{}, false
+	case err != nil:
+		s.writeStoreError(w, nodeName, err)
+		return snapshot.ChangeSummary{}, false
+	}
+	return snapshot.SummarizeChanges(baseline, payload, since), true
+}
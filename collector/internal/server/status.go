@@ -0,0 +1,42 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+const statusPath = "/api/v1/status"
+
+// ResourceStatusReporter is implemented by live collectors that track per-table probe outcomes.
+type ResourceStatusReporter interface {
+	ResourceStatuses() []snapshot.ResourceStatus
+}
+
+// collectorStatus is the /api/v1/status body.
+type collectorStatus struct {
+	Live      bool                      `json:"live"`
+	Resources []snapshot.ResourceStatus `json:"resources"`
+}
+
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	status := collectorStatus{
+		Live:      s.liveCollector != nil,
+		Resources: []snapshot.ResourceStatus{},
+	}
+	if reporter, ok := s.liveCollector.(ResourceStatusReporter); ok {
+		status.Resources = append(status.Resources, reporter.ResourceStatuses()...)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(status); err != nil {
+		s.logger.Error("failed to encode collector status", "error", err)
+	}
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// defaultSubgraphDepth is used when ?root= is given without ?depth=.
+const defaultSubgraphDepth = 1
+
+// parseSubgraphQuery reads ?root={id}&depth=N. An empty root means the full graph is served.
+func parseSubgraphQuery(r *http.Request) (string, int, error) {
+	query := r.URL.Query()
+	root := strings.TrimSpace(query.Get("root"))
+	rawDepth := strings.TrimSpace(query.Get("depth"))
+	if root == "" {
+		if rawDepth != "" {
+			return "", 0, fmt.Errorf("depth requires a root node id")
+		}
+		return "", 0, nil
+	}
+	if rawDepth == "" {
+		return root, defaultSubgraphDepth, nil
+	}
+
+	depth, err := strconv.Atoi(rawDepth)
+	if err != nil || depth < 0 || depth > snapshot.MaxSubgraphDepth {
+		return "", 0, fmt.Errorf("depth must be an integer between 0 and %d", snapshot.MaxSubgraphDepth)
+	}
+	return root, depth, nil
+}
@@ -0,0 +1,63 @@
+package server
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/snapshot"
+)
+
+// snapshotSummary is the compact view served by /api/v1/snapshots/{node}/summary for dashboards
+// that only need counts and freshness rather than the full graph.
+type snapshotSummary struct {
+	NodeName     string     `json:"nodeName"`
+	ClusterID    string     `json:"clusterID,omitempty"`
+	GeneratedAt  *time.Time `json:"generatedAt,omitempty"`
+	SourceHealth string     `json:"sourceHealth"`
+	NodeCount    int        `json:"nodeCount"`
+	EdgeCount    int        `json:"edgeCount"`
+	WarningCount int        `json:"warningCount"`
+	// ChangedSince is only set when the summary was requested with ?since=.
+	ChangedSince *snapshot.ChangeSummary `json:"changedSince,omitempty"`
+}
+
+func summarize(payload snapshot.LogicalTopologySnapshot) snapshotSummary {
+	summary := snapshotSummary{
+		NodeName:     payload.Metadata.NodeName,
+		ClusterID:    payload.Metadata.ClusterID,
+		SourceHealth: payload.Metadata.SourceHealth,
+		NodeCount:    len(payload.Nodes),
+		EdgeCount:    len(payload.Edges),
+		WarningCount: len(payload.Warnings),
+		ChangedSince: payload.ChangedSince,
+	}
+	if !payload.Metadata.GeneratedAt.IsZero() {
+		generatedAt := payload.Metadata.GeneratedAt.UTC()
+		summary.GeneratedAt = &generatedAt
+	}
+	return summary
+}
+
+func (s *Server) writeSummary(w http.ResponseWriter, payload snapshot.LogicalTopologySnapshot, nodeName string) {
+	payload = s.decorateMetadata(payload, nodeName)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(summarize(payload)); err != nil {
+		slog.Error("failed to encode snapshot summary", "node", nodeName, "error", err)
+	}
+}
+
+// writeStats serves /api/v1/snapshots/{node}/stats, the per-kind and per-severity breakdown of
+// the counts in the summary.
+func (s *Server) writeStats(w http.ResponseWriter, payload snapshot.LogicalTopologySnapshot, nodeName string) {
+	payload = s.decorateMetadata(payload, nodeName)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := json.NewEncoder(w).Encode(snapshot.SnapshotStats(payload)); err != nil {
+		slog.Error("failed to encode snapshot stats", "node", nodeName, "error", err)
+	}
+}
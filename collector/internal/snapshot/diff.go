@@ -0,0 +1,150 @@
+package snapshot
+
+import (
+	"sort"
+	"time"
+)
+
+// SnapshotDiff describes how snapshot B differs from snapshot A. Added entries exist only in B,
+// removed entries only in A. Nodes and edges are matched by ID.
+type SnapshotDiff struct {
+	Base         string           `json:"base"`
+	Against      string           `json:"against"`
+	NodesAdded   []Node           `json:"nodesAdded"`
+	NodesRemoved []Node           `json:"nodesRemoved"`
+	NodesChanged []NodeChange     `json:"nodesChanged"`
+	EdgesAdded   []Edge           `json:"edgesAdded"`
+	EdgesRemoved []Edge           `json:"edgesRemoved"`
+	Metadata     []MetadataChange `json:"metadata"`
+}
+
+// NodeChange records a node present in both snapshots whose kind or label differs.
+type NodeChange struct {
+	ID          string `json:"id"`
+	KindBefore  string `json:"kindBefore,omitempty"`
+	KindAfter   string `json:"kindAfter,omitempty"`
+	LabelBefore string `json:"labelBefore,omitempty"`
+	LabelAfter  string `json:"labelAfter,omitempty"`
+}
+
+// MetadataChange records a metadata field whose value differs between the snapshots.
+type MetadataChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// Empty reports whether the snapshots have the same nodes, edges, and metadata.
+func (d SnapshotDiff) Empty() bool {
+	return len(d.NodesAdded) == 0 && len(d.NodesRemoved) == 0 && len(d.NodesChanged) == 0 &&
+		len(d.EdgesAdded) == 0 && len(d.EdgesRemoved) == 0 && len(d.Metadata) == 0
+}
+
+// DiffSnapshots compares a against b. Results are sorted by ID so the output is stable.
+func DiffSnapshots(a, b LogicalTopologySnapshot) SnapshotDiff {
+	diff := SnapshotDiff{
+		Base:         a.Metadata.NodeName,
+		Against:      b.Metadata.NodeName,
+		NodesAdded:   []Node{},
+		NodesRemoved: []Node{},
+		NodesChanged: []NodeChange{},
+		EdgesAdded:   []Edge{},
+		EdgesRemoved: []Edge{},
+		Metadata:     diffMetadata(a.Metadata, b.Metadata),
+	}
+
+	nodesA := make(map[string]Node, len(a.Nodes))
+	for _, node := range a.Nodes {
+		nodesA[node.ID] = node
+	}
+	nodesB := make(map[string]Node, len(b.Nodes))
+	for _, node := range b.Nodes {
+		nodesB[node.ID] = node
+	}
+	for id, before := range nodesA {
+		after, ok := nodesB[id]
+		if !ok {
+			diff.NodesRemoved = append(diff.NodesRemoved, before)
+			continue
+		}
+		if before.Kind != after.Kind || before.Label != after.Label {
+			change := NodeChange{ID: id}
+			if before.Kind != after.Kind {
+				change.KindBefore, change.KindAfter = before.Kind, after.Kind
+			}
+			if before.Label != after.Label {
+				change.LabelBefore, change.LabelAfter = before.Label, after.Label
+			}
+			diff.NodesChanged = append(diff.NodesChanged, change)
+		}
+	}
+	for id, after := range nodesB {
+		if _, ok := nodesA[id]; !ok {
+			diff.NodesAdded = append(diff.NodesAdded, after)
+		}
+	}
+
+	edgesA := make(map[string]Edge, len(a.Edges))
+	for _, edge := range a.Edges {
+		edgesA[edgeKey(edge)] = edge
+	}
+	edgesB := make(map[string]Edge, len(b.Edges))
+	for _, edge := range b.Edges {
+		edgesB[edgeKey(edge)] = edge
+	}
+	for key, edge := range edgesA {
+		if _, ok := edgesB[key]; !ok {
+			diff.EdgesRemoved = append(diff.EdgesRemoved, edge)
+		}
+	}
+	for key, edge := range edgesB {
+		if _, ok := edgesA[key]; !ok {
+			diff.EdgesAdded = append(diff.EdgesAdded, edge)
+		}
+	}
+
+	sortNodes(diff.NodesAdded)
+	sortNodes(diff.NodesRemoved)
+	sort.Slice(diff.NodesChanged, func(i, j int) bool { return diff.NodesChanged[i].ID < diff.NodesChanged[j].ID })
+	sortEdges(diff.EdgesAdded)
+	sortEdges(diff.EdgesRemoved)
+	return diff
+}
+
+// edgeKey identifies an edge by ID, or by its endpoints and kind when the ID is empty.
+func edgeKey(edge Edge) string {
+	if edge.ID != "" {
+		return edge.ID
+	}
+	return edge.Source + "|" + edge.Kind + "|" + edge.Target
+}
+
+func diffMetadata(a, b Metadata) []MetadataChange {
+	changes := []MetadataChange{}
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, MetadataChange{Field: field, Before: before, After: after})
+		}
+	}
+	add("schemaVersion", a.SchemaVersion, b.SchemaVersion)
+	add("generatedAt", formatTime(a.GeneratedAt), formatTime(b.GeneratedAt))
+	add("sourceHealth", a.SourceHealth, b.SourceHealth)
+	add("nodeName", a.NodeName, b.NodeName)
+	add("clusterID", a.ClusterID, b.ClusterID)
+	return changes
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func sortNodes(nodes []Node) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID < nodes[j].ID })
+}
+
+func sortEdges(edges []Edge) {
+	sort.Slice(edges, func(i, j int) bool { return edgeKey(edges[i]) < edgeKey(edges[j]) })
+}
@@ -0,0 +1,98 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDiffSnapshotsReportsNodeAndEdgeChanges(t *testing.T) {
+	a := LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"},
+		Nodes: []Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"},
+			{ID: "ls-old", Kind: "logical_switch", Label: "retired"},
+		},
+		Edges: []Edge{
+			{ID: "lr-1->ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "lr-1->ls-old", Source: "lr-1", Target: "ls-old", Kind: "router_to_switch"},
+		},
+	}
+	b := LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", SourceHealth: "degraded"},
+		Nodes: []Node{
+			{ID: "lr-1", Kind: "logical_router", Label: "ovn_cluster_router"},
+			{ID: "ls-1", Kind: "logical_switch", Label: "worker-b"},
+			{ID: "ls-new", Kind: "logical_switch", Label: "join"},
+		},
+		Edges: []Edge{
+			{ID: "lr-1->ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "lr-1->ls-new", Source: "lr-1", Target: "ls-new", Kind: "router_to_switch"},
+		},
+	}
+
+	diff := DiffSnapshots(a, b)
+	if diff.Base != "worker-a" || diff.Against != "worker-b" {
+		t.Fatalf("expected base worker-a against worker-b, got %q/%q", diff.Base, diff.Against)
+	}
+	if len(diff.NodesAdded) != 1 || diff.NodesAdded[0].ID != "ls-new" {
+		t.Fatalf("expected ls-new added, got %#v", diff.NodesAdded)
+	}
+	if len(diff.NodesRemoved) != 1 || diff.NodesRemoved[0].ID != "ls-old" {
+		t.Fatalf("expected ls-old removed, got %#v", diff.NodesRemoved)
+	}
+	if len(diff.NodesChanged) != 1 {
+		t.Fatalf("expected one changed node, got %#v", diff.NodesChanged)
+	}
+	change := diff.NodesChanged[0]
+	if change.ID != "ls-1" || change.LabelBefore != "worker-a" || change.LabelAfter != "worker-b" || change.KindBefore != "" {
+		t.Fatalf("expected ls-1 label change only, got %#v", change)
+	}
+	if len(diff.EdgesAdded) != 1 || diff.EdgesAdded[0].ID != "lr-1->ls-new" {
+		t.Fatalf("expected lr-1->ls-new added, got %#v", diff.EdgesAdded)
+	}
+	if len(diff.EdgesRemoved) != 1 || diff.EdgesRemoved[0].ID != "lr-1->ls-old" {
+		t.Fatalf("expected lr-1->ls-old removed, got %#v", diff.EdgesRemoved)
+	}
+
+	fields := map[string]MetadataChange{}
+	for _, change := range diff.Metadata {
+		fields[change.Field] = change
+	}
+	if len(fields) != 2 {
+		t.Fatalf("expected nodeName and sourceHealth metadata changes, got %#v", diff.Metadata)
+	}
+	if got := fields["sourceHealth"]; got.Before != "healthy" || got.After != "degraded" {
+		t.Fatalf("unexpected sourceHealth change %#v", got)
+	}
+}
+
+func TestDiffSnapshotsIdenticalIsEmpty(t *testing.T) {
+	payload := LogicalTopologySnapshot{
+		Metadata: Metadata{
+			SchemaVersion: "v1alpha1",
+			NodeName:      "worker-a",
+			GeneratedAt:   time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC),
+		},
+		Nodes: []Node{{ID: "ls-1", Kind: "logical_switch", Label: "worker-a"}},
+		Edges: []Edge{{Source: "ls-1", Target: "lsp-1", Kind: "switch_to_port"}},
+	}
+
+	diff := DiffSnapshots(payload, payload)
+	if !diff.Empty() {
+		t.Fatalf("expected empty diff for identical snapshots, got %#v", diff)
+	}
+}
+
+func TestDiffSnapshotsMatchesEdgesWithoutIDByEndpoints(t *testing.T) {
+	a := LogicalTopologySnapshot{Edges: []Edge{{Source: "ls-1", Target: "lsp-1", Kind: "switch_to_port"}}}
+	b := LogicalTopologySnapshot{Edges: []Edge{{Source: "ls-1", Target: "lsp-2", Kind: "switch_to_port"}}}
+
+	diff := DiffSnapshots(a, b)
+	if len(diff.EdgesAdded) != 1 || diff.EdgesAdded[0].Target != "lsp-2" {
+		t.Fatalf("expected edge to lsp-2 added, got %#v", diff.EdgesAdded)
+	}
+	if len(diff.EdgesRemoved) != 1 || diff.EdgesRemoved[0].Target != "lsp-1" {
+		t.Fatalf("expected edge to lsp-1 removed, got %#v", diff.EdgesRemoved)
+	}
+}
@@ -0,0 +1,139 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrNoHistory reports a store that does not keep earlier snapshots.
+var ErrNoHistory = errors.New("snapshot history is not enabled")
+
+// historyTimeLayout names history files. It is fixed width, so file names sort by time.
+const historyTimeLayout = "20060102T150405.000000000Z"
+
+// HistoryStore is implemented by stores that keep earlier snapshots for each node.
+type HistoryStore interface {
+	// GetByNodeAt returns the newest snapshot for nodeName generated at or before at.
+	GetByNodeAt(ctx context.Context, nodeName string, at time.Time) (LogicalTopologySnapshot, error)
+}
+
+// SetHistoryDir keeps a copy of every snapshot passed to Put under dir/<node>/, named by its
+// generation time, so GetByNodeAt can serve earlier versions. An empty dir disables history.
+func (s *FileStore) SetHistoryDir(dir string) {
+	s.historyDir = dir
+}
+
+// GetByNodeAt returns the newest history entry for nodeName generated at or before at.
+func (s *FileStore) GetByNodeAt(_ context.Context, nodeName string, at time.Time) (LogicalTopologySnapshot, error) {
+	if s.historyDir == "" {
+		return LogicalTopologySnapshot{}, ErrNoHistory
+	}
+	if err := validateNodeName(nodeName); err != nil {
+		return LogicalTopologySnapshot{}, err
+	}
+
+	names, err := s.historyFiles(nodeName)
+	if err != nil {
+		return LogicalTopologySnapshot{}, err
+	}
+	cutoff := at.UTC().Format(historyTimeLayout)
+	index := sort.Search(len(names), func(i int) bool { return names[i] > cutoff+".json" })
+	if index == 0 {
+		return LogicalTopologySnapshot{}, ErrNotFound
+	}
+	payload, err := loadSnapshot(filepath.Join(s.historyDir, nodeName, names[index-1]))
+	if err != nil {
+		return LogicalTopologySnapshot{}, err
+	}
+	if payload.Metadata.NodeName == "" {
+		payload.Metadata.NodeName = nodeName
+	}
+	return payload, nil
+}
+
+// historyFiles lists the node's history file names, oldest first.
+func (s *FileStore) historyFiles(nodeName string) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(s.historyDir, nodeName))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{}, nil
+		}
+		return nil, err
+	}
+	names := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" {
+			continue
+		}
+		if _, err := time.Parse(historyTimeLayout, strings.TrimSuffix(name, ".json")); err != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// putHistory records data as the node's snapshot generated at generatedAt.
+func (s *FileStore) putHistory(nodeName string, generatedAt time.Time, data []byte) error {
+	if generatedAt.IsZero() {
+		generatedAt = time.Now()
+	}
+	dir := filepath.Join(s.historyDir, nodeName)
+	return writeFileAtomic(dir, nodeName, generatedAt.UTC().Format(historyTimeLayout)+".json", data)
+}
+
+// GetByNodeAt reads history from the first layer, which is the one Put writes to.
+func (s *LayeredStore) GetByNodeAt(ctx context.Context, nodeName string, at time.Time) (LogicalTopologySnapshot, error) {
+	if len(s.layers) == 0 {
+		return LogicalTopologySnapshot{}, ErrNoHistory
+	}
+	history, ok := s.layers[0].(HistoryStore)
+	if !ok {
+		return LogicalTopologySnapshot{}, ErrNoHistory
+	}
+	return history.GetByNodeAt(ctx, nodeName, at)
+}
+
+// ChangeSummary lists the IDs of nodes and edges added or removed relative to the newest
+// snapshot generated at or before Since. It is a lighter alternative to a full SnapshotDiff.
+type ChangeSummary struct {
+	Since               time.Time `json:"since"`
+	BaselineGeneratedAt time.Time `json:"baselineGeneratedAt"`
+	NodesAdded          []string  `json:"nodesAdded"`
+	NodesRemoved        []string  `json:"nodesRemoved"`
+	EdgesAdded          []string  `json:"edgesAdded"`
+	EdgesRemoved        []string  `json:"edgesRemoved"`
+}
+
+// SummarizeChanges compares baseline, the snapshot in effect at since, with current.
+func SummarizeChanges(baseline, current LogicalTopologySnapshot, since time.Time) ChangeSummary {
+	diff := DiffSnapshots(baseline, current)
+	summary := ChangeSummary{
+		Since:               since.UTC(),
+		BaselineGeneratedAt: baseline.Metadata.GeneratedAt.UTC(),
+		NodesAdded:          make([]string, 0, len(diff.NodesAdded)),
+		NodesRemoved:        make([]string, 0, len(diff.NodesRemoved)),
+		EdgesAdded:          make([]string, 0, len(diff.EdgesAdded)),
+		EdgesRemoved:        make([]string, 0, len(diff.EdgesRemoved)),
+	}
+	for _, node := range diff.NodesAdded {
+		summary.NodesAdded = append(summary.NodesAdded, node.ID)
+	}
+	for _, node := range diff.NodesRemoved {
+		summary.NodesRemoved = append(summary.NodesRemoved, node.ID)
+	}
+	for _, edge := range diff.EdgesAdded {
+		summary.EdgesAdded = append(summary.EdgesAdded, edgeKey(edge))
+	}
+	for _, edge := range diff.EdgesRemoved {
+		summary.EdgesRemoved = append(summary.EdgesRemoved, edgeKey(edge))
+	}
+	return summary
+}
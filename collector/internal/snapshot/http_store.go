@@ -0,0 +1,163 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
+)
+
+// ErrReadOnly reports a write to a store that only serves snapshots.
+var ErrReadOnly = errors.New("snapshot store is read-only")
+
+// DefaultHTTPStoreTimeout bounds each request HTTPStore makes to its upstream collector.
+const DefaultHTTPStoreTimeout = 30 * time.Second
+
+// Snapshot response headers set by an upstream collector. They mirror the server's headers and
+// are copied into the returned metadata.
+const (
+	upstreamHeaderGeneratedAt  = "X-OVN-Recon-Snapshot-Generated-At"
+	upstreamHeaderSourceHealth = "X-OVN-Recon-Snapshot-Source-Health"
+	upstreamHeaderNodeName     = "X-OVN-Recon-Snapshot-Node-Name"
+)
+
+// maxUpstreamErrorBody bounds how much of an upstream error response is quoted in errors.
+const maxUpstreamErrorBody = 512
+
+// HTTPStore is a read-only Store that proxies another collector's snapshot API, so a central
+// collector can serve snapshots gathered by per-cluster collectors.
+type HTTPStore struct {
+	baseURL     string
+	client      *http.Client
+	bearerToken string
+}
+
+// NewHTTPStore creates a store reading from the collector at baseURL, which includes any base
+// path the upstream serves under, e.g. https://collector.example.com/ovn.
+func NewHTTPStore(baseURL string) (*HTTPStore, error) {
+	parsed, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil {
+		return nil, fmt.Errorf("parse upstream URL: %w", err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("upstream URL %q must be an absolute http or https URL", baseURL)
+	}
+	return &HTTPStore{
+		baseURL: strings.TrimSuffix(parsed.String(), "/"),
+		client:  &http.Client{Timeout: DefaultHTTPStoreTimeout},
+	}, nil
+}
+
+// SetClient replaces the HTTP client used to reach the upstream collector.
+func (s *HTTPStore) SetClient(client *http.Client) {
+	s.client = client
+}
+
+// SetBearerToken sets the token sent as `Authorization: Bearer <token>` to an upstream collector
+// that requires authentication. An empty token sends no Authorization header.
+func (s *HTTPStore) SetBearerToken(token string) {
+	s.bearerToken = token
+}
+
+// GetByNode fetches the node's snapshot from the upstream collector. An upstream 404 is
+// reported as ErrNotFound; the upstream snapshot headers override the payload metadata.
+func (s *HTTPStore) GetByNode(ctx context.Context, nodeName string) (LogicalTopologySnapshot, error) {
+	resp, err := s.get(ctx, "/api/v1/snapshots/"+url.PathEscape(nodeName))
+	if err != nil {
+		return LogicalTopologySnapshot{}, fmt.Errorf("fetch upstream snapshot for node %s: %w", nodeName, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return LogicalTopologySnapshot{}, ErrNotFound
+	}
+	if err := upstreamStatusError(resp); err != nil {
+		return LogicalTopologySnapshot{}, fmt.Errorf("fetch upstream snapshot for node %s: %w", nodeName, err)
+	}
+
+	var payload LogicalTopologySnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		return LogicalTopologySnapshot{}, fmt.Errorf("decode upstream snapshot for node %s: %w", nodeName, err)
+	}
+	applyUpstreamHeaders(&payload, resp.Header)
+	if payload.Metadata.NodeName == "" {
+		payload.Metadata.NodeName = nodeName
+	}
+	return payload, nil
+}
+
+// ListNodes returns the upstream collector's node list.
+func (s *HTTPStore) ListNodes(ctx context.Context) ([]NodeSummary, error) {
+	resp, err := s.get(ctx, "/api/v1/nodes")
+	if err != nil {
+		return nil, fmt.Errorf("list upstream nodes: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := upstreamStatusError(resp); err != nil {
+		return nil, fmt.Errorf("list upstream nodes: %w", err)
+	}
+
+	summaries := []NodeSummary{}
+	if err := json.NewDecoder(resp.Body).Decode(&summaries); err != nil {
+		return nil, fmt.Errorf("decode upstream node list: %w", err)
+	}
+	return summaries, nil
+}
+
+// Put always fails with ErrReadOnly; snapshots are owned by the upstream collector.
+func (s *HTTPStore) Put(_ context.Context, nodeName string, _ LogicalTopologySnapshot) error {
+	return fmt.Errorf("store snapshot for node %s: %w", nodeName, ErrReadOnly)
+}
+
+// get issues a GET for path under the base URL with the bearer token, forwarding the request ID
+// when one is set.
+func (s *HTTPStore) get(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if s.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.bearerToken)
+	}
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
+	return s.client.Do(req)
+}
+
+// upstreamStatusError returns an error quoting the upstream response unless it succeeded.
+// 203 is accepted because upstream collectors use it to flag degraded snapshots.
+func upstreamStatusError(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxUpstreamErrorBody))
+	message := strings.TrimSpace(string(body))
+	if message == "" {
+		return fmt.Errorf("upstream returned %s", resp.Status)
+	}
+	return fmt.Errorf("upstream returned %s: %s", resp.Status, message)
+}
+
+// applyUpstreamHeaders copies the upstream X-OVN-Recon-Snapshot-* headers into the metadata.
+// Headers that are missing or malformed leave the decoded metadata unchanged.
+func applyUpstreamHeaders(payload *LogicalTopologySnapshot, header http.Header) {
+	if raw := header.Get(upstreamHeaderGeneratedAt); raw != "" {
+		if generatedAt, err := time.Parse(time.RFC3339, raw); err == nil {
+			payload.Metadata.GeneratedAt = generatedAt
+		}
+	}
+	if sourceHealth := header.Get(upstreamHeaderSourceHealth); sourceHealth != "" {
+		payload.Metadata.SourceHealth = sourceHealth
+	}
+	if nodeName := header.Get(upstreamHeaderNodeName); nodeName != "" {
+		payload.Metadata.NodeName = nodeName
+	}
+}
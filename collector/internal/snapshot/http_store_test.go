@@ -0,0 +1,163 @@
+package snapshot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/requestid"
+)
+
+func TestHTTPStoreFetchesSnapshotAndForwardsHeaders(t *testing.T) {
+	var gotPath, gotRequestID string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotRequestID = r.Header.Get(requestid.Header)
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-OVN-Recon-Snapshot-Generated-At", "2026-03-01T12:00:00Z")
+		w.Header().Set("X-OVN-Recon-Snapshot-Source-Health", "degraded")
+		w.Header().Set("X-OVN-Recon-Snapshot-Node-Name", "worker-a.example.com")
+		_ = json.NewEncoder(w).Encode(LogicalTopologySnapshot{
+			Metadata: Metadata{SchemaVersion: "v1alpha1", SourceHealth: "healthy"},
+			Nodes:    []Node{{ID: "ls-1", Kind: "logical_switch", Label: "ls-1"}},
+		})
+	}))
+	defer upstream.Close()
+
+	store, err := NewHTTPStore(upstream.URL + "/ovn/")
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	ctx := requestid.WithID(context.Background(), "req-123")
+	payload, err := store.GetByNode(ctx, "worker-a")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if gotPath != "/ovn/api/v1/snapshots/worker-a" {
+		t.Fatalf("unexpected upstream path %q", gotPath)
+	}
+	if gotRequestID != "req-123" {
+		t.Fatalf("expected request ID to be forwarded, got %q", gotRequestID)
+	}
+	if len(payload.Nodes) != 1 || payload.Nodes[0].ID != "ls-1" {
+		t.Fatalf("unexpected nodes: %#v", payload.Nodes)
+	}
+	wantGeneratedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if !payload.Metadata.GeneratedAt.Equal(wantGeneratedAt) {
+		t.Fatalf("expected generatedAt from header, got %s", payload.Metadata.GeneratedAt)
+	}
+	if payload.Metadata.SourceHealth != "degraded" {
+		t.Fatalf("expected source health from header, got %q", payload.Metadata.SourceHealth)
+	}
+	if payload.Metadata.NodeName != "worker-a.example.com" {
+		t.Fatalf("expected node name from header, got %q", payload.Metadata.NodeName)
+	}
+}
+
+func TestHTTPStoreMapsUpstreamNotFound(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "snapshot not found", http.StatusNotFound)
+	}))
+	defer upstream.Close()
+
+	store, err := NewHTTPStore(upstream.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	if _, err := store.GetByNode(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestHTTPStoreWrapsUpstreamErrors(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "probe exploded", http.StatusInternalServerError)
+	}))
+	defer upstream.Close()
+
+	store, err := NewHTTPStore(upstream.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	_, err = store.GetByNode(context.Background(), "worker-a")
+	if err == nil || errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected a wrapped upstream error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "worker-a") || !strings.Contains(err.Error(), "probe exploded") {
+		t.Fatalf("expected error to name the node and quote the upstream body, got %v", err)
+	}
+
+	upstream.Close()
+	if _, err := store.ListNodes(context.Background()); err == nil {
+		t.Fatal("expected an error once the upstream is unreachable")
+	}
+}
+
+func TestHTTPStoreListsNodesAndRejectsWrites(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode([]NodeSummary{{NodeName: "worker-a"}, {NodeName: "worker-b", Live: true}})
+	}))
+	defer upstream.Close()
+
+	store, err := NewHTTPStore(upstream.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	summaries, err := store.ListNodes(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(summaries) != 2 || summaries[0].NodeName != "worker-a" || !summaries[1].Live {
+		t.Fatalf("unexpected node summaries: %#v", summaries)
+	}
+
+	if err := store.Put(context.Background(), "worker-a", LogicalTopologySnapshot{}); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+}
+
+func TestHTTPStoreSendsBearerTokenToSecuredUpstream(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer s3cret" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(LogicalTopologySnapshot{Metadata: Metadata{NodeName: "worker-a"}})
+	}))
+	defer upstream.Close()
+
+	store, err := NewHTTPStore(upstream.URL)
+	if err != nil {
+		t.Fatalf("NewHTTPStore: %v", err)
+	}
+	if _, err := store.GetByNode(context.Background(), "worker-a"); err == nil || !strings.Contains(err.Error(), "401") {
+		t.Fatalf("expected an unauthorized error without a token, got %v", err)
+	}
+
+	store.SetBearerToken("s3cret")
+	payload, err := store.GetByNode(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("expected the token to be accepted, got %v", err)
+	}
+	if payload.Metadata.NodeName != "worker-a" {
+		t.Fatalf("unexpected node name %q", payload.Metadata.NodeName)
+	}
+}
+
+func TestNewHTTPStoreRejectsInvalidURLs(t *testing.T) {
+	for _, raw := range []string{"", "collector:8090", "ftp://collector", "http://"} {
+		if _, err := NewHTTPStore(raw); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// LayeredStore serves snapshots from an ordered list of stores, returning the first match.
+type LayeredStore struct {
+	layers []Store
+}
+
+// NewLayeredStore creates a store that consults layers in order.
+func NewLayeredStore(layers ...Store) *LayeredStore {
+	return &LayeredStore{layers: layers}
+}
+
+// FileStoreOptions configures the file stores built by NewLayeredFileStore. NodeMatch applies to
+// every directory. A non-empty HistoryDir keeps every snapshot stored in the first directory as
+// a history entry, pruned to Retention.
+type FileStoreOptions struct {
+	NodeMatch  NodeMatch
+	HistoryDir string
+	Retention  Retention
+}
+
+// NewLayeredFileStore builds a store over several snapshot directories. A node file in any
+// directory wins over every fallback file, and earlier directories win over later ones. A single
+// directory yields a plain FileStore.
+func NewLayeredFileStore(dirs []string, fallbackFile string, opts FileStoreOptions) Store {
+	if len(dirs) == 1 {
+		store := NewFileStore(dirs[0], fallbackFile)
+		store.SetNodeMatch(opts.NodeMatch)
+		store.SetHistoryDir(opts.HistoryDir)
+		store.SetRetention(opts.Retention)
+		return store
+	}
+
+	layers := make([]Store, 0, 2*len(dirs))
+	for i, dir := range dirs {
+		layer := &FileStore{dir: dir, fallbackFile: fallbackFile, nodeOnly: true, nodeMatch: opts.NodeMatch}
+		if i == 0 {
+			// Put only writes to the first layer, so only it keeps history.
+			layer.historyDir, layer.retention = opts.HistoryDir, opts.Retention
+		}
+		layers = append(layers, layer)
+	}
+	if fallbackFile != "" {
+		for _, dir := range dirs {
+			// Node files were already searched, fuzzily if enabled, by the node-only layers.
+			layers = append(layers, NewFileStore(dir, fallbackFile))
+		}
+	}
+	return NewLayeredStore(layers...)
+}
+
+// GetByNode returns the snapshot from the first layer that has one for the node.
+func (s *LayeredStore) GetByNode(ctx context.Context, nodeName string) (LogicalTopologySnapshot, error) {
+	for _, layer := range s.layers {
+		payload, err := layer.GetByNode(ctx, nodeName)
+		if err == nil {
+			return payload, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			return LogicalTopologySnapshot{}, err
+		}
+	}
+	return LogicalTopologySnapshot{}, ErrNotFound
+}
+
+// ListNodes merges node summaries across layers; the earliest layer wins for a node.
+func (s *LayeredStore) ListNodes(ctx context.Context) ([]NodeSummary, error) {
+	seen := map[string]bool{}
+	summaries := []NodeSummary{}
+	for _, layer := range s.layers {
+		layerSummaries, err := layer.ListNodes(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range layerSummaries {
+			if seen[summary.NodeName] {
+				continue
+			}
+			seen[summary.NodeName] = true
+			summaries = append(summaries, summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].NodeName < summaries[j].NodeName
+	})
+	return summaries, nil
+}
+
+// Put stores the snapshot in the first layer, which is consulted first on lookup.
+func (s *LayeredStore) Put(ctx context.Context, nodeName string, payload LogicalTopologySnapshot) error {
+	if len(s.layers) == 0 {
+		return fmt.Errorf("layered store has no layers")
+	}
+	return s.layers[0].Put(ctx, nodeName, payload)
+}
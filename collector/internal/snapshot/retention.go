@@ -0,0 +1,82 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
+)
+
+// Retention bounds the history kept per node. MaxCount keeps at most that many history
+// entries and MaxAge drops entries generated longer ago than that; zero disables a limit. The
+// newest entry is always kept.
+type Retention struct {
+	MaxCount int
+	MaxAge   time.Duration
+}
+
+// Enabled reports whether any limit is set.
+func (r Retention) Enabled() bool {
+	return r.MaxCount > 0 || r.MaxAge > 0
+}
+
+// SetRetention prunes the node's history entries beyond retention after every Put.
+func (s *FileStore) SetRetention(retention Retention) {
+	s.retention = retention
+}
+
+// SetClock replaces the time source used for retention by age. A nil clock restores the wall
+// clock.
+func (s *FileStore) SetClock(clk clock.Clock) {
+	if clk == nil {
+		clk = clock.Real{}
+	}
+	s.clock = clk
+}
+
+// pruneHistory deletes the node's oldest history entries beyond the retention limits. Every
+// file is attempted even when one fails; the failures are joined into the returned error.
+func (s *FileStore) pruneHistory(nodeName string) error {
+	if !s.retention.Enabled() {
+		return nil
+	}
+	names, err := s.historyFiles(nodeName)
+	if err != nil {
+		return err
+	}
+
+	// names is oldest first; the last entry is the newest and is never pruned.
+	prune := 0
+	if s.retention.MaxCount > 0 && len(names) > s.retention.MaxCount {
+		prune = len(names) - s.retention.MaxCount
+	}
+	if s.retention.MaxAge > 0 {
+		cutoff := s.now().Add(-s.retention.MaxAge)
+		for prune < len(names)-1 {
+			generatedAt, err := time.Parse(historyTimeLayout, strings.TrimSuffix(names[prune], ".json"))
+			if err != nil || !generatedAt.Before(cutoff) {
+				break
+			}
+			prune++
+		}
+	}
+
+	var errs []error
+	for _, name := range names[:prune] {
+		if err := os.Remove(filepath.Join(s.historyDir, nodeName, name)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("prune %s history %s: %w", nodeName, name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *FileStore) now() time.Time {
+	if s.clock == nil {
+		return time.Now()
+	}
+	return s.clock.Now()
+}
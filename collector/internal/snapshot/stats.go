@@ -0,0 +1,46 @@
+package snapshot
+
+import "time"
+
+// Stats tallies a snapshot for dashboards that need counts and freshness but not the graph.
+type Stats struct {
+	NodeName      string                  `json:"nodeName"`
+	ClusterID     string                  `json:"clusterID,omitempty"`
+	GeneratedAt   *time.Time              `json:"generatedAt,omitempty"`
+	SourceHealth  string                  `json:"sourceHealth"`
+	NodeCounts    map[string]int          `json:"nodeCounts"`
+	EdgeCounts    map[string]int          `json:"edgeCounts"`
+	WarningCounts map[WarningSeverity]int `json:"warningCounts"`
+}
+
+// SnapshotStats counts payload's nodes and edges by kind and its warnings by severity. Counts
+// are taken from the graph itself rather than Metadata.KindCounts, which older snapshots omit.
+// Warnings without a severity are counted under their code's default severity.
+func SnapshotStats(payload LogicalTopologySnapshot) Stats {
+	stats := Stats{
+		NodeName:      payload.Metadata.NodeName,
+		ClusterID:     payload.Metadata.ClusterID,
+		SourceHealth:  payload.Metadata.SourceHealth,
+		NodeCounts:    map[string]int{},
+		EdgeCounts:    map[string]int{},
+		WarningCounts: map[WarningSeverity]int{},
+	}
+	if !payload.Metadata.GeneratedAt.IsZero() {
+		generatedAt := payload.Metadata.GeneratedAt.UTC()
+		stats.GeneratedAt = &generatedAt
+	}
+	for _, node := range payload.Nodes {
+		stats.NodeCounts[node.Kind]++
+	}
+	for _, edge := range payload.Edges {
+		stats.EdgeCounts[edge.Kind]++
+	}
+	for _, warning := range payload.Warnings {
+		severity := warning.Severity
+		if severity == "" {
+			severity = warning.Code.DefaultSeverity()
+		}
+		stats.WarningCounts[severity]++
+	}
+	return stats
+}
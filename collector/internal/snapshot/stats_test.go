@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnapshotStatsCountsByKindAndSeverity(t *testing.T) {
+	generatedAt := time.Date(2026, 2, 14, 12, 0, 0, 0, time.FixedZone("EST", -5*60*60))
+	stats := SnapshotStats(LogicalTopologySnapshot{
+		Metadata: Metadata{NodeName: "worker-a", ClusterID: "east-prod", SourceHealth: "degraded", GeneratedAt: generatedAt},
+		Nodes: []Node{
+			{ID: "lr-1", Kind: "logical_router"},
+			{ID: "ls-1", Kind: "logical_switch"},
+			{ID: "ls-2", Kind: "logical_switch"},
+			{ID: "lsp-1", Kind: "logical_switch_port"},
+		},
+		Edges: []Edge{
+			{ID: "router_to_switch:lr-1:ls-1", Kind: "router_to_switch"},
+			{ID: "router_to_switch:lr-1:ls-2", Kind: "router_to_switch"},
+			{ID: "switch_to_port:ls-1:lsp-1", Kind: "switch_to_port"},
+		},
+		Warnings: []Warning{
+			{Code: WarningCommandFailed, Message: "NAT command failed"},
+			{Code: WarningParserNormalized, Message: "normalized"},
+			{Code: WarningLiveProbeFailed, Message: "probe failed", Severity: SeverityError},
+			NewWarning(WarningTopologyCycle, "cycle"),
+		},
+	})
+
+	if stats.NodeName != "worker-a" || stats.ClusterID != "east-prod" || stats.SourceHealth != "degraded" {
+		t.Fatalf("unexpected metadata: %#v", stats)
+	}
+	if stats.GeneratedAt == nil || !stats.GeneratedAt.Equal(generatedAt) || stats.GeneratedAt.Location() != time.UTC {
+		t.Fatalf("expected generatedAt %s in UTC, got %v", generatedAt, stats.GeneratedAt)
+	}
+	assertCounts(t, "node", stats.NodeCounts, map[string]int{"logical_router": 1, "logical_switch": 2, "logical_switch_port": 1})
+	assertCounts(t, "edge", stats.EdgeCounts, map[string]int{"router_to_switch": 2, "switch_to_port": 1})
+	assertCounts(t, "warning", stats.WarningCounts, map[WarningSeverity]int{SeverityError: 2, SeverityInfo: 1, SeverityWarning: 1})
+}
+
+func TestSnapshotStatsOfEmptySnapshot(t *testing.T) {
+	stats := SnapshotStats(LogicalTopologySnapshot{})
+	if stats.GeneratedAt != nil {
+		t.Fatalf("expected no generatedAt, got %v", stats.GeneratedAt)
+	}
+	if stats.NodeCounts == nil || stats.EdgeCounts == nil || stats.WarningCounts == nil {
+		t.Fatalf("expected empty, non-nil count maps: %#v", stats)
+	}
+}
+
+func assertCounts[K comparable](t *testing.T, name string, got, want map[K]int) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected %s counts %v, got %v", name, want, got)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Fatalf("expected %s counts %v, got %v", name, want, got)
+		}
+	}
+}
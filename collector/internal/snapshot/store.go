@@ -5,29 +5,93 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"net/netip"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
 )
 
 var ErrNotFound = errors.New("snapshot not found")
 
-// Store retrieves logical topology snapshots by node.
+// NodeMatch selects how FileStore resolves a requested node name to a snapshot file.
+type NodeMatch string
+
+const (
+	// NodeMatchExact only serves the file named exactly after the node.
+	NodeMatchExact NodeMatch = "exact"
+	// NodeMatchFuzzy also serves a file whose name is a short-hostname or FQDN form of the node,
+	// e.g. worker-a for worker-a.example.com and vice versa, when exactly one file matches.
+	NodeMatchFuzzy NodeMatch = "fuzzy"
+)
+
+// ParseNodeMatch parses a node match mode; an empty value selects NodeMatchExact.
+func ParseNodeMatch(value string) (NodeMatch, error) {
+	switch NodeMatch(strings.ToLower(strings.TrimSpace(value))) {
+	case "", NodeMatchExact:
+		return NodeMatchExact, nil
+	case NodeMatchFuzzy:
+		return NodeMatchFuzzy, nil
+	default:
+		return NodeMatchExact, fmt.Errorf("unknown node match mode %q (want exact or fuzzy)", value)
+	}
+}
+
+// AmbiguousNodeError reports a fuzzy node name that matched more than one snapshot file.
+type AmbiguousNodeError struct {
+	NodeName   string
+	Candidates []string
+}
+
+func (e *AmbiguousNodeError) Error() string {
+	return fmt.Sprintf("node %q matches multiple snapshots: %s", e.NodeName, strings.Join(e.Candidates, ", "))
+}
+
+// Store retrieves and persists logical topology snapshots by node.
 type Store interface {
 	GetByNode(ctx context.Context, nodeName string) (LogicalTopologySnapshot, error)
+	ListNodes(ctx context.Context) ([]NodeSummary, error)
+	// Put stores payload as the node's snapshot, replacing any earlier one.
+	Put(ctx context.Context, nodeName string, payload LogicalTopologySnapshot) error
+}
+
+// NodeSummary describes a node with an available snapshot.
+type NodeSummary struct {
+	NodeName    string     `json:"nodeName"`
+	GeneratedAt *time.Time `json:"generatedAt,omitempty"`
+	// Live is true when the node was discovered from running OVN pods.
+	Live bool `json:"live,omitempty"`
 }
 
 // FileStore reads snapshot payloads from JSON files on disk.
 type FileStore struct {
 	dir          string
 	fallbackFile string
+	// nodeOnly skips the fallback file on lookup while still excluding it from listings.
+	nodeOnly   bool
+	nodeMatch  NodeMatch
+	historyDir string
+	retention  Retention
+	clock      clock.Clock
 }
 
 // NewFileStore creates a file-backed snapshot store.
 func NewFileStore(dir, fallbackFile string) *FileStore {
-	return &FileStore{dir: dir, fallbackFile: fallbackFile}
+	return &FileStore{dir: dir, fallbackFile: fallbackFile, nodeMatch: NodeMatchExact}
+}
+
+// SetNodeMatch selects how requested node names are resolved to snapshot files.
+func (s *FileStore) SetNodeMatch(nodeMatch NodeMatch) {
+	s.nodeMatch = nodeMatch
 }
 
-// GetByNode loads a node-scoped snapshot, falling back to default payload when configured.
+// GetByNode loads a node-scoped snapshot, falling back to default payload when configured. A
+// nodeName that is an IP address also matches the node snapshot listing it in NodeAddresses.
 func (s *FileStore) GetByNode(_ context.Context, nodeName string) (LogicalTopologySnapshot, error) {
 	primary := filepath.Join(s.dir, fmt.Sprintf("%s.json", nodeName))
 	payload, err := loadSnapshot(primary)
@@ -42,7 +106,21 @@ func (s *FileStore) GetByNode(_ context.Context, nodeName string) (LogicalTopolo
 		return LogicalTopologySnapshot{}, err
 	}
 
-	if s.fallbackFile == "" {
+	if s.nodeMatch == NodeMatchFuzzy {
+		payload, found, err := s.getByFuzzyNode(nodeName)
+		if err != nil || found {
+			return payload, err
+		}
+	}
+
+	if addr, err := netip.ParseAddr(nodeName); err == nil {
+		payload, found, err := s.getByNodeAddress(nodeName, addr)
+		if err != nil || found {
+			return payload, err
+		}
+	}
+
+	if s.fallbackFile == "" || s.nodeOnly {
 		return LogicalTopologySnapshot{}, ErrNotFound
 	}
 
@@ -61,6 +139,215 @@ func (s *FileStore) GetByNode(_ context.Context, nodeName string) (LogicalTopolo
 	return payload, nil
 }
 
+// getByFuzzyNode loads the only snapshot file whose node name is a short-hostname or FQDN form
+// of nodeName. It reports false when no file matches.
+func (s *FileStore) getByFuzzyNode(nodeName string) (LogicalTopologySnapshot, bool, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return LogicalTopologySnapshot{}, false, nil
+		}
+		return LogicalTopologySnapshot{}, false, err
+	}
+
+	candidates := []string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || name == s.fallbackFile {
+			continue
+		}
+		if candidate := strings.TrimSuffix(name, ".json"); nodeNamesMatch(candidate, nodeName) {
+			candidates = append(candidates, candidate)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return LogicalTopologySnapshot{}, false, nil
+	case 1:
+	default:
+		return LogicalTopologySnapshot{}, false, &AmbiguousNodeError{NodeName: nodeName, Candidates: candidates}
+	}
+
+	payload, err := loadSnapshot(filepath.Join(s.dir, candidates[0]+".json"))
+	if err != nil {
+		return LogicalTopologySnapshot{}, false, err
+	}
+	if payload.Metadata.NodeName == "" {
+		payload.Metadata.NodeName = candidates[0]
+	}
+	return payload, true, nil
+}
+
+// getByNodeAddress loads the only node snapshot whose Metadata.NodeAddresses contains addr.
+// It reports false when no snapshot lists the address.
+func (s *FileStore) getByNodeAddress(requested string, addr netip.Addr) (LogicalTopologySnapshot, bool, error) {
+	index, err := s.nodeAddressIndex()
+	if err != nil {
+		return LogicalTopologySnapshot{}, false, err
+	}
+	candidates := index[addr.Unmap()]
+	switch len(candidates) {
+	case 0:
+		return LogicalTopologySnapshot{}, false, nil
+	case 1:
+	default:
+		return LogicalTopologySnapshot{}, false, &AmbiguousNodeError{NodeName: requested, Candidates: candidates}
+	}
+
+	payload, err := loadSnapshot(filepath.Join(s.dir, candidates[0]+".json"))
+	if err != nil {
+		return LogicalTopologySnapshot{}, false, err
+	}
+	if payload.Metadata.NodeName == "" {
+		payload.Metadata.NodeName = candidates[0]
+	}
+	return payload, true, nil
+}
+
+// nodeAddressIndex maps every address listed in a node snapshot's Metadata.NodeAddresses to the
+// names of the snapshot files listing it. Addresses are compared in canonical form, so
+// fd00::0001 and fd00::1 are the same address. The fallback file is never indexed.
+func (s *FileStore) nodeAddressIndex() (map[netip.Addr][]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return map[netip.Addr][]string{}, nil
+		}
+		return nil, err
+	}
+
+	index := map[netip.Addr][]string{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || name == s.fallbackFile {
+			continue
+		}
+		payload, err := loadSnapshot(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		node := strings.TrimSuffix(name, ".json")
+		for _, raw := range payload.Metadata.NodeAddresses {
+			addr, err := netip.ParseAddr(strings.TrimSpace(raw))
+			if err != nil {
+				continue
+			}
+			addr = addr.Unmap()
+			if !slices.Contains(index[addr], node) {
+				index[addr] = append(index[addr], node)
+			}
+		}
+	}
+	return index, nil
+}
+
+// nodeNamesMatch reports whether one name is the other with trailing DNS labels removed, so
+// prefixes only match on a label boundary: worker-a matches worker-a.example.com, not worker-ab.
+func nodeNamesMatch(a, b string) bool {
+	return strings.HasPrefix(a, b+".") || strings.HasPrefix(b, a+".")
+}
+
+// ListNodes returns a summary for each node-scoped snapshot file, excluding the fallback file.
+func (s *FileStore) ListNodes(_ context.Context) ([]NodeSummary, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []NodeSummary{}, nil
+		}
+		return nil, err
+	}
+
+	summaries := []NodeSummary{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".json" || name == s.fallbackFile {
+			continue
+		}
+		payload, err := loadSnapshot(filepath.Join(s.dir, name))
+		if err != nil {
+			return nil, err
+		}
+		summary := NodeSummary{NodeName: strings.TrimSuffix(name, ".json")}
+		if !payload.Metadata.GeneratedAt.IsZero() {
+			generatedAt := payload.Metadata.GeneratedAt.UTC()
+			summary.GeneratedAt = &generatedAt
+		}
+		summaries = append(summaries, summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].NodeName < summaries[j].NodeName
+	})
+	return summaries, nil
+}
+
+// Put writes payload to the node's snapshot file. The file is replaced atomically so concurrent
+// readers never see a partial snapshot. With a history directory set, payload is also kept as
+// a history entry and the node's history is pruned to the retention limits.
+func (s *FileStore) Put(_ context.Context, nodeName string, payload LogicalTopologySnapshot) error {
+	if err := validateNodeName(nodeName); err != nil {
+		return err
+	}
+	data, err := encodeSnapshot(nodeName, payload)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomic(s.dir, nodeName, nodeName+".json", data); err != nil {
+		return err
+	}
+	if s.historyDir == "" {
+		return nil
+	}
+	if err := s.putHistory(nodeName, payload.Metadata.GeneratedAt, data); err != nil {
+		return err
+	}
+	// The snapshot is already stored, so a pruning failure is only logged.
+	if err := s.pruneHistory(nodeName); err != nil {
+		slog.Warn("failed to prune snapshot history", "node", nodeName, "error", err)
+	}
+	return nil
+}
+
+// validateNodeName rejects node names that cannot be used as a file name in the store.
+func validateNodeName(nodeName string) error {
+	if nodeName == "" || strings.ContainsAny(nodeName, `/\`) || nodeName == "." || nodeName == ".." {
+		return fmt.Errorf("invalid node name %q", nodeName)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to dir/name through a temporary file so concurrent readers never
+// see a partial file. nodeName only prefixes the temporary file name.
+func writeFileAtomic(dir, nodeName, name string, data []byte) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+nodeName+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, name))
+}
+
+// encodeSnapshot renders payload as the indented JSON written to snapshot files.
+func encodeSnapshot(nodeName string, payload LogicalTopologySnapshot) ([]byte, error) {
+	data, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encode snapshot for node %s: %w", nodeName, err)
+	}
+	return append(data, '\n'), nil
+}
+
 func loadSnapshot(path string) (LogicalTopologySnapshot, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -6,7 +6,11 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/dlbewley/ovn-recon/collector/internal/clock"
 )
 
 func TestFileStoreReturnsNodeSnapshot(t *testing.T) {
@@ -50,6 +54,37 @@ func TestFileStoreReturnsNotFoundWhenNoFiles(t *testing.T) {
 	}
 }
 
+func TestFileStoreListNodesExcludesFallback(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-b.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", GeneratedAt: time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1"},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "default.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1"},
+	})
+	if err := os.WriteFile(filepath.Join(tmpDir, "README.md"), []byte("not a snapshot"), 0o600); err != nil {
+		t.Fatalf("write non-snapshot file: %v", err)
+	}
+
+	store := NewFileStore(tmpDir, "default.json")
+	summaries, err := store.ListNodes(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(summaries) != 2 {
+		t.Fatalf("expected two node summaries, got %#v", summaries)
+	}
+	if summaries[0].NodeName != "worker-a" || summaries[0].GeneratedAt != nil {
+		t.Fatalf("unexpected first summary: %#v", summaries[0])
+	}
+	if summaries[1].NodeName != "worker-b" || summaries[1].GeneratedAt == nil || !summaries[1].GeneratedAt.Equal(time.Date(2026, 2, 14, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected second summary: %#v", summaries[1])
+	}
+}
+
 func writeFixture(t *testing.T, path string, payload LogicalTopologySnapshot) {
 	t.Helper()
 	bytes, err := json.Marshal(payload)
@@ -60,3 +95,285 @@ func writeFixture(t *testing.T, path string, payload LogicalTopologySnapshot) {
 		t.Fatalf("write fixture: %v", err)
 	}
 }
+
+func TestLayeredFileStorePrefersEarlierLayersAndNodeFilesOverFallback(t *testing.T) {
+	goldenDir := t.TempDir()
+	generatedDir := t.TempDir()
+	writeFixture(t, filepath.Join(goldenDir, "worker-a.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "golden"},
+	})
+	writeFixture(t, filepath.Join(goldenDir, "default.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", SourceHealth: "fallback"},
+	})
+	writeFixture(t, filepath.Join(generatedDir, "worker-a.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "generated"},
+	})
+	writeFixture(t, filepath.Join(generatedDir, "worker-b.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", SourceHealth: "generated"},
+	})
+
+	store := NewLayeredFileStore([]string{goldenDir, generatedDir}, "default.json", FileStoreOptions{NodeMatch: NodeMatchExact})
+	tests := map[string]string{
+		"worker-a": "golden",
+		"worker-b": "generated",
+		"worker-c": "fallback",
+	}
+	for nodeName, wantSource := range tests {
+		payload, err := store.GetByNode(context.Background(), nodeName)
+		if err != nil {
+			t.Fatalf("GetByNode(%s) returned error: %v", nodeName, err)
+		}
+		if payload.Metadata.SourceHealth != wantSource {
+			t.Fatalf("GetByNode(%s): expected %s layer, got %q", nodeName, wantSource, payload.Metadata.SourceHealth)
+		}
+	}
+
+	summaries, err := store.ListNodes(context.Background())
+	if err != nil {
+		t.Fatalf("ListNodes returned error: %v", err)
+	}
+	if len(summaries) != 2 || summaries[0].NodeName != "worker-a" || summaries[1].NodeName != "worker-b" {
+		t.Fatalf("expected merged [worker-a worker-b], got %#v", summaries)
+	}
+}
+
+func TestLayeredStoreReturnsNotFoundWhenNoLayerMatches(t *testing.T) {
+	store := NewLayeredStore(NewFileStore(t.TempDir(), ""), NewFileStore(t.TempDir(), "default.json"))
+	if _, err := store.GetByNode(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNewLayeredFileStoreKeepsSingleDirectoryFileStore(t *testing.T) {
+	if _, ok := NewLayeredFileStore([]string{t.TempDir()}, "default.json", FileStoreOptions{NodeMatch: NodeMatchExact}).(*FileStore); !ok {
+		t.Fatalf("expected a single directory to produce a plain FileStore")
+	}
+}
+
+func TestFileStoreNodeMatch(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, name := range []string{"worker-a.example.com", "worker-b", "worker-c.east.example.com", "worker-c.west.example.com"} {
+		writeFixture(t, filepath.Join(tmpDir, name+".json"), LogicalTopologySnapshot{
+			Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: name},
+		})
+	}
+	writeFixture(t, filepath.Join(tmpDir, "default.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", SourceHealth: "fallback"},
+	})
+
+	exact := NewFileStore(tmpDir, "default.json")
+	payload, err := exact.GetByNode(context.Background(), "worker-a")
+	if err != nil {
+		t.Fatalf("expected exact mode to fall back, got %v", err)
+	}
+	if payload.Metadata.SourceHealth != "fallback" {
+		t.Fatalf("expected exact mode to ignore worker-a.example.com, got %#v", payload.Metadata)
+	}
+
+	fuzzy := NewFileStore(tmpDir, "default.json")
+	fuzzy.SetNodeMatch(NodeMatchFuzzy)
+	for requested, want := range map[string]string{
+		"worker-a":             "worker-a.example.com",
+		"worker-b.example.com": "worker-b",
+		"worker-b":             "worker-b",
+	} {
+		payload, err := fuzzy.GetByNode(context.Background(), requested)
+		if err != nil {
+			t.Fatalf("GetByNode(%s) returned error: %v", requested, err)
+		}
+		if payload.Metadata.NodeName != want {
+			t.Fatalf("GetByNode(%s): expected %s, got %q", requested, want, payload.Metadata.NodeName)
+		}
+	}
+
+	// A prefix only matches on a DNS label boundary.
+	payload, err = fuzzy.GetByNode(context.Background(), "worker")
+	if err != nil || payload.Metadata.SourceHealth != "fallback" {
+		t.Fatalf("expected worker to match no node and fall back, got %#v, %v", payload.Metadata, err)
+	}
+
+	_, err = fuzzy.GetByNode(context.Background(), "worker-c")
+	var ambiguous *AmbiguousNodeError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected AmbiguousNodeError, got %v", err)
+	}
+	if strings.Join(ambiguous.Candidates, ",") != "worker-c.east.example.com,worker-c.west.example.com" {
+		t.Fatalf("unexpected candidates: %v", ambiguous.Candidates)
+	}
+}
+
+func TestFileStoreGetByNodeMatchesNameOrAddress(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeFixture(t, filepath.Join(tmpDir, "worker-a.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", NodeAddresses: []string{"10.0.0.11", "fd00:10::0011"}},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-b.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-b", NodeAddresses: []string{"10.0.0.12", "fd00:10::12"}},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-c.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-c", NodeAddresses: []string{"10.0.0.99"}},
+	})
+	writeFixture(t, filepath.Join(tmpDir, "worker-d.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-d", NodeAddresses: []string{"10.0.0.99"}},
+	})
+
+	store := NewFileStore(tmpDir, "")
+	for requested, want := range map[string]string{
+		"worker-a":         "worker-a",
+		"worker-b":         "worker-b",
+		"10.0.0.11":        "worker-a",
+		"fd00:10::11":      "worker-a",
+		"::ffff:10.0.0.12": "worker-b",
+		"FD00:10::12":      "worker-b",
+	} {
+		payload, err := store.GetByNode(context.Background(), requested)
+		if err != nil {
+			t.Fatalf("GetByNode(%s) returned error: %v", requested, err)
+		}
+		if payload.Metadata.NodeName != want {
+			t.Fatalf("GetByNode(%s): expected %s, got %q", requested, want, payload.Metadata.NodeName)
+		}
+	}
+
+	if _, err := store.GetByNode(context.Background(), "10.0.0.13"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an unlisted address, got %v", err)
+	}
+
+	_, err := store.GetByNode(context.Background(), "10.0.0.99")
+	var ambiguous *AmbiguousNodeError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected AmbiguousNodeError for an address listed twice, got %v", err)
+	}
+	if strings.Join(ambiguous.Candidates, ",") != "worker-c,worker-d" {
+		t.Fatalf("unexpected candidates: %v", ambiguous.Candidates)
+	}
+}
+
+func TestStorePutWritesNodeSnapshot(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	writeFixture(t, filepath.Join(second, "worker-a.json"), LogicalTopologySnapshot{
+		Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "degraded"},
+	})
+	store := NewLayeredFileStore([]string{first, second}, "default.json", FileStoreOptions{NodeMatch: NodeMatchExact})
+
+	captured := LogicalTopologySnapshot{Metadata: Metadata{SchemaVersion: "v1alpha1", NodeName: "worker-a", SourceHealth: "healthy"}}
+	if err := store.Put(context.Background(), "worker-a", captured); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(first, "worker-a.json")); err != nil {
+		t.Fatalf("expected snapshot written to the first layer: %v", err)
+	}
+	payload, err := store.GetByNode(context.Background(), "worker-a")
+	if err != nil || payload.Metadata.SourceHealth != "healthy" {
+		t.Fatalf("expected stored snapshot to win lookup, got %#v (err=%v)", payload.Metadata, err)
+	}
+	entries, _ := os.ReadDir(first)
+	if len(entries) != 1 {
+		t.Fatalf("expected no temporary files left behind, got %d entries", len(entries))
+	}
+
+	if err := NewFileStore(first, "default.json").Put(context.Background(), "../escape", captured); err == nil {
+		t.Fatalf("expected node names with path separators to be rejected")
+	}
+}
+
+func TestFileStoreGetByNodeAtServesStoredVersion(t *testing.T) {
+	dir := t.TempDir()
+	store := NewFileStore(dir, "default.json")
+	if _, err := store.GetByNodeAt(context.Background(), "worker-a", time.Now()); !errors.Is(err, ErrNoHistory) {
+		t.Fatalf("expected ErrNoHistory without a history directory, got %v", err)
+	}
+
+	store.SetHistoryDir(filepath.Join(dir, "history"))
+	first := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	for i, health := range []string{"degraded", "healthy"} {
+		payload := LogicalTopologySnapshot{Metadata: Metadata{
+			SchemaVersion: "v1alpha1",
+			GeneratedAt:   first.Add(time.Duration(i) * time.Hour),
+			SourceHealth:  health,
+		}}
+		if err := store.Put(context.Background(), "worker-a", payload); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	tests := map[time.Time]string{
+		first:                       "degraded",
+		first.Add(59 * time.Minute): "degraded",
+		first.Add(time.Hour):        "healthy",
+		first.Add(24 * time.Hour):   "healthy",
+	}
+	for at, wantHealth := range tests {
+		payload, err := store.GetByNodeAt(context.Background(), "worker-a", at)
+		if err != nil || payload.Metadata.SourceHealth != wantHealth {
+			t.Fatalf("GetByNodeAt(%s) = %q, %v; want %q", at, payload.Metadata.SourceHealth, err, wantHealth)
+		}
+	}
+	if _, err := store.GetByNodeAt(context.Background(), "worker-a", first.Add(-time.Second)); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound before the first version, got %v", err)
+	}
+	if summaries, err := store.ListNodes(context.Background()); err != nil || len(summaries) != 1 {
+		t.Fatalf("expected history files to stay out of listings, got %#v (err=%v)", summaries, err)
+	}
+}
+
+func TestFileStorePrunesHistoryBeyondRetention(t *testing.T) {
+	start := time.Date(2026, 2, 16, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		retention Retention
+		wantKept  int
+	}{
+		{name: "unlimited", retention: Retention{}, wantKept: 5},
+		{name: "max count", retention: Retention{MaxCount: 2}, wantKept: 2},
+		{name: "max age", retention: Retention{MaxAge: 210 * time.Minute}, wantKept: 3},
+		{name: "both", retention: Retention{MaxCount: 2, MaxAge: 210 * time.Minute}, wantKept: 2},
+		{name: "newest always kept", retention: Retention{MaxAge: time.Minute}, wantKept: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			historyDir := filepath.Join(dir, "history")
+			store := NewFileStore(dir, "default.json")
+			store.SetHistoryDir(historyDir)
+			store.SetRetention(tc.retention)
+			// The last snapshot is generated an hour before now, so it is still older than MaxAge
+			// in the "newest always kept" case.
+			store.SetClock(clock.NewFake(start.Add(5 * time.Hour)))
+			for i := 0; i < 5; i++ {
+				payload := LogicalTopologySnapshot{Metadata: Metadata{SchemaVersion: "v1alpha1", GeneratedAt: start.Add(time.Duration(i) * time.Hour)}}
+				if err := store.Put(context.Background(), "worker-a", payload); err != nil {
+					t.Fatalf("Put failed: %v", err)
+				}
+			}
+
+			entries, err := os.ReadDir(filepath.Join(historyDir, "worker-a"))
+			if err != nil {
+				t.Fatalf("read history: %v", err)
+			}
+			if len(entries) != tc.wantKept {
+				t.Fatalf("expected %d history entries, got %d", tc.wantKept, len(entries))
+			}
+			newest, err := store.GetByNodeAt(context.Background(), "worker-a", start.Add(5*time.Hour))
+			if err != nil || !newest.Metadata.GeneratedAt.Equal(start.Add(4*time.Hour)) {
+				t.Fatalf("expected the newest entry to survive pruning, got %s (err=%v)", newest.Metadata.GeneratedAt, err)
+			}
+			oldestKept := start.Add(time.Duration(5-tc.wantKept) * time.Hour)
+			if _, err := store.GetByNodeAt(context.Background(), "worker-a", oldestKept.Add(-time.Second)); !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected entries before %s to be pruned, got %v", oldestKept, err)
+			}
+		})
+	}
+}
+
+func TestParseNodeMatch(t *testing.T) {
+	for value, want := range map[string]NodeMatch{"": NodeMatchExact, "exact": NodeMatchExact, " Fuzzy ": NodeMatchFuzzy} {
+		got, err := ParseNodeMatch(value)
+		if err != nil || got != want {
+			t.Fatalf("ParseNodeMatch(%q) = %q, %v; want %q", value, got, err, want)
+		}
+	}
+	if _, err := ParseNodeMatch("loose"); err == nil {
+		t.Fatalf("expected an error for an unknown mode")
+	}
+}
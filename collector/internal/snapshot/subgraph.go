@@ -0,0 +1,83 @@
+package snapshot
+
+// MaxSubgraphDepth bounds the hop count accepted by Subgraph.
+const MaxSubgraphDepth = 16
+
+// Subgraph returns the part of payload within depth hops of the node rootID, following edges in
+// either direction. Edges are kept when both endpoints are kept, and groups are trimmed to the
+// kept nodes. Metadata and warnings are carried over, with kind tallies recomputed for the
+// smaller graph. The boolean is false when rootID is not a node in payload.
+func Subgraph(payload LogicalTopologySnapshot, rootID string, depth int) (LogicalTopologySnapshot, bool) {
+	found := false
+	for _, node := range payload.Nodes {
+		if node.ID == rootID {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return LogicalTopologySnapshot{}, false
+	}
+
+	neighbors := map[string][]string{}
+	for _, edge := range payload.Edges {
+		neighbors[edge.Source] = append(neighbors[edge.Source], edge.Target)
+		neighbors[edge.Target] = append(neighbors[edge.Target], edge.Source)
+	}
+
+	kept := map[string]bool{rootID: true}
+	frontier := []string{rootID}
+	for hop := 0; hop < depth && len(frontier) > 0; hop++ {
+		var next []string
+		for _, id := range frontier {
+			for _, neighbor := range neighbors[id] {
+				if !kept[neighbor] {
+					kept[neighbor] = true
+					next = append(next, neighbor)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	result := LogicalTopologySnapshot{
+		Metadata: payload.Metadata,
+		Nodes:    []Node{},
+		Edges:    []Edge{},
+		Groups:   []Group{},
+		Warnings: payload.Warnings,
+	}
+	kindCounts := map[string]int{}
+	for _, node := range payload.Nodes {
+		if kept[node.ID] {
+			result.Nodes = append(result.Nodes, node)
+			kindCounts[node.Kind]++
+		}
+	}
+	edgeKindCounts := map[string]int{}
+	for _, edge := range payload.Edges {
+		if kept[edge.Source] && kept[edge.Target] {
+			result.Edges = append(result.Edges, edge)
+			edgeKindCounts[edge.Kind]++
+		}
+	}
+	for _, group := range payload.Groups {
+		nodeIDs := []string{}
+		for _, id := range group.NodeIDs {
+			if kept[id] {
+				nodeIDs = append(nodeIDs, id)
+			}
+		}
+		if len(nodeIDs) > 0 {
+			group.NodeIDs = nodeIDs
+			result.Groups = append(result.Groups, group)
+		}
+	}
+	if payload.Metadata.KindCounts != nil {
+		result.Metadata.KindCounts = kindCounts
+	}
+	if payload.Metadata.EdgeKindCounts != nil {
+		result.Metadata.EdgeKindCounts = edgeKindCounts
+	}
+	return result, true
+}
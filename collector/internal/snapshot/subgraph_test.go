@@ -0,0 +1,81 @@
+package snapshot
+
+import (
+	"sort"
+	"strings"
+	"testing"
+)
+
+func subgraphFixture() LogicalTopologySnapshot {
+	return LogicalTopologySnapshot{
+		Metadata: Metadata{
+			NodeName:       "worker-a",
+			KindCounts:     map[string]int{"logical_router": 1, "logical_switch": 2, "logical_switch_port": 2, "nat": 1},
+			EdgeKindCounts: map[string]int{"router_to_switch": 2, "router_to_nat": 1, "switch_to_port": 2},
+		},
+		Nodes: []Node{
+			{ID: "lr-1", Kind: "logical_router"},
+			{ID: "ls-1", Kind: "logical_switch"},
+			{ID: "ls-2", Kind: "logical_switch"},
+			{ID: "lsp-1", Kind: "logical_switch_port"},
+			{ID: "lsp-2", Kind: "logical_switch_port"},
+			{ID: "nat-1", Kind: "nat"},
+		},
+		Edges: []Edge{
+			{ID: "router_to_switch:lr-1:ls-1", Source: "lr-1", Target: "ls-1", Kind: "router_to_switch"},
+			{ID: "router_to_switch:lr-1:ls-2", Source: "lr-1", Target: "ls-2", Kind: "router_to_switch"},
+			{ID: "router_to_nat:lr-1:nat-1", Source: "lr-1", Target: "nat-1", Kind: "router_to_nat"},
+			{ID: "switch_to_port:ls-1:lsp-1", Source: "ls-1", Target: "lsp-1", Kind: "switch_to_port"},
+			{ID: "switch_to_port:ls-2:lsp-2", Source: "ls-2", Target: "lsp-2", Kind: "switch_to_port"},
+		},
+		Groups: []Group{{ID: "ports", Label: "Ports", NodeIDs: []string{"lsp-1", "lsp-2"}}},
+	}
+}
+
+func nodeIDs(nodes []Node) string {
+	ids := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		ids = append(ids, node.ID)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func TestSubgraphDepthOneFromRouterKeepsDirectNeighbors(t *testing.T) {
+	result, ok := Subgraph(subgraphFixture(), "lr-1", 1)
+	if !ok {
+		t.Fatalf("expected root lr-1 to be found")
+	}
+	if got := nodeIDs(result.Nodes); got != "lr-1,ls-1,ls-2,nat-1" {
+		t.Fatalf("expected router and its directly connected nodes, got %s", got)
+	}
+	if len(result.Edges) != 3 {
+		t.Fatalf("expected only the router's three edges, got %#v", result.Edges)
+	}
+	if len(result.Groups) != 0 {
+		t.Fatalf("expected port group without kept nodes to be dropped, got %#v", result.Groups)
+	}
+	if result.Metadata.KindCounts["logical_switch_port"] != 0 || result.Metadata.KindCounts["logical_switch"] != 2 {
+		t.Fatalf("expected kind counts recomputed for the subgraph, got %#v", result.Metadata.KindCounts)
+	}
+}
+
+func TestSubgraphFollowsEdgesInBothDirections(t *testing.T) {
+	result, ok := Subgraph(subgraphFixture(), "lsp-1", 2)
+	if !ok {
+		t.Fatalf("expected root lsp-1 to be found")
+	}
+	if got := nodeIDs(result.Nodes); got != "lr-1,ls-1,lsp-1" {
+		t.Fatalf("expected path back to the router, got %s", got)
+	}
+	if len(result.Groups) != 1 || strings.Join(result.Groups[0].NodeIDs, ",") != "lsp-1" {
+		t.Fatalf("expected port group trimmed to lsp-1, got %#v", result.Groups)
+	}
+
+	if result, _ := Subgraph(subgraphFixture(), "lsp-1", 0); nodeIDs(result.Nodes) != "lsp-1" || len(result.Edges) != 0 {
+		t.Fatalf("expected depth 0 to return only the root, got %#v", result)
+	}
+	if _, ok := Subgraph(subgraphFixture(), "missing", 1); ok {
+		t.Fatalf("expected unknown root to be reported")
+	}
+}
@@ -2,18 +2,93 @@ package snapshot
 
 import "time"
 
+// SchemaVersion is the snapshot payload version written to Metadata.SchemaVersion and
+// published in the JSON schema served at /api/v1/schema.
+const SchemaVersion = "v1alpha1"
+
 // Metadata captures collection metadata returned with each snapshot.
 type Metadata struct {
 	SchemaVersion string    `json:"schemaVersion"`
 	GeneratedAt   time.Time `json:"generatedAt"`
 	SourceHealth  string    `json:"sourceHealth"`
 	NodeName      string    `json:"nodeName"`
+	// NodeAddresses lists the node's IPs, IPv4 and IPv6 on dual-stack nodes, so the snapshot can
+	// also be looked up by address.
+	NodeAddresses []string `json:"nodeAddresses,omitempty"`
+	// ClusterID identifies the source cluster when snapshots from several clusters are aggregated.
+	ClusterID string `json:"clusterID,omitempty"`
+	// KindCounts tallies nodes by kind.
+	KindCounts map[string]int `json:"kindCounts,omitempty"`
+	// EdgeKindCounts tallies edges by kind.
+	EdgeKindCounts map[string]int `json:"edgeKindCounts,omitempty"`
+}
+
+// WarningCode identifies the kind of a snapshot warning. It is serialized as a plain string.
+type WarningCode string
+
+// Warning codes produced by the collector.
+const (
+	// WarningCommandFailed reports an OVN probe command that could not be run.
+	WarningCommandFailed WarningCode = "COMMAND_FAILED"
+	// WarningParserFailed reports probe output that could not be parsed.
+	WarningParserFailed WarningCode = "PARSER_FAILED"
+	// WarningParserNormalized reports probe output that parsed only after normalization.
+	WarningParserNormalized WarningCode = "PARSER_NORMALIZED"
+	// WarningLiveProbeFailed reports a failed live collection answered from a file snapshot.
+	WarningLiveProbeFailed WarningCode = "LIVE_PROBE_FAILED"
+	// WarningTopologyCycle reports a loop among routers and switches.
+	WarningTopologyCycle WarningCode = "TOPOLOGY_CYCLE"
+	// WarningSnapshotDefault reports that the default fallback snapshot was served.
+	WarningSnapshotDefault WarningCode = "SNAPSHOT_DEFAULT"
+	// WarningDuplicateUUID reports an OVN table that returned the same _uuid more than once.
+	WarningDuplicateUUID WarningCode = "DUPLICATE_UUID"
+	// WarningSnapshotTruncated reports a snapshot trimmed to the configured node or edge limit.
+	WarningSnapshotTruncated WarningCode = "SNAPSHOT_TRUNCATED"
+)
+
+// WarningSeverity ranks a warning so clients can style it.
+type WarningSeverity string
+
+// Warning severities.
+const (
+	SeverityInfo    WarningSeverity = "info"
+	SeverityWarning WarningSeverity = "warning"
+	SeverityError   WarningSeverity = "error"
+)
+
+// DefaultSeverity returns the severity used for code when a producer does not choose one.
+// Unknown codes are treated as warnings.
+func (c WarningCode) DefaultSeverity() WarningSeverity {
+	switch c {
+	case WarningParserNormalized, WarningSnapshotDefault:
+		return SeverityInfo
+	case WarningCommandFailed, WarningParserFailed:
+		return SeverityError
+	default:
+		return SeverityWarning
+	}
 }
 
 // Warning provides structured warnings for degraded collection states.
+// Severity is omitted by older producers; clients should treat it as optional.
 type Warning struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code     WarningCode     `json:"code"`
+	Message  string          `json:"message"`
+	Severity WarningSeverity `json:"severity,omitempty"`
+}
+
+// NewWarning returns a warning with the default severity for code.
+func NewWarning(code WarningCode, message string) Warning {
+	return Warning{Code: code, Message: message, Severity: code.DefaultSeverity()}
+}
+
+// ResourceStatus records the most recent live probe outcomes for one OVN table, so repeated
+// failures between successes are visible without reading every snapshot's warnings.
+type ResourceStatus struct {
+	Resource      string     `json:"resource"`
+	LastSuccessAt *time.Time `json:"lastSuccessAt,omitempty"`
+	LastFailureAt *time.Time `json:"lastFailureAt,omitempty"`
+	LastFailure   string     `json:"lastFailure,omitempty"`
 }
 
 // Node is a graph node in a logical topology snapshot.
@@ -47,4 +122,6 @@ type LogicalTopologySnapshot struct {
 	Edges    []Edge    `json:"edges"`
 	Groups   []Group   `json:"groups"`
 	Warnings []Warning `json:"warnings"`
+	// ChangedSince is only set when the snapshot was requested with ?since=.
+	ChangedSince *ChangeSummary `json:"changedSince,omitempty"`
 }
@@ -0,0 +1,62 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWarningCodeDefaultSeverity(t *testing.T) {
+	cases := map[WarningCode]WarningSeverity{
+		WarningCommandFailed:    SeverityError,
+		WarningParserFailed:     SeverityError,
+		WarningParserNormalized: SeverityInfo,
+		WarningSnapshotDefault:  SeverityInfo,
+		WarningLiveProbeFailed:  SeverityWarning,
+		WarningTopologyCycle:    SeverityWarning,
+		WarningDuplicateUUID:    SeverityWarning,
+		WarningCode("UNKNOWN"):  SeverityWarning,
+	}
+	for code, want := range cases {
+		if got := code.DefaultSeverity(); got != want {
+			t.Fatalf("expected %s to default to %q, got %q", code, want, got)
+		}
+	}
+}
+
+func TestWarningJSONIsBackwardCompatible(t *testing.T) {
+	var legacy Warning
+	if err := json.Unmarshal([]byte(`{"code":"PARSER_FAILED","message":"bad"}`), &legacy); err != nil {
+		t.Fatalf("failed to decode legacy warning: %v", err)
+	}
+	if legacy.Code != WarningParserFailed || legacy.Severity != "" {
+		t.Fatalf("unexpected legacy warning: %#v", legacy)
+	}
+
+	encoded, err := json.Marshal(NewWarning(WarningParserNormalized, "normalized"))
+	if err != nil {
+		t.Fatalf("failed to encode warning: %v", err)
+	}
+	if string(encoded) != `{"code":"PARSER_NORMALIZED","message":"normalized","severity":"info"}` {
+		t.Fatalf("unexpected warning encoding: %s", encoded)
+	}
+}
+
+func TestToSchemaVersion(t *testing.T) {
+	payload := LogicalTopologySnapshot{
+		Metadata: Metadata{NodeName: "worker-a"},
+		Nodes:    []Node{{ID: "lr-1", Kind: "logical_router"}},
+	}
+
+	converted, err := ToSchemaVersion(payload, SchemaVersion)
+	if err != nil {
+		t.Fatalf("ToSchemaVersion(%s) returned error: %v", SchemaVersion, err)
+	}
+	if converted.Metadata.SchemaVersion != SchemaVersion || len(converted.Nodes) != 1 {
+		t.Fatalf("expected the identity transform stamped with %s, got %#v", SchemaVersion, converted)
+	}
+
+	if _, err := ToSchemaVersion(payload, "v1"); !errors.Is(err, ErrUnsupportedSchemaVersion) {
+		t.Fatalf("expected ErrUnsupportedSchemaVersion for v1, got %v", err)
+	}
+}
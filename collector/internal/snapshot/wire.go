@@ -0,0 +1,41 @@
+package snapshot
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupportedSchemaVersion reports a wire schema version the collector cannot produce.
+var ErrUnsupportedSchemaVersion = errors.New("unsupported snapshot schema version")
+
+// wireTransforms down-convert a snapshot built in the current SchemaVersion to each wire schema
+// version clients may request. When SchemaVersion changes, keep an entry for every older version
+// still served so existing clients can pin it.
+var wireTransforms = map[string]func(LogicalTopologySnapshot) LogicalTopologySnapshot{
+	SchemaVersion: func(payload LogicalTopologySnapshot) LogicalTopologySnapshot { return payload },
+}
+
+// SupportedSchemaVersions returns the wire schema versions ToSchemaVersion can produce, sorted.
+func SupportedSchemaVersions() []string {
+	versions := make([]string, 0, len(wireTransforms))
+	for version := range wireTransforms {
+		versions = append(versions, version)
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// ToSchemaVersion converts payload to the given wire schema version and stamps it into
+// Metadata.SchemaVersion. It returns ErrUnsupportedSchemaVersion for an unknown version.
+func ToSchemaVersion(payload LogicalTopologySnapshot, version string) (LogicalTopologySnapshot, error) {
+	transform, ok := wireTransforms[version]
+	if !ok {
+		return LogicalTopologySnapshot{}, fmt.Errorf("%w %q (supported: %s)",
+			ErrUnsupportedSchemaVersion, version, strings.Join(SupportedSchemaVersions(), ", "))
+	}
+	payload = transform(payload)
+	payload.Metadata.SchemaVersion = version
+	return payload, nil
+}
@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
@@ -31,6 +33,23 @@ type OvnReconSpec struct {
 	// +kubebuilder:default=ovn-recon
 	TargetNamespace string `json:"targetNamespace,omitempty"`
 
+	// CreateTargetNamespace creates the target namespace when it does not exist instead of
+	// waiting for it. A namespace created this way is deleted with the OvnRecon; a
+	// pre-existing namespace is never deleted.
+	// +kubebuilder:default=false
+	CreateTargetNamespace bool `json:"createTargetNamespace,omitempty"`
+
+	// ClusterID identifies this cluster in collector snapshot metadata so snapshots from
+	// multiple clusters can be aggregated in one UI.
+	// +kubebuilder:validation:MaxLength=253
+	ClusterID string `json:"clusterID,omitempty"`
+
+	// PullSecretName names a Secret in the target namespace used to pull both the console plugin
+	// and collector images. It is added to both pod specs and to the collector ServiceAccount.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	PullSecretName string `json:"pullSecretName,omitempty"`
+
 	// Operator configuration.
 	Operator OperatorSpec `json:"operator,omitempty"`
 
@@ -40,6 +59,9 @@ type OvnReconSpec struct {
 	// Collector configuration.
 	Collector CollectorSpec `json:"collector,omitempty"`
 
+	// NetworkPolicy configuration for clusters that enforce default-deny NetworkPolicies.
+	NetworkPolicy NetworkPolicySpec `json:"networkPolicy,omitempty"`
+
 	// Deprecated: use consolePlugin.image instead.
 	// Image configuration for the plugin container.
 	Image ImageSpec `json:"image,omitempty"`
@@ -80,8 +102,37 @@ type LegacyCollectorImageSpec struct {
 type OperatorSpec struct {
 	// Logging controls for the operator controller.
 	Logging OperatorLoggingSpec `json:"logging,omitempty"`
+
+	// FinalizerName overrides the finalizer placed on this OvnRecon so that distinct
+	// operator instances managing overlapping resources do not remove each other's finalizers.
+	// Defaults to "ovnrecon.bewley.net/finalizer" when omitted. Changing the value on an
+	// existing resource replaces the previously applied finalizer on the next reconcile.
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?/[A-Za-z0-9]([-A-Za-z0-9_.]*[A-Za-z0-9])?$`
+	FinalizerName string `json:"finalizerName,omitempty"`
+
+	// DisabledSteps lists reconcile steps to skip, for staged rollouts and for isolating a
+	// step that causes churn. Skipped steps leave their resources and conditions untouched.
+	// +listType=set
+	// +kubebuilder:validation:items:Enum=deployment;service;collectorService;collectorRBAC;collectorDeployment;collectorHealth;networkPolicy;consolePlugin;consoleOperator
+	DisabledSteps []string `json:"disabledSteps,omitempty"`
+
+	// VersionLabelPolicy controls the app.kubernetes.io/version label when an image tag is not a
+	// valid label value, such as a digest. Sanitize replaces invalid characters with '-'. Omit
+	// leaves the label off rather than publishing a mangled version.
+	// +kubebuilder:validation:Enum=Sanitize;Omit
+	// +kubebuilder:default=Sanitize
+	// +optional
+	VersionLabelPolicy string `json:"versionLabelPolicy,omitempty"`
 }
 
+const (
+	// VersionLabelPolicySanitize replaces invalid characters in the version label with '-'.
+	VersionLabelPolicySanitize = "Sanitize"
+	// VersionLabelPolicyOmit drops the version label when the tag is not a valid label value.
+	VersionLabelPolicyOmit = "Omit"
+)
+
 type OperatorLoggingSpec struct {
 	// +kubebuilder:validation:Enum=error;warn;info;debug;trace
 	// +kubebuilder:default=info
@@ -109,6 +160,81 @@ type ConsolePluginSpec struct {
 
 	// Logging controls for the console plugin backend.
 	Logging ConsolePluginLoggingSpec `json:"logging,omitempty"`
+
+	// Scheduling constraints for the console plugin pods.
+	Scheduling SchedulingSpec `json:"scheduling,omitempty"`
+
+	// ReconcileStrategy controls how the operator maintains the ConsolePlugin resource.
+	// Managed keeps it in sync with the desired state. CreateOnly creates it when absent but
+	// never overwrites later edits, for ConsolePlugins managed by GitOps tooling.
+	// +kubebuilder:validation:Enum=Managed;CreateOnly
+	// +kubebuilder:default=Managed
+	// +optional
+	ReconcileStrategy string `json:"reconcileStrategy,omitempty"`
+
+	// PodDisruptionBudget controls the PodDisruptionBudget for the console plugin pods.
+	// +optional
+	PodDisruptionBudget PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Service controls how the console plugin Service is exposed.
+	// +optional
+	Service ConsolePluginServiceSpec `json:"service,omitempty"`
+
+	// Env adds environment variables to the plugin container, such as proxy settings.
+	// Variables the operator sets keep the operator's value on a name collision.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+}
+
+// ConsolePluginServiceSpec configures the Service in front of the console plugin pods. The
+// ConsolePlugin backend always points at the same port.
+type ConsolePluginServiceSpec struct {
+	// Type is the Service type. Defaults to ClusterIP.
+	// +kubebuilder:validation:Enum=ClusterIP;NodePort;LoadBalancer
+	// +optional
+	Type corev1.ServiceType `json:"type,omitempty"`
+
+	// Port is the Service port. Defaults to 9443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+const (
+	// ReconcileStrategyManaged keeps the ConsolePlugin in sync with the desired state.
+	ReconcileStrategyManaged = "Managed"
+	// ReconcileStrategyCreateOnly creates the ConsolePlugin once and leaves it alone afterwards.
+	ReconcileStrategyCreateOnly = "CreateOnly"
+)
+
+// PodDisruptionBudgetSpec configures an operator-managed policy/v1 PodDisruptionBudget.
+type PodDisruptionBudgetSpec struct {
+	// Enabled reconciles a PodDisruptionBudget that selects the workload pods. A single-replica
+	// workload with minAvailable 1 blocks voluntary evictions such as node drains.
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+
+	// MinAvailable is the number or percentage of pods that must stay available during a
+	// voluntary disruption. Defaults to 1.
+	// +kubebuilder:validation:XIntOrString
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty"`
+}
+
+// SchedulingSpec holds pod scheduling constraints copied verbatim into a pod template.
+type SchedulingSpec struct {
+	// NodeSelector restricts pods to nodes with matching labels.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations allow pods to schedule onto nodes with matching taints.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity sets node and pod affinity rules for pods.
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
 }
 
 type ConsolePluginLoggingSpec struct {
@@ -135,8 +261,78 @@ type CollectorSpec struct {
 	// +kubebuilder:default:={"openshift-ovn-kubernetes","openshift-frr-k8s"}
 	ProbeNamespaces []string `json:"probeNamespaces,omitempty"`
 
+	// RBACNamespaces defines namespaces where the collector is granted exec access. It may be a
+	// superset of ProbeNamespaces so access can be granted ahead of probing, and must include
+	// every probe namespace. Defaults to ProbeNamespaces.
+	// +optional
+	RBACNamespaces []string `json:"rbacNamespaces,omitempty"`
+
 	// Logging controls for the collector service.
 	Logging CollectorLoggingSpec `json:"logging,omitempty"`
+
+	// HealthCheck controls how the operator calls the collector health endpoint.
+	HealthCheck CollectorHealthCheckSpec `json:"healthCheck,omitempty"`
+
+	// Replicas is the number of collector pods behind the collector Service. Defaults to 1.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// PodDisruptionBudget controls the PodDisruptionBudget for the collector pods.
+	// +optional
+	PodDisruptionBudget PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+
+	// Resources overrides the collector container resource requests and limits. Requests and
+	// limits that are left empty keep the built-in defaults.
+	// +optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// NbctlArgs are inserted right after ovn-nbctl in every OVN NB probe command, for
+	// deployments that expose the NB database on a unix socket or need --db flags.
+	// Arguments must not contain whitespace.
+	// +optional
+	NbctlArgs []string `json:"nbctlArgs,omitempty"`
+
+	// Command overrides the collector container entrypoint, for patched images or wrapper
+	// scripts used while debugging. Unset runs the image's entrypoint.
+	// +optional
+	Command []string `json:"command,omitempty"`
+
+	// Env adds environment variables to the collector container, such as proxy settings or
+	// feature flags. Variables the operator sets keep the operator's value on a name collision.
+	// +optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Args overrides the collector container arguments, for example debug flags understood by a
+	// custom image. Unset passes the image's default arguments.
+	// +optional
+	Args []string `json:"args,omitempty"`
+
+	// PodSelector restricts probing to pods in the probe namespaces whose labels match every
+	// entry, for shared namespaces that also run unrelated pods. Unset probes every running pod.
+	// +optional
+	PodSelector map[string]string `json:"podSelector,omitempty"`
+
+	// InjectTrustedCABundle creates a ConfigMap that OpenShift fills with the cluster-wide trusted
+	// CA bundle and mounts it as the collector's system CA store, for TLS to endpoints signed by
+	// a custom or proxy CA.
+	// +kubebuilder:default=false
+	// +optional
+	InjectTrustedCABundle bool `json:"injectTrustedCABundle,omitempty"`
+
+	// Scheduling constraints for the collector pods.
+	Scheduling SchedulingSpec `json:"scheduling,omitempty"`
+}
+
+type CollectorHealthCheckSpec struct {
+	// Scheme used when calling the collector health endpoint.
+	// +kubebuilder:validation:Enum=http;https
+	// +kubebuilder:default=http
+	Scheme string `json:"scheme,omitempty"`
+
+	// CABundle references a ConfigMap key in the target namespace containing PEM CA
+	// certificates trusted for https health checks. Defaults to the in-cluster service CA.
+	CABundle *corev1.ConfigMapKeySelector `json:"caBundle,omitempty"`
 }
 
 type CollectorLoggingSpec struct {
@@ -148,6 +344,13 @@ type CollectorLoggingSpec struct {
 	IncludeProbeOutput bool `json:"includeProbeOutput,omitempty"`
 }
 
+type NetworkPolicySpec struct {
+	// Enabled reconciles NetworkPolicies that allow the traffic OVN Recon needs under a
+	// default-deny policy.
+	// +kubebuilder:default=false
+	Enabled bool `json:"enabled,omitempty"`
+}
+
 type FeatureGateSpec struct {
 	// OVNCollector enables logical topology features backed by the collector service.
 	// +kubebuilder:default=false
@@ -158,12 +361,41 @@ type FeatureGateSpec struct {
 type OvnReconStatus struct {
 	// Conditions represent the latest available observations of an object's state
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// Role reports whether this instance is the primary OvnRecon that the operator reconciles,
+	// or a secondary instance that is ignored while another one targeting the same namespace is active.
+	// +kubebuilder:validation:Enum=Primary;Secondary
+	// +optional
+	Role string `json:"role,omitempty"`
+
+	// CollectorEndpoint is the in-cluster URL of the collector Service, e.g.
+	// http://ovn-recon-collector.ovn-recon.svc:8090. It is empty while the collector is disabled.
+	// +optional
+	CollectorEndpoint string `json:"collectorEndpoint,omitempty"`
+
+	// ObservedNodeCount is the number of nodes the collector last reported it can probe live, read
+	// from its /api/v1/nodes endpoint once the collector is healthy. It is unset while the collector
+	// is disabled, not yet healthy, or could not be queried; zero means live probing is not working.
+	// +optional
+	ObservedNodeCount *int32 `json:"observedNodeCount,omitempty"`
 }
 
+const (
+	// RolePrimary marks the OvnRecon instance the operator reconciles.
+	RolePrimary = "Primary"
+	// RoleSecondary marks an OvnRecon instance skipped because another instance with the same
+	// target namespace is primary.
+	RoleSecondary = "Secondary"
+)
+
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Role",type=string,JSONPath=".status.role"
+// +kubebuilder:printcolumn:name="Available",type=string,JSONPath=".status.conditions[?(@.type==\"Available\")].status"
+// +kubebuilder:printcolumn:name="Collector",type=string,JSONPath=".status.collectorEndpoint"
+// +kubebuilder:printcolumn:name="Age",type=date,JSONPath=".metadata.creationTimestamp"
 
 // OvnRecon is the Schema for the ovnrecons API.
 type OvnRecon struct {
@@ -21,8 +21,10 @@ limitations under the License.
 package v1beta1
 
 import (
-	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -40,6 +42,26 @@ func (in *AccessLogSpec) DeepCopy() *AccessLogSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectorHealthCheckSpec) DeepCopyInto(out *CollectorHealthCheckSpec) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorHealthCheckSpec.
+func (in *CollectorHealthCheckSpec) DeepCopy() *CollectorHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectorHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CollectorImageSpec) DeepCopyInto(out *CollectorImageSpec) {
 	*out = *in
@@ -84,7 +106,50 @@ func (in *CollectorSpec) DeepCopyInto(out *CollectorSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.RBACNamespaces != nil {
+		in, out := &in.RBACNamespaces, &out.RBACNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	out.Logging = in.Logging
+	in.HealthCheck.DeepCopyInto(&out.HealthCheck)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NbctlArgs != nil {
+		in, out := &in.NbctlArgs, &out.NbctlArgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectorSpec.
@@ -113,11 +178,36 @@ func (in *ConsolePluginLoggingSpec) DeepCopy() *ConsolePluginLoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConsolePluginServiceSpec) DeepCopyInto(out *ConsolePluginServiceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsolePluginServiceSpec.
+func (in *ConsolePluginServiceSpec) DeepCopy() *ConsolePluginServiceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConsolePluginServiceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ConsolePluginSpec) DeepCopyInto(out *ConsolePluginSpec) {
 	*out = *in
 	out.Image = in.Image
 	out.Logging = in.Logging
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.PodDisruptionBudget.DeepCopyInto(&out.PodDisruptionBudget)
+	out.Service = in.Service
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConsolePluginSpec.
@@ -175,6 +265,21 @@ func (in *LegacyCollectorImageSpec) DeepCopy() *LegacyCollectorImageSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorEventsSpec) DeepCopyInto(out *OperatorEventsSpec) {
 	*out = *in
@@ -210,6 +315,11 @@ func (in *OperatorLoggingSpec) DeepCopy() *OperatorLoggingSpec {
 func (in *OperatorSpec) DeepCopyInto(out *OperatorSpec) {
 	*out = *in
 	out.Logging = in.Logging
+	if in.DisabledSteps != nil {
+		in, out := &in.DisabledSteps, &out.DisabledSteps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSpec.
@@ -284,9 +394,10 @@ func (in *OvnReconList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OvnReconSpec) DeepCopyInto(out *OvnReconSpec) {
 	*out = *in
-	out.Operator = in.Operator
-	out.ConsolePlugin = in.ConsolePlugin
+	in.Operator.DeepCopyInto(&out.Operator)
+	in.ConsolePlugin.DeepCopyInto(&out.ConsolePlugin)
 	in.Collector.DeepCopyInto(&out.Collector)
+	out.NetworkPolicy = in.NetworkPolicy
 	out.Image = in.Image
 	out.FeatureGates = in.FeatureGates
 	out.CollectorImage = in.CollectorImage
@@ -312,11 +423,16 @@ func (in *OvnReconStatus) DeepCopyInto(out *OvnReconStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+		*out = make([]metav1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ObservedNodeCount != nil {
+		in, out := &in.ObservedNodeCount, &out.ObservedNodeCount
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OvnReconStatus.
@@ -328,3 +444,57 @@ func (in *OvnReconStatus) DeepCopy() *OvnReconStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
+	*out = *in
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDisruptionBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SchedulingSpec) DeepCopyInto(out *SchedulingSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingSpec.
+func (in *SchedulingSpec) DeepCopy() *SchedulingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SchedulingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
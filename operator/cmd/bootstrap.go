@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+	"github.com/dlbewley/ovn-recon-operator/internal/controller"
+)
+
+// runBootstrap implements `manager bootstrap`: it installs a default OvnRecon (or the one in
+// -f) and its target namespace, then exits. Re-running it is safe.
+func runBootstrap(args []string) int {
+	fs := flag.NewFlagSet("bootstrap", flag.ContinueOnError)
+	var inputPath string
+	var timeout time.Duration
+	fs.StringVar(&inputPath, "f", "", "Path to OvnRecon YAML ('-' for stdin); defaults to a built-in OvnRecon")
+	fs.DurationVar(&timeout, "timeout", 30*time.Second, "Time allowed for the API calls")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	ovnRecon := controller.DefaultOvnRecon()
+	if inputPath != "" {
+		data, err := readBootstrapInput(inputPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "read input: %v\n", err)
+			return 1
+		}
+		ovnRecon = &reconv1beta1.OvnRecon{}
+		if err := yaml.Unmarshal(data, ovnRecon); err != nil {
+			fmt.Fprintf(os.Stderr, "parse OvnRecon: %v\n", err)
+			return 1
+		}
+		if ovnRecon.Name == "" {
+			ovnRecon.Name = "ovn-recon"
+		}
+	}
+
+	cfg, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load kubeconfig: %v\n", err)
+		return 1
+	}
+	c, err := client.New(cfg, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create client: %v\n", err)
+		return 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	result, err := controller.Bootstrap(ctx, c, ovnRecon)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if result.NamespaceCreated {
+		fmt.Printf("namespace/%s created\n", result.Namespace)
+	} else {
+		fmt.Printf("namespace/%s unchanged\n", result.Namespace)
+	}
+	if result.OvnReconCreated {
+		fmt.Printf("ovnrecon/%s created\n", ovnRecon.Name)
+	} else {
+		fmt.Printf("ovnrecon/%s unchanged\n", ovnRecon.Name)
+	}
+	return 0
+}
+
+func readBootstrapInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
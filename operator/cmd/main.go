@@ -40,6 +40,7 @@ import (
 	reconv1alpha1 "github.com/dlbewley/ovn-recon-operator/api/v1alpha1"
 	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
 	"github.com/dlbewley/ovn-recon-operator/internal/controller"
+	webhookv1beta1 "github.com/dlbewley/ovn-recon-operator/internal/webhook/v1beta1"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -58,6 +59,10 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "bootstrap" {
+		os.Exit(runBootstrap(os.Args[2:]))
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
@@ -65,6 +70,8 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableWebhooks bool
+	var rejectImageConflicts bool
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -83,6 +90,12 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableWebhooks, "enable-webhooks", false,
+		"If set, the OvnRecon validating webhook is served. Requires a serving certificate in --webhook-cert-path "+
+			"and the webhook manifests from config/webhook.")
+	flag.BoolVar(&rejectImageConflicts, "webhook-reject-image-conflicts", false,
+		"If set, the validating webhook rejects OvnRecons whose legacy and hierarchical image fields conflict "+
+			"instead of returning a warning.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -212,6 +225,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "OvnRecon")
 		os.Exit(1)
 	}
+	if enableWebhooks {
+		if err := webhookv1beta1.SetupOvnReconWebhookWithManager(mgr, rejectImageConflicts); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "OvnRecon")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if metricsCertWatcher != nil {
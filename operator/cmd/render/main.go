@@ -15,7 +15,11 @@ import (
 
 func main() {
 	var inputPath string
+	var validateOnly bool
+	var showEffective bool
 	flag.StringVar(&inputPath, "f", "", "Path to OvnRecon YAML ('-' for stdin)")
+	flag.BoolVar(&validateOnly, "validate", false, "Validate the OvnRecon and exit without rendering")
+	flag.BoolVar(&showEffective, "show-effective", false, "Print the OvnRecon spec with operator defaults applied and exit")
 	flag.Parse()
 
 	if inputPath == "" {
@@ -41,6 +45,27 @@ func main() {
 		ovnRecon.Kind = "OvnRecon"
 	}
 
+	if validateOnly {
+		errs := controller.ValidateOvnRecon(&ovnRecon)
+		for _, err := range errs {
+			fmt.Fprintln(os.Stderr, err)
+		}
+		if len(errs) > 0 {
+			exitf("OvnRecon %q is invalid: %d error(s)", ovnRecon.Name, len(errs))
+		}
+		fmt.Fprintf(os.Stderr, "OvnRecon %q is valid\n", ovnRecon.Name)
+		return
+	}
+
+	if showEffective {
+		out, err := yaml.Marshal(controller.EffectiveSpec(&ovnRecon))
+		if err != nil {
+			exitf("render effective spec: %v", err)
+		}
+		fmt.Fprint(os.Stdout, string(out))
+		return
+	}
+
 	objects := []interface{}{
 		controller.DesiredDeployment(&ovnRecon),
 		controller.DesiredService(&ovnRecon),
@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// reconcileBackoffBase is the retry delay after the first failed reconcile of an OvnRecon.
+	reconcileBackoffBase = 5 * time.Second
+	// reconcileBackoffMax caps the retry delay however many times an OvnRecon keeps failing.
+	reconcileBackoffMax = 5 * time.Minute
+	// reconcileBackoffJitter spreads each delay by up to this fraction either way, so OvnRecons
+	// that fail together do not retry together.
+	reconcileBackoffJitter = 0.2
+)
+
+// reconcileBackoff is a per-OvnRecon exponential rate limiter with jitter. controller-runtime
+// consults it whenever Reconcile returns an error and calls Forget after a success, which resets
+// the delay for that OvnRecon.
+type reconcileBackoff struct {
+	base   time.Duration
+	max    time.Duration
+	jitter float64
+	// random returns a value in [0, 1); tests replace it to make delays deterministic.
+	random func() float64
+
+	mu       sync.Mutex
+	failures map[reconcile.Request]int
+}
+
+var _ workqueue.TypedRateLimiter[reconcile.Request] = &reconcileBackoff{}
+
+func newReconcileBackoff(base, max time.Duration, jitter float64) *reconcileBackoff {
+	return &reconcileBackoff{
+		base:     base,
+		max:      max,
+		jitter:   jitter,
+		random:   rand.Float64,
+		failures: map[reconcile.Request]int{},
+	}
+}
+
+// When records another failure for item and returns how long to wait before retrying it.
+func (b *reconcileBackoff) When(item reconcile.Request) time.Duration {
+	b.mu.Lock()
+	failures := b.failures[item]
+	b.failures[item] = failures + 1
+	b.mu.Unlock()
+
+	delay := b.base
+	for i := 0; i < failures && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	jittered := time.Duration(float64(delay) * (1 + b.jitter*(2*b.random()-1)))
+	if jittered > b.max {
+		jittered = b.max
+	}
+	return jittered
+}
+
+// Forget clears the failure count for item after a successful reconcile.
+func (b *reconcileBackoff) Forget(item reconcile.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.failures, item)
+}
+
+// NumRequeues returns how many consecutive failures have been recorded for item.
+func (b *reconcileBackoff) NumRequeues(item reconcile.Request) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.failures[item]
+}
@@ -0,0 +1,54 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestReconcileBackoffGrowsAndResets(t *testing.T) {
+	t.Parallel()
+
+	backoff := newReconcileBackoff(time.Second, 10*time.Second, 0.2)
+	backoff.random = func() float64 { return 0.5 } // no jitter
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}
+	other := reconcile.Request{NamespacedName: types.NamespacedName{Name: "other"}}
+
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second, 10 * time.Second} {
+		if got := backoff.When(item); got != want {
+			t.Fatalf("failure %d: delay = %s, want %s", i+1, got, want)
+		}
+	}
+	if got := backoff.NumRequeues(item); got != 6 {
+		t.Fatalf("NumRequeues = %d, want 6", got)
+	}
+	if got := backoff.When(other); got != time.Second {
+		t.Fatalf("other OvnRecon delay = %s, want its own backoff to start at 1s", got)
+	}
+
+	backoff.Forget(item)
+	if got := backoff.NumRequeues(item); got != 0 {
+		t.Fatalf("NumRequeues after Forget = %d, want 0", got)
+	}
+	if got := backoff.When(item); got != time.Second {
+		t.Fatalf("delay after success = %s, want reset to 1s", got)
+	}
+}
+
+func TestReconcileBackoffJitterStaysWithinBounds(t *testing.T) {
+	t.Parallel()
+
+	backoff := newReconcileBackoff(10*time.Second, time.Minute, 0.2)
+	item := reconcile.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}
+
+	backoff.random = func() float64 { return 0 }
+	if got := backoff.When(item); got != 8*time.Second {
+		t.Fatalf("low jitter delay = %s, want 8s", got)
+	}
+	backoff.random = func() float64 { return 0.999999 }
+	if got := backoff.When(item); got < 23*time.Second || got > 24*time.Second {
+		t.Fatalf("high jitter delay = %s, want just under 24s", got)
+	}
+}
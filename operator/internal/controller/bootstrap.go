@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+// BootstrapResult reports what Bootstrap created. Both created flags are false when
+// everything already existed.
+type BootstrapResult struct {
+	Namespace        string
+	NamespaceCreated bool
+	OvnReconCreated  bool
+}
+
+// DefaultOvnRecon returns the OvnRecon installed by the bootstrap command when no manifest is
+// given: the console plugin enabled in the default target namespace, collector disabled.
+func DefaultOvnRecon() *reconv1beta1.OvnRecon {
+	return &reconv1beta1.OvnRecon{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: reconv1beta1.GroupVersion.String(),
+			Kind:       "OvnRecon",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "ovn-recon",
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: defaultNamespace,
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				DisplayName: "OVN Recon",
+				Enabled:     true,
+			},
+		},
+	}
+}
+
+// Bootstrap ensures the OvnRecon's target namespace exists and creates the OvnRecon when it
+// is absent. It is idempotent: an existing namespace or OvnRecon is left untouched, so running
+// it again never undoes later edits.
+func Bootstrap(ctx context.Context, c client.Client, ovnRecon *reconv1beta1.OvnRecon) (BootstrapResult, error) {
+	result := BootstrapResult{Namespace: targetNamespace(ovnRecon)}
+	if errs := ValidateOvnRecon(ovnRecon); len(errs) > 0 {
+		return result, fmt.Errorf("OvnRecon %q is invalid: %v", ovnRecon.Name, errs)
+	}
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   result.Namespace,
+			Labels: labelsForOvnRecon(ovnRecon.Name),
+		},
+	}
+	if err := c.Create(ctx, ns); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return result, fmt.Errorf("create namespace %q: %w", ns.Name, err)
+		}
+	} else {
+		result.NamespaceCreated = true
+	}
+
+	if err := c.Create(ctx, ovnRecon.DeepCopy()); err != nil {
+		if !errors.IsAlreadyExists(err) {
+			return result, fmt.Errorf("create OvnRecon %q: %w", ovnRecon.Name, err)
+		}
+	} else {
+		result.OvnReconCreated = true
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func newBootstrapTestClient(t *testing.T, objs ...client.Object) client.Client {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		corev1.AddToScheme,
+		reconv1beta1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestBootstrapIsIdempotent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := newBootstrapTestClient(t)
+
+	first, err := Bootstrap(ctx, c, DefaultOvnRecon())
+	if err != nil {
+		t.Fatalf("first Bootstrap() error = %v", err)
+	}
+	if !first.NamespaceCreated || !first.OvnReconCreated {
+		t.Fatalf("first Bootstrap() = %+v, want namespace and OvnRecon created", first)
+	}
+	if first.Namespace != defaultNamespace {
+		t.Fatalf("first Bootstrap() namespace = %q, want %q", first.Namespace, defaultNamespace)
+	}
+
+	// Edits made after the first run must survive a second run.
+	installed := &reconv1beta1.OvnRecon{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "ovn-recon"}, installed); err != nil {
+		t.Fatalf("get OvnRecon: %v", err)
+	}
+	installed.Spec.ConsolePlugin.DisplayName = "Edited"
+	if err := c.Update(ctx, installed); err != nil {
+		t.Fatalf("update OvnRecon: %v", err)
+	}
+
+	second, err := Bootstrap(ctx, c, DefaultOvnRecon())
+	if err != nil {
+		t.Fatalf("second Bootstrap() error = %v", err)
+	}
+	if second.NamespaceCreated || second.OvnReconCreated {
+		t.Fatalf("second Bootstrap() = %+v, want nothing created", second)
+	}
+
+	if err := c.Get(ctx, client.ObjectKey{Name: "ovn-recon"}, installed); err != nil {
+		t.Fatalf("get OvnRecon: %v", err)
+	}
+	if installed.Spec.ConsolePlugin.DisplayName != "Edited" {
+		t.Fatalf("display name = %q, want edit preserved", installed.Spec.ConsolePlugin.DisplayName)
+	}
+}
+
+func TestBootstrapUsesExistingNamespace(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ovnRecon := DefaultOvnRecon()
+	ovnRecon.Spec.TargetNamespace = "existing"
+	existing := &corev1.Namespace{}
+	existing.Name = "existing"
+	c := newBootstrapTestClient(t, existing)
+
+	result, err := Bootstrap(ctx, c, ovnRecon)
+	if err != nil {
+		t.Fatalf("Bootstrap() error = %v", err)
+	}
+	if result.NamespaceCreated || !result.OvnReconCreated {
+		t.Fatalf("Bootstrap() = %+v, want only OvnRecon created", result)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := c.Get(ctx, client.ObjectKey{Name: "existing"}, ns); err != nil {
+		t.Fatalf("get namespace: %v", err)
+	}
+	if len(ns.Labels) != 0 {
+		t.Fatalf("namespace labels = %v, want pre-existing namespace untouched", ns.Labels)
+	}
+}
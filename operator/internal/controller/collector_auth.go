@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func collectorAuthSecretName(ovnRecon *reconv1beta1.OvnRecon) string {
+	return collectorName(ovnRecon) + "-auth"
+}
+
+// reconcileCollectorAuthSecret ensures the collector bearer token Secret exists. The token is
+// generated once and replaced only when the OvnRecon rotation annotation changes.
+func (r *OvnReconReconciler) reconcileCollectorAuthSecret(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorAuthSecretName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		token := secret.Data[collectorAuthTokenKey]
+		requested := ovnRecon.Annotations[collectorAuthTokenRotationAnnotation]
+		if len(token) == 0 || secret.Annotations[collectorAuthTokenRotationAnnotation] != requested {
+			generated, err := generateCollectorAuthToken()
+			if err != nil {
+				return err
+			}
+			token = generated
+		}
+
+		desired := DesiredCollectorAuthSecret(ovnRecon, token)
+		secret.Labels = mergeStringMap(secret.Labels, desired.Labels)
+		secret.Annotations = mergeStringMap(secret.Annotations, desired.Annotations)
+		if requested == "" {
+			delete(secret.Annotations, collectorAuthTokenRotationAnnotation)
+		}
+		secret.Type = desired.Type
+		secret.Data = desired.Data
+		return nil
+	})
+	return err
+}
+
+func (r *OvnReconReconciler) deleteCollectorAuthSecret(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorAuthSecretName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+	if err := r.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+func generateCollectorAuthToken() ([]byte, error) {
+	raw := make([]byte, 32)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("generate collector auth token: %w", err)
+	}
+	return []byte(hex.EncodeToString(raw)), nil
+}
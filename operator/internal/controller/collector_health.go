@@ -0,0 +1,221 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+const (
+	// defaultServiceCAFile is the OpenShift service CA bundle mounted into every pod's
+	// service account volume.
+	defaultServiceCAFile         = "/var/run/secrets/kubernetes.io/serviceaccount/service-ca.crt"
+	defaultCollectorHealthScheme = "http"
+	collectorHealthTimeout       = 5 * time.Second
+)
+
+func collectorHealthScheme(ovnRecon *reconv1beta1.OvnRecon) string {
+	if ovnRecon.Spec.Collector.HealthCheck.Scheme != "" {
+		return ovnRecon.Spec.Collector.HealthCheck.Scheme
+	}
+	return defaultCollectorHealthScheme
+}
+
+// collectorEndpoint returns the in-cluster URL of the collector Service, reported in status and
+// used as the base for health and node queries.
+func collectorEndpoint(ovnRecon *reconv1beta1.OvnRecon) string {
+	return fmt.Sprintf("%s://%s.%s.svc:8090", collectorHealthScheme(ovnRecon), collectorName(ovnRecon), targetNamespace(ovnRecon))
+}
+
+func collectorHealthURL(ovnRecon *reconv1beta1.OvnRecon) string {
+	return collectorEndpoint(ovnRecon) + "/healthz"
+}
+
+// collectorHealthCABundle returns the PEM CA bundle trusted for https health checks. A configured
+// ConfigMap key wins; otherwise the in-cluster service CA file is used when present. A nil bundle
+// means the system roots are used.
+func (r *OvnReconReconciler) collectorHealthCABundle(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) ([]byte, error) {
+	if ref := ovnRecon.Spec.Collector.HealthCheck.CABundle; ref != nil {
+		configMap := &corev1.ConfigMap{}
+		if err := r.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: targetNamespace(ovnRecon)}, configMap); err != nil {
+			if errors.IsNotFound(err) && ref.Optional != nil && *ref.Optional {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("read collector CA bundle ConfigMap %q: %w", ref.Name, err)
+		}
+		bundle, ok := configMap.Data[ref.Key]
+		if !ok {
+			if ref.Optional != nil && *ref.Optional {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("collector CA bundle ConfigMap %q has no key %q", ref.Name, ref.Key)
+		}
+		return []byte(bundle), nil
+	}
+
+	caFile := r.ServiceCAFile
+	if caFile == "" {
+		caFile = defaultServiceCAFile
+	}
+	bundle, err := os.ReadFile(caFile)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read service CA file %q: %w", caFile, err)
+	}
+	return bundle, nil
+}
+
+// collectorHealthTLSConfig builds the TLS client configuration for the given CA bundle.
+func collectorHealthTLSConfig(caBundle []byte) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if len(caBundle) == 0 {
+		return tlsConfig, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caBundle) {
+		return nil, fmt.Errorf("collector CA bundle contains no valid PEM certificates")
+	}
+	tlsConfig.RootCAs = pool
+	return tlsConfig, nil
+}
+
+func newCollectorHealthClient(tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Timeout: collectorHealthTimeout,
+		Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: tlsConfig,
+		},
+	}
+}
+
+// collectorClient returns an HTTP client for the collector, trusting the configured CA bundle
+// for https.
+func (r *OvnReconReconciler) collectorClient(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (*http.Client, error) {
+	caBundle, err := r.collectorHealthCABundle(ctx, ovnRecon)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := collectorHealthTLSConfig(caBundle)
+	if err != nil {
+		return nil, err
+	}
+	return newCollectorHealthClient(tlsConfig), nil
+}
+
+// checkCollectorHealth calls the collector health endpoint.
+func (r *OvnReconReconciler) checkCollectorHealth(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	httpClient, err := r.collectorClient(ctx, ovnRecon)
+	if err != nil {
+		return err
+	}
+	return probeCollectorHealth(ctx, httpClient, collectorHealthURL(ovnRecon))
+}
+
+// observeCollectorNodes asks the collector's /api/v1/nodes endpoint, authenticated with the
+// collector bearer token, how many nodes it can currently probe live.
+func (r *OvnReconReconciler) observeCollectorNodes(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (int32, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Name: collectorAuthSecretName(ovnRecon), Namespace: targetNamespace(ovnRecon)}, secret); err != nil {
+		return 0, fmt.Errorf("read collector auth Secret: %w", err)
+	}
+	httpClient, err := r.collectorClient(ctx, ovnRecon)
+	if err != nil {
+		return 0, err
+	}
+	return countLiveCollectorNodes(ctx, httpClient, collectorEndpoint(ovnRecon)+"/api/v1/nodes", string(secret.Data[collectorAuthTokenKey]))
+}
+
+func countLiveCollectorNodes(ctx context.Context, httpClient *http.Client, url, token string) (int32, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("collector node query failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("collector node query returned status %d", resp.StatusCode)
+	}
+
+	var nodes []struct {
+		Live bool `json:"live"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&nodes); err != nil {
+		return 0, fmt.Errorf("decode collector nodes: %w", err)
+	}
+	var live int32
+	for _, node := range nodes {
+		if node.Live {
+			live++
+		}
+	}
+	return live, nil
+}
+
+func probeCollectorHealth(ctx context.Context, httpClient *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("collector health check failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("collector health check returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// collectorDeploymentReady reports whether the collector Deployment has all replicas ready.
+func (r *OvnReconReconciler) collectorDeploymentReady(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
+	deployment := &appsv1.Deployment{}
+	err := r.Get(ctx, client.ObjectKey{Name: collectorName(ovnRecon), Namespace: targetNamespace(ovnRecon)}, deployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if deployment.Spec.Replicas == nil || *deployment.Spec.Replicas == 0 {
+		return false, nil
+	}
+	return deployment.Status.ReadyReplicas == *deployment.Spec.Replicas, nil
+}
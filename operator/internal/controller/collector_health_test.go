@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func newTLSHealthServer(t *testing.T) (*httptest.Server, []byte) {
+	t.Helper()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/healthz" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	return server, caPEM
+}
+
+func TestCollectorHealthCABundlePrefersConfigMap(t *testing.T) {
+	t.Parallel()
+
+	server, caPEM := newTLSHealthServer(t)
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add core scheme: %v", err)
+	}
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "collector-ca", Namespace: "ovn-recon"},
+		Data:       map[string]string{"ca.crt": string(caPEM)},
+	}
+	reconciler := &OvnReconReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build(),
+		Scheme:        scheme,
+		ServiceCAFile: filepath.Join(t.TempDir(), "missing-service-ca.crt"),
+	}
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector: reconv1beta1.CollectorSpec{
+				HealthCheck: reconv1beta1.CollectorHealthCheckSpec{
+					Scheme: "https",
+					CABundle: &corev1.ConfigMapKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "collector-ca"},
+						Key:                  "ca.crt",
+					},
+				},
+			},
+		},
+	}
+
+	bundle, err := reconciler.collectorHealthCABundle(context.Background(), ovnRecon)
+	if err != nil {
+		t.Fatalf("collectorHealthCABundle failed: %v", err)
+	}
+	tlsConfig, err := collectorHealthTLSConfig(bundle)
+	if err != nil {
+		t.Fatalf("collectorHealthTLSConfig failed: %v", err)
+	}
+	if tlsConfig.RootCAs == nil {
+		t.Fatalf("expected custom root CAs from ConfigMap")
+	}
+	if err := probeCollectorHealth(context.Background(), newCollectorHealthClient(tlsConfig), server.URL+"/healthz"); err != nil {
+		t.Fatalf("expected health check to trust ConfigMap CA: %v", err)
+	}
+	if got := collectorHealthURL(ovnRecon); got != "https://ovn-recon-collector.ovn-recon.svc:8090/healthz" {
+		t.Fatalf("unexpected health URL: %s", got)
+	}
+}
+
+func TestCollectorHealthCABundleDefaultsToServiceCA(t *testing.T) {
+	t.Parallel()
+
+	server, caPEM := newTLSHealthServer(t)
+	serviceCAFile := filepath.Join(t.TempDir(), "service-ca.crt")
+	if err := os.WriteFile(serviceCAFile, caPEM, 0o600); err != nil {
+		t.Fatalf("failed to write service CA: %v", err)
+	}
+
+	reconciler := &OvnReconReconciler{ServiceCAFile: serviceCAFile}
+	ovnRecon := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+
+	bundle, err := reconciler.collectorHealthCABundle(context.Background(), ovnRecon)
+	if err != nil {
+		t.Fatalf("collectorHealthCABundle failed: %v", err)
+	}
+	tlsConfig, err := collectorHealthTLSConfig(bundle)
+	if err != nil {
+		t.Fatalf("collectorHealthTLSConfig failed: %v", err)
+	}
+	if err := probeCollectorHealth(context.Background(), newCollectorHealthClient(tlsConfig), server.URL+"/healthz"); err != nil {
+		t.Fatalf("expected health check to trust service CA: %v", err)
+	}
+	if got := collectorHealthURL(ovnRecon); got != "http://ovn-recon-collector.ovn-recon.svc:8090/healthz" {
+		t.Fatalf("unexpected default health URL: %s", got)
+	}
+}
+
+func TestCollectorHealthTLSConfigFallsBackToSystemRoots(t *testing.T) {
+	t.Parallel()
+
+	server, _ := newTLSHealthServer(t)
+	reconciler := &OvnReconReconciler{ServiceCAFile: filepath.Join(t.TempDir(), "missing.crt")}
+
+	bundle, err := reconciler.collectorHealthCABundle(context.Background(), &reconv1beta1.OvnRecon{})
+	if err != nil {
+		t.Fatalf("collectorHealthCABundle failed: %v", err)
+	}
+	if bundle != nil {
+		t.Fatalf("expected no bundle when service CA is absent")
+	}
+	tlsConfig, err := collectorHealthTLSConfig(bundle)
+	if err != nil {
+		t.Fatalf("collectorHealthTLSConfig failed: %v", err)
+	}
+	if tlsConfig.RootCAs != nil {
+		t.Fatalf("expected system roots when no CA bundle is available")
+	}
+	if err := probeCollectorHealth(context.Background(), newCollectorHealthClient(tlsConfig), server.URL+"/healthz"); err == nil {
+		t.Fatalf("expected untrusted self-signed collector certificate to fail verification")
+	}
+
+	if _, err := collectorHealthTLSConfig([]byte("not a certificate")); err == nil {
+		t.Fatalf("expected invalid CA bundle to be rejected")
+	}
+}
+
+func TestCountLiveCollectorNodesSendsTokenAndCountsLiveNodes(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes" || r.Header.Get("Authorization") != "Bearer s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"nodeName":"worker-a","live":true},{"nodeName":"worker-b","live":true},{"nodeName":"archived","live":false}]`))
+	}))
+	t.Cleanup(server.Close)
+
+	count, err := countLiveCollectorNodes(context.Background(), server.Client(), server.URL+"/api/v1/nodes", "s3cret")
+	if err != nil {
+		t.Fatalf("countLiveCollectorNodes failed: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("expected 2 live nodes, got %d", count)
+	}
+
+	if _, err := countLiveCollectorNodes(context.Background(), server.Client(), server.URL+"/api/v1/nodes", "wrong"); err == nil {
+		t.Fatalf("expected a rejected token to fail the node query")
+	}
+}
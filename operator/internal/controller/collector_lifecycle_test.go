@@ -2,16 +2,22 @@ package controller
 
 import (
 	"context"
+	"reflect"
+	"strings"
 	"testing"
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
 )
@@ -82,6 +88,9 @@ func TestDeleteCollectorResourcesRemovesService(t *testing.T) {
 	if err := corev1.AddToScheme(scheme); err != nil {
 		t.Fatalf("failed to add core/v1 scheme: %v", err)
 	}
+	if err := policyv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add policy/v1 scheme: %v", err)
+	}
 
 	ovnRecon := &reconv1beta1.OvnRecon{
 		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
@@ -322,3 +331,357 @@ func TestProbeNamespaceEventEnqueuesMatchingOvnRecon(t *testing.T) {
 		t.Fatalf("unexpected disabled or nonmatching reconcile request: %#v", requests)
 	}
 }
+
+func TestCollectorRBACReadyConditionStaysFalseWithMissingRoleBinding(t *testing.T) {
+	t.Parallel()
+
+	collectorEnabled := true
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector: reconv1beta1.CollectorSpec{
+				Enabled:         &collectorEnabled,
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+			},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin},
+			},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t,
+		ovnRecon,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-ovn-kubernetes"}},
+	)
+
+	if _, err := reconciler.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	condition := meta.FindStatusCondition(current.Status.Conditions, "CollectorRBACReady")
+	if condition == nil {
+		t.Fatalf("expected CollectorRBACReady condition, got %#v", current.Status.Conditions)
+	}
+	if condition.Status != metav1.ConditionFalse || condition.Reason != "CollectorRBACIncomplete" {
+		t.Fatalf("expected CollectorRBACReady=False/CollectorRBACIncomplete, got %s/%s", condition.Status, condition.Reason)
+	}
+	if !strings.Contains(condition.Message, "openshift-frr-k8s") || strings.Contains(condition.Message, "openshift-ovn-kubernetes") {
+		t.Fatalf("expected message to list only the FRR namespace gap, got %q", condition.Message)
+	}
+}
+
+func TestCollectorRBACGapsDetectsWrongRoleRef(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector: reconv1beta1.CollectorSpec{
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes"},
+			},
+		},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: collectorRoleBindingName(ovnRecon), Namespace: "openshift-ovn-kubernetes"},
+		Subjects: []rbacv1.Subject{{
+			Kind:      rbacv1.ServiceAccountKind,
+			Name:      collectorServiceAccountName(ovnRecon),
+			Namespace: "ovn-recon",
+		}},
+		RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, roleBinding)
+
+	gaps, err := reconciler.collectorRBACGaps(context.Background(), ovnRecon)
+	if err != nil {
+		t.Fatalf("collectorRBACGaps failed: %v", err)
+	}
+	if len(gaps) != 1 || !strings.Contains(gaps[0], "ClusterRole view") {
+		t.Fatalf("expected a single wrong-roleRef gap, got %v", gaps)
+	}
+}
+
+func TestReconcileCollectorAuthSecretGeneratesAndRotatesToken(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ovn-recon-collector-auth", Namespace: "ovn-recon"}
+
+	if err := reconciler.reconcileCollectorAuthSecret(ctx, ovnRecon); err != nil {
+		t.Fatalf("reconcileCollectorAuthSecret failed: %v", err)
+	}
+	secret := &corev1.Secret{}
+	if err := reconciler.Get(ctx, key, secret); err != nil {
+		t.Fatalf("expected collector auth secret to be created: %v", err)
+	}
+	token := string(secret.Data[collectorAuthTokenKey])
+	if len(token) != 64 {
+		t.Fatalf("expected a 64 character hex token, got %q", token)
+	}
+
+	if err := reconciler.reconcileCollectorAuthSecret(ctx, ovnRecon); err != nil {
+		t.Fatalf("second reconcileCollectorAuthSecret failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, secret); err != nil {
+		t.Fatalf("failed to get collector auth secret: %v", err)
+	}
+	if got := string(secret.Data[collectorAuthTokenKey]); got != token {
+		t.Fatalf("expected token to be stable across reconciles, got %q then %q", token, got)
+	}
+
+	ovnRecon.Annotations = map[string]string{collectorAuthTokenRotationAnnotation: "2026-10-17"}
+	if err := reconciler.reconcileCollectorAuthSecret(ctx, ovnRecon); err != nil {
+		t.Fatalf("rotating reconcileCollectorAuthSecret failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, secret); err != nil {
+		t.Fatalf("failed to get collector auth secret: %v", err)
+	}
+	if got := string(secret.Data[collectorAuthTokenKey]); got == token || got == "" {
+		t.Fatalf("expected rotation annotation to replace the token, got %q", got)
+	}
+	if got := secret.Annotations[collectorAuthTokenRotationAnnotation]; got != "2026-10-17" {
+		t.Fatalf("expected applied rotation to be recorded on the secret, got %q", got)
+	}
+
+	if err := reconciler.deleteCollectorResources(ctx, ovnRecon); err != nil {
+		t.Fatalf("deleteCollectorResources failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, secret); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected collector auth secret to be deleted, got err=%v", err)
+	}
+}
+
+func TestReconcileCollectorAccessControlsAddsPullSecretToServiceAccount(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add core/v1 scheme: %v", err)
+	}
+	if err := rbacv1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add rbac/v1 scheme: %v", err)
+	}
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			PullSecretName:  "quay-creds",
+		},
+	}
+	// OpenShift injects a dockercfg secret into every ServiceAccount; it must survive reconcile.
+	serviceAccount := &corev1.ServiceAccount{
+		ObjectMeta:       metav1.ObjectMeta{Name: collectorServiceAccountName(ovnRecon), Namespace: "ovn-recon"},
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "ovn-recon-collector-dockercfg-abcde"}},
+	}
+	reconciler := &OvnReconReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}, serviceAccount).
+			Build(),
+		Scheme: scheme,
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := reconciler.reconcileCollectorAccessControls(context.Background(), ovnRecon); err != nil {
+			t.Fatalf("reconcileCollectorAccessControls failed: %v", err)
+		}
+	}
+
+	updated := &corev1.ServiceAccount{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: serviceAccount.Name, Namespace: "ovn-recon"}, updated); err != nil {
+		t.Fatalf("failed to get collector ServiceAccount: %v", err)
+	}
+	want := []corev1.LocalObjectReference{{Name: "ovn-recon-collector-dockercfg-abcde"}, {Name: "quay-creds"}}
+	if !reflect.DeepEqual(updated.ImagePullSecrets, want) {
+		t.Fatalf("expected pull secrets %#v, got %#v", want, updated.ImagePullSecrets)
+	}
+}
+
+func TestReconcileCollectorTrustedCABundleLabelsAndMountsConfigMap(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector:       reconv1beta1.CollectorSpec{InjectTrustedCABundle: true},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ovn-recon-collector-trusted-ca", Namespace: "ovn-recon"}
+
+	if err := reconciler.reconcileCollectorTrustedCABundle(ctx, ovnRecon); err != nil {
+		t.Fatalf("reconcileCollectorTrustedCABundle failed: %v", err)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reconciler.Get(ctx, key, configMap); err != nil {
+		t.Fatalf("expected trusted CA bundle ConfigMap to be created: %v", err)
+	}
+	if got := configMap.Labels[trustedCABundleInjectLabel]; got != "true" {
+		t.Fatalf("expected inject label on ConfigMap, got %q", got)
+	}
+
+	configMap.Data = map[string]string{trustedCABundleKey: "injected"}
+	if err := reconciler.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to simulate bundle injection: %v", err)
+	}
+	if err := reconciler.reconcileCollectorTrustedCABundle(ctx, ovnRecon); err != nil {
+		t.Fatalf("second reconcileCollectorTrustedCABundle failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, configMap); err != nil {
+		t.Fatalf("failed to get trusted CA bundle ConfigMap: %v", err)
+	}
+	if got := configMap.Data[trustedCABundleKey]; got != "injected" {
+		t.Fatalf("expected injected bundle to be preserved, got %q", got)
+	}
+
+	podSpec := DesiredCollectorDeployment(ovnRecon).Spec.Template.Spec
+	if len(podSpec.Volumes) != 1 || podSpec.Volumes[0].ConfigMap == nil || podSpec.Volumes[0].ConfigMap.Name != key.Name {
+		t.Fatalf("expected collector pod to mount the trusted CA bundle ConfigMap, got %#v", podSpec.Volumes)
+	}
+	mounts := podSpec.Containers[0].VolumeMounts
+	if len(mounts) != 1 || mounts[0].Name != podSpec.Volumes[0].Name || mounts[0].MountPath != trustedCABundleMountPath || !mounts[0].ReadOnly {
+		t.Fatalf("expected read-only trusted CA bundle mount, got %#v", mounts)
+	}
+
+	ovnRecon.Spec.Collector.InjectTrustedCABundle = false
+	if err := reconciler.reconcileCollectorTrustedCABundle(ctx, ovnRecon); err != nil {
+		t.Fatalf("disabled reconcileCollectorTrustedCABundle failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, configMap); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected trusted CA bundle ConfigMap to be deleted when disabled, got err=%v", err)
+	}
+	podSpec = DesiredCollectorDeployment(ovnRecon).Spec.Template.Spec
+	if len(podSpec.Volumes) != 0 || len(podSpec.Containers[0].VolumeMounts) != 0 {
+		t.Fatalf("expected no trusted CA bundle mount when disabled, got %#v", podSpec.Volumes)
+	}
+}
+
+func TestReconcileCollectorConfigMapProjectsResolvedSpec(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace:          "ovn-recon",
+			CollectorProbeNamespaces: []string{"legacy-ns"},
+			Collector: reconv1beta1.CollectorSpec{
+				Enabled:         ptr.To(true),
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes", "custom-ovn"},
+				Image:           reconv1beta1.CollectorImageSpec{Repository: "quay.io/example/collector", Tag: "v1.2.3"},
+			},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin, reconcileStepCollectorHealth},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace)
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}
+	key := types.NamespacedName{Name: "ovn-recon-collector-config", Namespace: "ovn-recon"}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	configMap := &corev1.ConfigMap{}
+	if err := reconciler.Get(ctx, key, configMap); err != nil {
+		t.Fatalf("expected collector config ConfigMap to be created: %v", err)
+	}
+	want := map[string]string{
+		collectorConfigProbeNamespacesKey: "openshift-ovn-kubernetes,custom-ovn",
+		collectorConfigImageKey:           "quay.io/example/collector:v1.2.3",
+		collectorConfigFeatureGateKey:     "true",
+	}
+	if !reflect.DeepEqual(configMap.Data, want) {
+		t.Fatalf("expected ConfigMap data %v, got %v", want, configMap.Data)
+	}
+	deployment := &appsv1.Deployment{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "ovn-recon-collector", Namespace: "ovn-recon"}, deployment); err != nil {
+		t.Fatalf("failed to get collector Deployment: %v", err)
+	}
+	if got := deployment.Spec.Template.Spec.Containers[0].Image; got != want[collectorConfigImageKey] {
+		t.Fatalf("expected ConfigMap image to match the Deployment image %q, got %q", got, want[collectorConfigImageKey])
+	}
+
+	// Hand edits to the data are reverted.
+	configMap.Data[collectorConfigImageKey] = "edited"
+	if err := reconciler.Update(ctx, configMap); err != nil {
+		t.Fatalf("failed to edit ConfigMap: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("second Reconcile failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, configMap); err != nil {
+		t.Fatalf("failed to get ConfigMap: %v", err)
+	}
+	if !reflect.DeepEqual(configMap.Data, want) {
+		t.Fatalf("expected ConfigMap data %v to be restored, got %v", want, configMap.Data)
+	}
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, request.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	current.Spec.Collector.Enabled = ptr.To(false)
+	if err := reconciler.Update(ctx, current); err != nil {
+		t.Fatalf("failed to disable collector: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("disabled Reconcile failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, configMap); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected collector config ConfigMap to be deleted when disabled, got err=%v", err)
+	}
+}
+
+func TestCollectorRBACNamespacesGrantSupersetOfProbeNamespaces(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector: reconv1beta1.CollectorSpec{
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes"},
+				RBACNamespaces:  []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+			},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t,
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-ovn-kubernetes"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "openshift-frr-k8s"}},
+	)
+	ctx := context.Background()
+
+	if err := reconciler.reconcileCollectorAccessControls(ctx, ovnRecon); err != nil {
+		t.Fatalf("reconcileCollectorAccessControls failed: %v", err)
+	}
+	for _, namespace := range []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"} {
+		roleBinding := &rbacv1.RoleBinding{}
+		if err := reconciler.Get(ctx, types.NamespacedName{Name: collectorRoleBindingName(ovnRecon), Namespace: namespace}, roleBinding); err != nil {
+			t.Fatalf("expected collector RoleBinding in RBAC namespace %s: %v", namespace, err)
+		}
+	}
+
+	env := DesiredCollectorDeployment(ovnRecon).Spec.Template.Spec.Containers[0].Env
+	if got, _ := envValue(env, "COLLECTOR_TARGET_NAMESPACES"); got != "openshift-ovn-kubernetes" {
+		t.Fatalf("expected only the probe subset in COLLECTOR_TARGET_NAMESPACES, got %q", got)
+	}
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+// collectorRBACGaps lists every RBAC namespace where the collector lacks a RoleBinding to its
+// ClusterRole for its ServiceAccount. An empty result means exec access is in place everywhere.
+func (r *OvnReconReconciler) collectorRBACGaps(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) ([]string, error) {
+	gaps := []string{}
+	for _, probeNamespace := range collectorRBACNamespacesFor(ovnRecon) {
+		probeNamespace = strings.TrimSpace(probeNamespace)
+		if probeNamespace == "" {
+			continue
+		}
+
+		roleBinding := &rbacv1.RoleBinding{}
+		err := r.Get(ctx, client.ObjectKey{Name: collectorRoleBindingName(ovnRecon), Namespace: probeNamespace}, roleBinding)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				gaps = append(gaps, fmt.Sprintf("%s: RoleBinding %s not found", probeNamespace, collectorRoleBindingName(ovnRecon)))
+				continue
+			}
+			return nil, err
+		}
+		if gap := collectorRoleBindingGap(ovnRecon, roleBinding); gap != "" {
+			gaps = append(gaps, fmt.Sprintf("%s: %s", probeNamespace, gap))
+		}
+	}
+	return gaps, nil
+}
+
+func collectorRoleBindingGap(ovnRecon *reconv1beta1.OvnRecon, roleBinding *rbacv1.RoleBinding) string {
+	roleRef := roleBinding.RoleRef
+	if roleRef.Kind != "ClusterRole" || roleRef.Name != collectorClusterRoleName(ovnRecon) {
+		return fmt.Sprintf("RoleBinding %s references %s %s, expected ClusterRole %s", roleBinding.Name, roleRef.Kind, roleRef.Name, collectorClusterRoleName(ovnRecon))
+	}
+	for _, subject := range roleBinding.Subjects {
+		if subject.Kind == rbacv1.ServiceAccountKind &&
+			subject.Name == collectorServiceAccountName(ovnRecon) &&
+			subject.Namespace == targetNamespace(ovnRecon) {
+			return ""
+		}
+	}
+	return fmt.Sprintf("RoleBinding %s does not bind ServiceAccount %s/%s", roleBinding.Name, targetNamespace(ovnRecon), collectorServiceAccountName(ovnRecon))
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+const (
+	// trustedCABundleInjectLabel asks the OpenShift network operator to fill a ConfigMap with
+	// the cluster-wide trusted CA bundle.
+	trustedCABundleInjectLabel = "config.openshift.io/inject-trusted-cabundle"
+	trustedCABundleKey         = "ca-bundle.crt"
+	trustedCABundleVolumeName  = "trusted-ca-bundle"
+	trustedCABundleMountPath   = "/etc/pki/ca-trust/extracted/pem"
+)
+
+func collectorTrustedCABundleName(ovnRecon *reconv1beta1.OvnRecon) string {
+	return collectorName(ovnRecon) + "-trusted-ca"
+}
+
+// reconcileCollectorTrustedCABundle ensures the injectable trust bundle ConfigMap exists when
+// enabled and removes it otherwise. Its data belongs to the network operator and is never
+// overwritten here.
+func (r *OvnReconReconciler) reconcileCollectorTrustedCABundle(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	if !ovnRecon.Spec.Collector.InjectTrustedCABundle {
+		return r.deleteCollectorTrustedCABundle(ctx, ovnRecon)
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorTrustedCABundleName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		desired := DesiredCollectorTrustedCABundle(ovnRecon)
+		configMap.Labels = mergeStringMap(configMap.Labels, desired.Labels)
+		configMap.Annotations = mergeStringMap(configMap.Annotations, desired.Annotations)
+		return nil
+	})
+	return err
+}
+
+func (r *OvnReconReconciler) deleteCollectorTrustedCABundle(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      collectorTrustedCABundleName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+	if err := r.Delete(ctx, configMap); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// appliedSpecHashAnnotation records a hash of the Deployment spec the operator last applied. It
+// is the "last applied" side of the three-way comparison in applyDeployment.
+const appliedSpecHashAnnotation = "ovnrecon.bewley.net/applied-spec-hash"
+
+// applyDeployment creates or updates a managed Deployment with a three-way reconcile:
+//
+//   - Labels and annotations are merged, so keys added by users or other controllers survive
+//     while operator-managed keys are reset to their desired values.
+//   - The spec is always replaced by the desired spec, so edits to it are reverted.
+//   - When the live spec no longer carries the spec recorded in appliedSpecHashAnnotation, and
+//     that recorded spec is still the desired one, someone edited the Deployment outside the
+//     operator. applyDeployment then reports true so the caller can surface the correction.
+//     A desired spec that changed since the last apply is an ordinary rollout, not drift.
+//
+// Fields the API server defaults, and so are unset in the desired spec, are not treated as drift.
+func (r *OvnReconReconciler) applyDeployment(ctx context.Context, desired *appsv1.Deployment) (bool, error) {
+	desiredHash, err := deploymentSpecHash(desired.Spec)
+	if err != nil {
+		return false, err
+	}
+
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      desired.Name,
+			Namespace: desired.Namespace,
+		},
+	}
+	drifted := false
+	_, err = controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
+		drifted = deploymentDrifted(deployment, desired.Spec, desiredHash)
+		deployment.Labels = mergeStringMap(deployment.Labels, desired.Labels)
+		deployment.Annotations = mergeStringMap(deployment.Annotations, desired.Annotations)
+		deployment.Annotations[appliedSpecHashAnnotation] = desiredHash
+		deployment.Spec = desired.Spec
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return drifted, nil
+}
+
+// deploymentDrifted reports whether an existing Deployment was last applied with desiredHash but
+// its spec has since been changed away from desired.
+func deploymentDrifted(live *appsv1.Deployment, desired appsv1.DeploymentSpec, desiredHash string) bool {
+	if live.ResourceVersion == "" || live.Annotations[appliedSpecHashAnnotation] != desiredHash {
+		return false
+	}
+	return !equality.Semantic.DeepDerivative(desired, live.Spec)
+}
+
+// deploymentSpecHash returns a short, stable hash of spec.
+func deploymentSpecHash(spec appsv1.DeploymentSpec) (string, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("hash deployment spec: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8]), nil
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestReconcileCorrectsDeploymentDriftAndKeepsUserMetadata(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace)
+	recorder := record.NewFakeRecorder(50)
+	reconciler.Recorder = recorder
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}
+	key := types.NamespacedName{Name: "ovn-recon", Namespace: "ovn-recon"}
+
+	driftEvents := func() []string {
+		var events []string
+		for len(recorder.Events) > 0 {
+			if event := <-recorder.Events; strings.HasPrefix(event, "Normal DeploymentDriftCorrected") {
+				events = append(events, event)
+			}
+		}
+		return events
+	}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("first Reconcile failed: %v", err)
+	}
+	if events := driftEvents(); len(events) != 0 {
+		t.Fatalf("expected no drift event when creating the Deployment, got %v", events)
+	}
+	applied := &appsv1.Deployment{}
+	if err := reconciler.Get(ctx, key, applied); err != nil {
+		t.Fatalf("failed to get Deployment: %v", err)
+	}
+	wantImage := applied.Spec.Template.Spec.Containers[0].Image
+	wantReplicas := *applied.Spec.Replicas
+
+	// A user edits managed spec fields and adds their own metadata.
+	applied.Spec.Replicas = ptr.To(wantReplicas + 3)
+	applied.Spec.Template.Spec.Containers[0].Image = "quay.io/someone/else:latest"
+	applied.Annotations["team.example.com/owner"] = "netops"
+	applied.Labels["team.example.com/tier"] = "debug"
+	if err := reconciler.Update(ctx, applied); err != nil {
+		t.Fatalf("failed to edit Deployment: %v", err)
+	}
+
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("second Reconcile failed: %v", err)
+	}
+	if events := driftEvents(); len(events) != 1 || !strings.Contains(events[0], "Deployment ovn-recon") {
+		t.Fatalf("expected one DeploymentDriftCorrected event naming the Deployment, got %v", events)
+	}
+	restored := &appsv1.Deployment{}
+	if err := reconciler.Get(ctx, key, restored); err != nil {
+		t.Fatalf("failed to get Deployment: %v", err)
+	}
+	if got := restored.Spec.Template.Spec.Containers[0].Image; got != wantImage {
+		t.Fatalf("expected image %q to be restored, got %q", wantImage, got)
+	}
+	if got := *restored.Spec.Replicas; got != wantReplicas {
+		t.Fatalf("expected replicas %d to be restored, got %d", wantReplicas, got)
+	}
+	if restored.Annotations["team.example.com/owner"] != "netops" || restored.Labels["team.example.com/tier"] != "debug" {
+		t.Fatalf("expected user metadata to be kept, got labels %v annotations %v", restored.Labels, restored.Annotations)
+	}
+
+	// Changing the OvnRecon rolls the Deployment out without reporting drift.
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, request.NamespacedName, current); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	current.Spec.ConsolePlugin.Image.Tag = "v9.9.9"
+	if err := reconciler.Update(ctx, current); err != nil {
+		t.Fatalf("failed to update OvnRecon: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, request); err != nil {
+		t.Fatalf("third Reconcile failed: %v", err)
+	}
+	if events := driftEvents(); len(events) != 0 {
+		t.Fatalf("expected no drift event for an OvnRecon change, got %v", events)
+	}
+	if err := reconciler.Get(ctx, key, restored); err != nil {
+		t.Fatalf("failed to get Deployment: %v", err)
+	}
+	if got := restored.Spec.Template.Spec.Containers[0].Image; !strings.HasSuffix(got, ":v9.9.9") {
+		t.Fatalf("expected the new tag to roll out, got %q", got)
+	}
+}
+
+func TestManagedDeploymentEventsMapToTheirOvnRecon(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	for _, deployment := range []*appsv1.Deployment{DesiredDeployment(ovnRecon), DesiredCollectorDeployment(ovnRecon)} {
+		requests := reconcileRequestsForManagedDeployment(context.Background(), deployment)
+		if len(requests) != 1 || requests[0].NamespacedName != (types.NamespacedName{Name: "ovn-recon"}) {
+			t.Fatalf("expected Deployment %s to map to its OvnRecon, got %#v", deployment.Name, requests)
+		}
+	}
+
+	unmanaged := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:   "other",
+		Labels: map[string]string{"app.kubernetes.io/instance": "ovn-recon"},
+	}}
+	if requests := reconcileRequestsForManagedDeployment(context.Background(), unmanaged); len(requests) != 0 {
+		t.Fatalf("expected an unmanaged Deployment to be ignored, got %#v", requests)
+	}
+}
@@ -23,9 +23,12 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
@@ -35,21 +38,32 @@ import (
 
 const defaultCollectorRepository = "quay.io/dbewley/ovn-collector"
 
+const (
+	defaultConsolePluginDisplayName = "OVN Recon"
+	// pluginReplicas is fixed; the console plugin is stateless and not user-scalable.
+	pluginReplicas int32 = 1
+)
+
+const (
+	// collectorAuthTokenKey is the Secret data key holding the collector bearer token.
+	collectorAuthTokenKey = "token"
+	// collectorAuthTokenRotationAnnotation on an OvnRecon requests a new collector token
+	// whenever its value changes.
+	collectorAuthTokenRotationAnnotation = "ovnrecon.bewley.net/rotate-collector-token"
+)
+
 var defaultCollectorProbeNamespaces = []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"}
 
 // DesiredDeployment renders the Deployment for a given OvnRecon instance.
 func DesiredDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deployment {
 	namespace := targetNamespace(ovnRecon)
 	imageTag := imageTagFor(ovnRecon)
-	appLabels := labelsForOvnReconWithVersion(ovnRecon.Name, imageTag)
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, imageTag)
 	operatorAnnotations := operatorVersionAnnotations()
 
 	pullPolicy := imagePullPolicyFor(ovnRecon)
-	image := imageRepositoryFor(ovnRecon)
-	if imageTag != "" {
-		image = fmt.Sprintf("%s:%s", image, imageTag)
-	}
-	replicas := int32(1)
+	image := pluginImageFor(ovnRecon)
+	replicas := pluginReplicas
 
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -73,9 +87,14 @@ func DesiredDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deployment {
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
-					Labels: appLabels,
+					Labels:      appLabels,
+					Annotations: collectorAuthTokenRotationPodAnnotations(ovnRecon),
 				},
 				Spec: corev1.PodSpec{
+					ImagePullSecrets: imagePullSecrets(ovnRecon),
+					NodeSelector:     schedulingNodeSelector(ovnRecon.Spec.ConsolePlugin.Scheduling),
+					Tolerations:      schedulingTolerations(ovnRecon.Spec.ConsolePlugin.Scheduling),
+					Affinity:         schedulingAffinity(ovnRecon.Spec.ConsolePlugin.Scheduling),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: pointer.Bool(true),
 						SeccompProfile: &corev1.SeccompProfile{
@@ -85,16 +104,7 @@ func DesiredDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deployment {
 					Containers: []corev1.Container{{
 						Name:  "ovn-recon",
 						Image: image,
-						Env: []corev1.EnvVar{
-							{
-								Name:  "OVN_RECON_NGINX_ERROR_LOG_LEVEL",
-								Value: consolePluginErrorLogLevelFor(ovnRecon),
-							},
-							{
-								Name:  "OVN_RECON_NGINX_ACCESS_LOG",
-								Value: consolePluginAccessLogDirectiveFor(ovnRecon),
-							},
-						},
+						Env:   pluginEnvFor(ovnRecon),
 						Ports: []corev1.ContainerPort{{
 							ContainerPort: 9443,
 							Name:          "https",
@@ -109,16 +119,7 @@ func DesiredDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deployment {
 							ReadOnlyRootFilesystem: pointer.Bool(false),
 							RunAsNonRoot:           pointer.Bool(true),
 						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("50m"),
-								corev1.ResourceMemory: resource.MustParse("32Mi"),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("512Mi"),
-							},
-						},
+						Resources: pluginResources(),
 						LivenessProbe: &corev1.Probe{
 							ProbeHandler: corev1.ProbeHandler{
 								HTTPGet: &corev1.HTTPGetAction{
@@ -171,16 +172,13 @@ func DesiredCollectorDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deploym
 	namespace := targetNamespace(ovnRecon)
 	imageTag := collectorImageTagFor(ovnRecon)
 	name := collectorName(ovnRecon)
-	appLabels := labelsForOvnReconWithVersion(ovnRecon.Name, imageTag)
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, imageTag)
 	appLabels["app.kubernetes.io/component"] = "collector"
 	operatorAnnotations := operatorVersionAnnotations()
 
 	pullPolicy := collectorImagePullPolicyFor(ovnRecon)
-	image := collectorImageRepositoryFor(ovnRecon)
-	if imageTag != "" {
-		image = fmt.Sprintf("%s:%s", image, imageTag)
-	}
-	replicas := int32(1)
+	image := collectorImageFor(ovnRecon)
+	replicas := collectorReplicasFor(ovnRecon)
 
 	return &appsv1.Deployment{
 		TypeMeta: metav1.TypeMeta{
@@ -210,9 +208,14 @@ func DesiredCollectorDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deploym
 						"app.kubernetes.io/managed-by": "ovn-recon-operator",
 						"app.kubernetes.io/component":  "collector",
 					},
+					Annotations: collectorAuthTokenRotationPodAnnotations(ovnRecon),
 				},
 				Spec: corev1.PodSpec{
 					ServiceAccountName: collectorServiceAccountName(ovnRecon),
+					ImagePullSecrets:   imagePullSecrets(ovnRecon),
+					NodeSelector:       schedulingNodeSelector(ovnRecon.Spec.Collector.Scheduling),
+					Tolerations:        schedulingTolerations(ovnRecon.Spec.Collector.Scheduling),
+					Affinity:           schedulingAffinity(ovnRecon.Spec.Collector.Scheduling),
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: pointer.Bool(true),
 						SeccompProfile: &corev1.SeccompProfile{
@@ -223,20 +226,9 @@ func DesiredCollectorDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deploym
 						Name:            "ovn-collector",
 						Image:           image,
 						ImagePullPolicy: pullPolicy,
-						Env: []corev1.EnvVar{
-							{
-								Name:  "COLLECTOR_TARGET_NAMESPACES",
-								Value: strings.Join(collectorProbeNamespacesFor(ovnRecon), ","),
-							},
-							{
-								Name:  "COLLECTOR_LOG_LEVEL",
-								Value: collectorLogLevelFor(ovnRecon),
-							},
-							{
-								Name:  "COLLECTOR_INCLUDE_PROBE_OUTPUT",
-								Value: strconv.FormatBool(collectorIncludeProbeOutputFor(ovnRecon)),
-							},
-						},
+						Command:         ovnRecon.Spec.Collector.Command,
+						Args:            ovnRecon.Spec.Collector.Args,
+						Env:             collectorEnvFor(ovnRecon),
 						Ports: []corev1.ContainerPort{{
 							ContainerPort: 8090,
 							Name:          "http",
@@ -250,16 +242,8 @@ func DesiredCollectorDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deploym
 							ReadOnlyRootFilesystem: pointer.Bool(false),
 							RunAsNonRoot:           pointer.Bool(true),
 						},
-						Resources: corev1.ResourceRequirements{
-							Requests: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("50m"),
-								corev1.ResourceMemory: resource.MustParse("64Mi"),
-							},
-							Limits: corev1.ResourceList{
-								corev1.ResourceCPU:    resource.MustParse("500m"),
-								corev1.ResourceMemory: resource.MustParse("512Mi"),
-							},
-						},
+						Resources:    collectorResourcesFor(ovnRecon),
+						VolumeMounts: collectorVolumeMountsFor(ovnRecon),
 						LivenessProbe: &corev1.Probe{
 							ProbeHandler: corev1.ProbeHandler{
 								HTTPGet: &corev1.HTTPGetAction{
@@ -285,17 +269,95 @@ func DesiredCollectorDeployment(ovnRecon *reconv1beta1.OvnRecon) *appsv1.Deploym
 							FailureThreshold:    3,
 						},
 					}},
+					Volumes: collectorVolumesFor(ovnRecon),
 				},
 			},
 		},
 	}
 }
 
+// DesiredCollectorTrustedCABundle renders the empty ConfigMap that the OpenShift network
+// operator fills with the cluster-wide trusted CA bundle under trustedCABundleKey.
+func DesiredCollectorTrustedCABundle(ovnRecon *reconv1beta1.OvnRecon) *corev1.ConfigMap {
+	labels := labelsForOvnRecon(ovnRecon.Name)
+	labels["app.kubernetes.io/component"] = "collector"
+	labels[trustedCABundleInjectLabel] = "true"
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        collectorTrustedCABundleName(ovnRecon),
+			Namespace:   targetNamespace(ovnRecon),
+			Labels:      labels,
+			Annotations: mergeStringMap(nil, operatorVersionAnnotations()),
+		},
+	}
+}
+
+// DesiredCollectorConfigMap renders the ConfigMap that publishes the effective collector
+// configuration (probe namespaces, image and feature gate) for auditing and other tooling.
+func DesiredCollectorConfigMap(ovnRecon *reconv1beta1.OvnRecon) *corev1.ConfigMap {
+	labels := labelsForOvnRecon(ovnRecon.Name)
+	labels["app.kubernetes.io/component"] = "collector"
+
+	return &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        collectorConfigMapName(ovnRecon),
+			Namespace:   targetNamespace(ovnRecon),
+			Labels:      labels,
+			Annotations: mergeStringMap(nil, operatorVersionAnnotations()),
+		},
+		Data: map[string]string{
+			collectorConfigProbeNamespacesKey: strings.Join(collectorProbeNamespacesFor(ovnRecon), ","),
+			collectorConfigImageKey:           collectorImageFor(ovnRecon),
+			collectorConfigFeatureGateKey:     strconv.FormatBool(collectorFeatureEnabled(ovnRecon)),
+		},
+	}
+}
+
+// collectorVolumesFor and collectorVolumeMountsFor mount the injected bundle over the path the
+// Go runtime reads system roots from on RHEL-based images.
+func collectorVolumesFor(ovnRecon *reconv1beta1.OvnRecon) []corev1.Volume {
+	if !ovnRecon.Spec.Collector.InjectTrustedCABundle {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: trustedCABundleVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			ConfigMap: &corev1.ConfigMapVolumeSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: collectorTrustedCABundleName(ovnRecon)},
+				Items: []corev1.KeyToPath{{
+					Key:  trustedCABundleKey,
+					Path: "tls-ca-bundle.pem",
+				}},
+			},
+		},
+	}}
+}
+
+func collectorVolumeMountsFor(ovnRecon *reconv1beta1.OvnRecon) []corev1.VolumeMount {
+	if !ovnRecon.Spec.Collector.InjectTrustedCABundle {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      trustedCABundleVolumeName,
+		MountPath: trustedCABundleMountPath,
+		ReadOnly:  true,
+	}}
+}
+
 // DesiredCollectorService renders the collector Service for a given OvnRecon instance.
 func DesiredCollectorService(ovnRecon *reconv1beta1.OvnRecon) *corev1.Service {
 	namespace := targetNamespace(ovnRecon)
 	name := collectorName(ovnRecon)
-	appLabels := labelsForOvnReconWithVersion(ovnRecon.Name, collectorImageTagFor(ovnRecon))
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, collectorImageTagFor(ovnRecon))
 	appLabels["app.kubernetes.io/component"] = "collector"
 
 	return &corev1.Service{
@@ -324,10 +386,68 @@ func DesiredCollectorService(ovnRecon *reconv1beta1.OvnRecon) *corev1.Service {
 	}
 }
 
+// DesiredPluginEgressNetworkPolicy renders the egress NetworkPolicy for the plugin pods. It allows
+// DNS lookups and connections to the collector so nginx keeps working under default-deny egress.
+func DesiredPluginEgressNetworkPolicy(ovnRecon *reconv1beta1.OvnRecon) *networkingv1.NetworkPolicy {
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, imageTagFor(ovnRecon))
+	udp := corev1.ProtocolUDP
+	tcp := corev1.ProtocolTCP
+	dnsPort := intstr.FromInt32(53)
+	dnsAltPort := intstr.FromInt32(5353)
+	collectorPort := intstr.FromInt32(8090)
+
+	return &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "networking.k8s.io/v1",
+			Kind:       "NetworkPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pluginEgressNetworkPolicyName(ovnRecon),
+			Namespace:   targetNamespace(ovnRecon),
+			Labels:      appLabels,
+			Annotations: operatorVersionAnnotations(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "ovn-recon",
+					"app.kubernetes.io/instance":  ovnRecon.Name,
+					"app.kubernetes.io/component": "plugin",
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress: []networkingv1.NetworkPolicyEgressRule{
+				{
+					// OpenShift cluster DNS listens on 5353 behind the 53 Service port.
+					To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+					Ports: []networkingv1.NetworkPolicyPort{
+						{Protocol: &udp, Port: &dnsPort},
+						{Protocol: &tcp, Port: &dnsPort},
+						{Protocol: &udp, Port: &dnsAltPort},
+						{Protocol: &tcp, Port: &dnsAltPort},
+					},
+				},
+				{
+					To: []networkingv1.NetworkPolicyPeer{{
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"app.kubernetes.io/name":      "ovn-recon",
+								"app.kubernetes.io/instance":  ovnRecon.Name,
+								"app.kubernetes.io/component": "collector",
+							},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{{Protocol: &tcp, Port: &collectorPort}},
+				},
+			},
+		},
+	}
+}
+
 // DesiredService renders the Service for a given OvnRecon instance.
 func DesiredService(ovnRecon *reconv1beta1.OvnRecon) *corev1.Service {
 	namespace := targetNamespace(ovnRecon)
-	appLabels := labelsForOvnReconWithVersion(ovnRecon.Name, imageTagFor(ovnRecon))
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, imageTagFor(ovnRecon))
 	annotations := mergeStringMap(nil, operatorVersionAnnotations())
 	annotations["service.alpha.openshift.io/serving-cert-secret-name"] = "plugin-serving-cert"
 	annotations["service.beta.openshift.io/serving-cert-secret-name"] = "plugin-serving-cert"
@@ -349,8 +469,9 @@ func DesiredService(ovnRecon *reconv1beta1.OvnRecon) *corev1.Service {
 				"app.kubernetes.io/instance":  ovnRecon.Name,
 				"app.kubernetes.io/component": "plugin",
 			},
+			Type: pluginServiceTypeFor(ovnRecon),
 			Ports: []corev1.ServicePort{{
-				Port:       9443,
+				Port:       pluginServicePortFor(ovnRecon),
 				TargetPort: intstr.FromInt32(9443),
 				Name:       "https",
 			}},
@@ -358,6 +479,49 @@ func DesiredService(ovnRecon *reconv1beta1.OvnRecon) *corev1.Service {
 	}
 }
 
+// DesiredPluginPodDisruptionBudget renders the PodDisruptionBudget for the console plugin pods.
+func DesiredPluginPodDisruptionBudget(ovnRecon *reconv1beta1.OvnRecon) *policyv1.PodDisruptionBudget {
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, imageTagFor(ovnRecon))
+	return desiredPodDisruptionBudget(ovnRecon, ovnRecon.Name, "plugin", appLabels, ovnRecon.Spec.ConsolePlugin.PodDisruptionBudget)
+}
+
+// DesiredCollectorPodDisruptionBudget renders the PodDisruptionBudget for the collector pods.
+func DesiredCollectorPodDisruptionBudget(ovnRecon *reconv1beta1.OvnRecon) *policyv1.PodDisruptionBudget {
+	appLabels := labelsForOvnReconWithVersion(ovnRecon, collectorImageTagFor(ovnRecon))
+	appLabels["app.kubernetes.io/component"] = "collector"
+	return desiredPodDisruptionBudget(ovnRecon, collectorName(ovnRecon), "collector", appLabels, ovnRecon.Spec.Collector.PodDisruptionBudget)
+}
+
+func desiredPodDisruptionBudget(ovnRecon *reconv1beta1.OvnRecon, name, component string, appLabels map[string]string, spec reconv1beta1.PodDisruptionBudgetSpec) *policyv1.PodDisruptionBudget {
+	minAvailable := intstr.FromInt32(1)
+	if spec.MinAvailable != nil {
+		minAvailable = *spec.MinAvailable
+	}
+
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "policy/v1",
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   targetNamespace(ovnRecon),
+			Labels:      appLabels,
+			Annotations: operatorVersionAnnotations(),
+		},
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable: &minAvailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":      "ovn-recon",
+					"app.kubernetes.io/instance":  ovnRecon.Name,
+					"app.kubernetes.io/component": component,
+				},
+			},
+		},
+	}
+}
+
 func collectorImageRepositoryFor(ovnRecon *reconv1beta1.OvnRecon) string {
 	if ovnRecon.Spec.Collector.Image.Repository != "" {
 		return ovnRecon.Spec.Collector.Image.Repository
@@ -379,6 +543,30 @@ func collectorImageTagFor(ovnRecon *reconv1beta1.OvnRecon) string {
 	return imageTagFor(ovnRecon)
 }
 
+// collectorImageFor returns the full collector image reference, with its tag when one is set.
+func collectorImageFor(ovnRecon *reconv1beta1.OvnRecon) string {
+	image := collectorImageRepositoryFor(ovnRecon)
+	if imageTag := collectorImageTagFor(ovnRecon); imageTag != "" {
+		image = fmt.Sprintf("%s:%s", image, imageTag)
+	}
+	return image
+}
+
+func pluginImageFor(ovnRecon *reconv1beta1.OvnRecon) string {
+	image := imageRepositoryFor(ovnRecon)
+	if imageTag := imageTagFor(ovnRecon); imageTag != "" {
+		image = fmt.Sprintf("%s:%s", image, imageTag)
+	}
+	return image
+}
+
+func consolePluginDisplayNameFor(ovnRecon *reconv1beta1.OvnRecon) string {
+	if ovnRecon.Spec.ConsolePlugin.DisplayName != "" {
+		return ovnRecon.Spec.ConsolePlugin.DisplayName
+	}
+	return defaultConsolePluginDisplayName
+}
+
 func collectorImagePullPolicyFor(ovnRecon *reconv1beta1.OvnRecon) corev1.PullPolicy {
 	if ovnRecon.Spec.Collector.Image.PullPolicy != "" {
 		return corev1.PullPolicy(ovnRecon.Spec.Collector.Image.PullPolicy)
@@ -389,6 +577,99 @@ func collectorImagePullPolicyFor(ovnRecon *reconv1beta1.OvnRecon) corev1.PullPol
 	return imagePullPolicyFor(ovnRecon)
 }
 
+// ConflictingImageFields lists image fields set in both the legacy and hierarchical spec with
+// different values. The hierarchical value always wins; the list only explains that to users.
+func ConflictingImageFields(ovnRecon *reconv1beta1.OvnRecon) []string {
+	spec := ovnRecon.Spec
+	pairs := []struct {
+		legacyField, legacy, hierarchicalField, hierarchical string
+	}{
+		{"image.repository", spec.Image.Repository, "consolePlugin.image.repository", spec.ConsolePlugin.Image.Repository},
+		{"image.tag", spec.Image.Tag, "consolePlugin.image.tag", spec.ConsolePlugin.Image.Tag},
+		{"image.pullPolicy", spec.Image.PullPolicy, "consolePlugin.image.pullPolicy", spec.ConsolePlugin.Image.PullPolicy},
+		{"collectorImage.repository", spec.CollectorImage.Repository, "collector.image.repository", spec.Collector.Image.Repository},
+		{"collectorImage.tag", spec.CollectorImage.Tag, "collector.image.tag", spec.Collector.Image.Tag},
+		{"collectorImage.pullPolicy", spec.CollectorImage.PullPolicy, "collector.image.pullPolicy", spec.Collector.Image.PullPolicy},
+	}
+
+	conflicts := []string{}
+	for _, pair := range pairs {
+		if pair.legacy == "" || pair.hierarchical == "" || pair.legacy == pair.hierarchical {
+			continue
+		}
+		conflicts = append(conflicts, fmt.Sprintf("%s=%q overrides %s=%q", pair.hierarchicalField, pair.hierarchical, pair.legacyField, pair.legacy))
+	}
+	return conflicts
+}
+
+func collectorReplicasFor(ovnRecon *reconv1beta1.OvnRecon) int32 {
+	if ovnRecon.Spec.Collector.Replicas != nil && *ovnRecon.Spec.Collector.Replicas > 0 {
+		return *ovnRecon.Spec.Collector.Replicas
+	}
+	return 1
+}
+
+func pluginServiceTypeFor(ovnRecon *reconv1beta1.OvnRecon) corev1.ServiceType {
+	if ovnRecon.Spec.ConsolePlugin.Service.Type != "" {
+		return ovnRecon.Spec.ConsolePlugin.Service.Type
+	}
+	return corev1.ServiceTypeClusterIP
+}
+
+// pluginServicePortFor returns the plugin Service port. The ConsolePlugin backend uses the same
+// value so the console always dials the port the Service exposes.
+func pluginServicePortFor(ovnRecon *reconv1beta1.OvnRecon) int32 {
+	if ovnRecon.Spec.ConsolePlugin.Service.Port > 0 {
+		return ovnRecon.Spec.ConsolePlugin.Service.Port
+	}
+	return 9443
+}
+
+// pluginResources returns the console plugin container resources. The plugin only serves
+// static assets and proxies API calls, so it requests less memory than the collector.
+func pluginResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("32Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+}
+
+// defaultCollectorResources returns the collector container defaults. The collector buffers
+// whole probe outputs in memory, so its request is higher than the plugin's.
+func defaultCollectorResources() corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("50m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	}
+}
+
+// collectorResourcesFor returns the collector container resources, keeping the default
+// requests or limits for whichever of the two the spec leaves empty. The override never
+// touches the plugin container.
+func collectorResourcesFor(ovnRecon *reconv1beta1.OvnRecon) corev1.ResourceRequirements {
+	resources := *ovnRecon.Spec.Collector.Resources.DeepCopy()
+	defaults := defaultCollectorResources()
+	if len(resources.Requests) == 0 {
+		resources.Requests = defaults.Requests
+	}
+	if len(resources.Limits) == 0 {
+		resources.Limits = defaults.Limits
+	}
+	return resources
+}
+
 func collectorProbeNamespacesFor(ovnRecon *reconv1beta1.OvnRecon) []string {
 	if len(ovnRecon.Spec.Collector.ProbeNamespaces) != 0 {
 		return append([]string{}, ovnRecon.Spec.Collector.ProbeNamespaces...)
@@ -399,6 +680,15 @@ func collectorProbeNamespacesFor(ovnRecon *reconv1beta1.OvnRecon) []string {
 	return append([]string{}, ovnRecon.Spec.CollectorProbeNamespaces...)
 }
 
+// collectorRBACNamespacesFor lists the namespaces that get a collector RoleBinding, which are
+// the probe namespaces unless collector.rbacNamespaces grants a wider set.
+func collectorRBACNamespacesFor(ovnRecon *reconv1beta1.OvnRecon) []string {
+	if len(ovnRecon.Spec.Collector.RBACNamespaces) != 0 {
+		return append([]string{}, ovnRecon.Spec.Collector.RBACNamespaces...)
+	}
+	return collectorProbeNamespacesFor(ovnRecon)
+}
+
 func collectorLogLevelFor(ovnRecon *reconv1beta1.OvnRecon) string {
 	if strings.TrimSpace(ovnRecon.Spec.Collector.Logging.Level) != "" {
 		return strings.ToLower(strings.TrimSpace(ovnRecon.Spec.Collector.Logging.Level))
@@ -410,6 +700,120 @@ func collectorIncludeProbeOutputFor(ovnRecon *reconv1beta1.OvnRecon) bool {
 	return ovnRecon.Spec.Collector.Logging.IncludeProbeOutput
 }
 
+func collectorEnvFor(ovnRecon *reconv1beta1.OvnRecon) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{
+			Name:  "COLLECTOR_TARGET_NAMESPACES",
+			Value: strings.Join(collectorProbeNamespacesFor(ovnRecon), ","),
+		},
+		{
+			Name:  "COLLECTOR_LOG_LEVEL",
+			Value: collectorLogLevelFor(ovnRecon),
+		},
+		{
+			Name:  "COLLECTOR_INCLUDE_PROBE_OUTPUT",
+			Value: strconv.FormatBool(collectorIncludeProbeOutputFor(ovnRecon)),
+		},
+	}
+	if clusterID := strings.TrimSpace(ovnRecon.Spec.ClusterID); clusterID != "" {
+		env = append(env, corev1.EnvVar{Name: "COLLECTOR_CLUSTER_ID", Value: clusterID})
+	}
+	if len(ovnRecon.Spec.Collector.NbctlArgs) > 0 {
+		env = append(env, corev1.EnvVar{Name: "COLLECTOR_NBCTL_EXTRA_ARGS", Value: strings.Join(ovnRecon.Spec.Collector.NbctlArgs, " ")})
+	}
+	if len(ovnRecon.Spec.Collector.PodSelector) > 0 {
+		env = append(env, corev1.EnvVar{Name: "COLLECTOR_POD_SELECTOR", Value: labels.SelectorFromSet(ovnRecon.Spec.Collector.PodSelector).String()})
+	}
+	env = append(env, collectorAuthTokenEnv(ovnRecon, "COLLECTOR_AUTH_TOKEN"))
+	return appendUserEnv(env, ovnRecon.Spec.Collector.Env)
+}
+
+func pluginEnvFor(ovnRecon *reconv1beta1.OvnRecon) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{
+			Name:  "OVN_RECON_NGINX_ERROR_LOG_LEVEL",
+			Value: consolePluginErrorLogLevelFor(ovnRecon),
+		},
+		{
+			Name:  "OVN_RECON_NGINX_ACCESS_LOG",
+			Value: consolePluginAccessLogDirectiveFor(ovnRecon),
+		},
+	}
+	if collectorFeatureEnabled(ovnRecon) {
+		// The plugin nginx proxy forwards this token to the collector as a bearer token.
+		env = append(env, collectorAuthTokenEnv(ovnRecon, "OVN_RECON_NGINX_COLLECTOR_AUTH_TOKEN"))
+	}
+	return appendUserEnv(env, ovnRecon.Spec.ConsolePlugin.Env)
+}
+
+// appendUserEnv appends the user's extra environment variables after the operator's, skipping
+// any whose name the operator already sets so managed settings cannot be overridden.
+func appendUserEnv(env, extra []corev1.EnvVar) []corev1.EnvVar {
+	managed := make(map[string]bool, len(env))
+	for _, envVar := range env {
+		managed[envVar.Name] = true
+	}
+	for _, envVar := range extra {
+		if managed[envVar.Name] {
+			continue
+		}
+		env = append(env, *envVar.DeepCopy())
+	}
+	return env
+}
+
+// collectorAuthTokenEnv references the collector auth token Secret. The reference is required,
+// so pods started before the Secret exists wait for it instead of running without a token.
+func collectorAuthTokenEnv(ovnRecon *reconv1beta1.OvnRecon, name string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: collectorAuthSecretName(ovnRecon)},
+				Key:                  collectorAuthTokenKey,
+			},
+		},
+	}
+}
+
+// collectorAuthTokenRotationPodAnnotations copies the rotation request onto pod templates so a
+// rotated token rolls both the plugin and collector pods.
+func collectorAuthTokenRotationPodAnnotations(ovnRecon *reconv1beta1.OvnRecon) map[string]string {
+	rotation := ovnRecon.Annotations[collectorAuthTokenRotationAnnotation]
+	if rotation == "" {
+		return nil
+	}
+	return map[string]string{collectorAuthTokenRotationAnnotation: rotation}
+}
+
+// DesiredCollectorAuthSecret renders the Secret holding the bearer token shared by the plugin
+// proxy and the collector. The applied rotation request is recorded on the Secret.
+func DesiredCollectorAuthSecret(ovnRecon *reconv1beta1.OvnRecon, token []byte) *corev1.Secret {
+	labels := labelsForOvnRecon(ovnRecon.Name)
+	labels["app.kubernetes.io/component"] = "collector"
+	annotations := mergeStringMap(nil, operatorVersionAnnotations())
+	if rotation := ovnRecon.Annotations[collectorAuthTokenRotationAnnotation]; rotation != "" {
+		annotations[collectorAuthTokenRotationAnnotation] = rotation
+	}
+
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        collectorAuthSecretName(ovnRecon),
+			Namespace:   targetNamespace(ovnRecon),
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			collectorAuthTokenKey: token,
+		},
+	}
+}
+
 func consolePluginErrorLogLevelFor(ovnRecon *reconv1beta1.OvnRecon) string {
 	level := strings.ToLower(strings.TrimSpace(ovnRecon.Spec.ConsolePlugin.Logging.Level))
 	switch level {
@@ -429,10 +833,7 @@ func consolePluginAccessLogDirectiveFor(ovnRecon *reconv1beta1.OvnRecon) string
 
 // DesiredConsolePlugin renders the ConsolePlugin for a given OvnRecon instance.
 func DesiredConsolePlugin(ovnRecon *reconv1beta1.OvnRecon) *unstructured.Unstructured {
-	displayName := ovnRecon.Spec.ConsolePlugin.DisplayName
-	if displayName == "" {
-		displayName = "OVN Recon"
-	}
+	displayName := consolePluginDisplayNameFor(ovnRecon)
 
 	plugin := &unstructured.Unstructured{}
 	plugin.SetGroupVersionKind(schema.GroupVersionKind{
@@ -454,7 +855,7 @@ func DesiredConsolePlugin(ovnRecon *reconv1beta1.OvnRecon) *unstructured.Unstruc
 			"service": map[string]interface{}{
 				"name":      ovnRecon.Name,
 				"namespace": targetNamespace(ovnRecon),
-				"port":      9443,
+				"port":      int64(pluginServicePortFor(ovnRecon)),
 				"basePath":  "/",
 			},
 		},
@@ -463,6 +864,38 @@ func DesiredConsolePlugin(ovnRecon *reconv1beta1.OvnRecon) *unstructured.Unstruc
 	return plugin
 }
 
+// imagePullSecrets returns the shared pull secret reference for plugin and collector pods.
+func imagePullSecrets(ovnRecon *reconv1beta1.OvnRecon) []corev1.LocalObjectReference {
+	name := strings.TrimSpace(ovnRecon.Spec.PullSecretName)
+	if name == "" {
+		return nil
+	}
+	return []corev1.LocalObjectReference{{Name: name}}
+}
+
+// The scheduling helpers copy so rendered pod templates never alias the OvnRecon spec.
+func schedulingNodeSelector(scheduling reconv1beta1.SchedulingSpec) map[string]string {
+	if len(scheduling.NodeSelector) == 0 {
+		return nil
+	}
+	return mergeStringMap(nil, scheduling.NodeSelector)
+}
+
+func schedulingTolerations(scheduling reconv1beta1.SchedulingSpec) []corev1.Toleration {
+	if len(scheduling.Tolerations) == 0 {
+		return nil
+	}
+	tolerations := make([]corev1.Toleration, 0, len(scheduling.Tolerations))
+	for i := range scheduling.Tolerations {
+		tolerations = append(tolerations, *scheduling.Tolerations[i].DeepCopy())
+	}
+	return tolerations
+}
+
+func schedulingAffinity(scheduling reconv1beta1.SchedulingSpec) *corev1.Affinity {
+	return scheduling.Affinity.DeepCopy()
+}
+
 func mergeStringMap(dst, src map[string]string) map[string]string {
 	if dst == nil {
 		dst = map[string]string{}
@@ -1,8 +1,14 @@
 package controller
 
 import (
+	"reflect"
+
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"testing"
 
 	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
@@ -84,6 +90,43 @@ func TestDesiredDeploymentUsesPluginImageFallbacks(t *testing.T) {
 	}
 }
 
+func TestVersionLabelPolicy(t *testing.T) {
+	const digest = "sha256:4f1d2c"
+	tests := []struct {
+		name   string
+		policy string
+		tag    string
+		want   string
+		wantOK bool
+	}{
+		{name: "sanitize rewrites a digest", policy: "", tag: digest, want: "sha256-4f1d2c", wantOK: true},
+		{name: "omit drops a digest", policy: reconv1beta1.VersionLabelPolicyOmit, tag: digest},
+		{name: "omit keeps a valid tag", policy: reconv1beta1.VersionLabelPolicyOmit, tag: "v1.2.3", want: "v1.2.3", wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cr := &reconv1beta1.OvnRecon{
+				ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+				Spec: reconv1beta1.OvnReconSpec{
+					Operator:      reconv1beta1.OperatorSpec{VersionLabelPolicy: tt.policy},
+					ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{Tag: tt.tag}},
+				},
+			}
+
+			for kind, labels := range map[string]map[string]string{
+				"deployment":   DesiredDeployment(cr).Labels,
+				"pod template": DesiredDeployment(cr).Spec.Template.Labels,
+				"service":      DesiredService(cr).Labels,
+			} {
+				got, ok := labels["app.kubernetes.io/version"]
+				if ok != tt.wantOK || got != tt.want {
+					t.Fatalf("%s version label = %q (present=%v), want %q (present=%v)", kind, got, ok, tt.want, tt.wantOK)
+				}
+			}
+		})
+	}
+}
+
 func TestConsolePluginLoggingEnvOverrides(t *testing.T) {
 	cr := &reconv1beta1.OvnRecon{
 		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
@@ -225,6 +268,106 @@ func TestPluginServiceSelectorTargetsPluginComponentOnly(t *testing.T) {
 	}
 }
 
+func TestPluginServiceDefaultsToClusterIP(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	}
+
+	svc := DesiredService(cr)
+	if svc.Spec.Type != corev1.ServiceTypeClusterIP {
+		t.Fatalf("service type = %q, want ClusterIP", svc.Spec.Type)
+	}
+	if svc.Spec.Ports[0].Port != 9443 {
+		t.Fatalf("service port = %d, want 9443", svc.Spec.Ports[0].Port)
+	}
+	port, _, _ := unstructured.NestedInt64(DesiredConsolePlugin(cr).Object, "spec", "backend", "service", "port")
+	if port != 9443 {
+		t.Fatalf("console plugin backend port = %d, want 9443", port)
+	}
+}
+
+func TestPluginServiceTypeAndPortOverride(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				Service: reconv1beta1.ConsolePluginServiceSpec{
+					Type: corev1.ServiceTypeNodePort,
+					Port: 8443,
+				},
+			},
+		},
+	}
+
+	svc := DesiredService(cr)
+	if svc.Spec.Type != corev1.ServiceTypeNodePort {
+		t.Fatalf("service type = %q, want NodePort", svc.Spec.Type)
+	}
+	if svc.Spec.Ports[0].Port != 8443 {
+		t.Fatalf("service port = %d, want 8443", svc.Spec.Ports[0].Port)
+	}
+	if svc.Spec.Ports[0].TargetPort != intstr.FromInt32(9443) {
+		t.Fatalf("service target port = %v, want container port 9443", svc.Spec.Ports[0].TargetPort)
+	}
+
+	port, found, err := unstructured.NestedInt64(DesiredConsolePlugin(cr).Object, "spec", "backend", "service", "port")
+	if err != nil || !found {
+		t.Fatalf("console plugin backend port missing: found=%v err=%v", found, err)
+	}
+	if port != 8443 {
+		t.Fatalf("console plugin backend port = %d, want 8443", port)
+	}
+}
+
+func TestPluginEgressNetworkPolicyTargetsCollectorPort(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "recon-ns",
+			NetworkPolicy:   reconv1beta1.NetworkPolicySpec{Enabled: true},
+		},
+	}
+
+	policy := DesiredPluginEgressNetworkPolicy(cr)
+	if policy.Name != "ovn-recon-plugin-egress" || policy.Namespace != "recon-ns" {
+		t.Fatalf("unexpected policy name/namespace: %s/%s", policy.Namespace, policy.Name)
+	}
+	if policy.Spec.PodSelector.MatchLabels["app.kubernetes.io/component"] != "plugin" {
+		t.Fatalf("expected policy to select plugin pods, got %#v", policy.Spec.PodSelector.MatchLabels)
+	}
+	if len(policy.Spec.PolicyTypes) != 1 || policy.Spec.PolicyTypes[0] != networkingv1.PolicyTypeEgress {
+		t.Fatalf("expected egress-only policy, got %#v", policy.Spec.PolicyTypes)
+	}
+
+	collectorService := DesiredCollectorService(cr)
+	foundCollectorRule := false
+	foundDNSRule := false
+	for _, rule := range policy.Spec.Egress {
+		for _, port := range rule.Ports {
+			if port.Port == nil {
+				continue
+			}
+			if port.Port.IntVal == 53 {
+				foundDNSRule = true
+			}
+			if port.Port.IntVal != collectorService.Spec.Ports[0].TargetPort.IntVal {
+				continue
+			}
+			for _, peer := range rule.To {
+				if peer.PodSelector != nil && peer.PodSelector.MatchLabels["app.kubernetes.io/component"] == collectorService.Spec.Selector["app.kubernetes.io/component"] {
+					foundCollectorRule = true
+				}
+			}
+		}
+	}
+	if !foundCollectorRule {
+		t.Fatalf("expected egress rule to collector pods on port %d, got %#v", collectorService.Spec.Ports[0].TargetPort.IntVal, policy.Spec.Egress)
+	}
+	if !foundDNSRule {
+		t.Fatalf("expected DNS egress rule, got %#v", policy.Spec.Egress)
+	}
+}
+
 func TestCollectorLoggingEnvOverrides(t *testing.T) {
 	cr := &reconv1beta1.OvnRecon{
 		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
@@ -249,6 +392,431 @@ func TestCollectorLoggingEnvOverrides(t *testing.T) {
 	}
 }
 
+func TestCollectorDeploymentReplicasAndResources(t *testing.T) {
+	defaultDep := DesiredCollectorDeployment(&reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	})
+	if got := *defaultDep.Spec.Replicas; got != 1 {
+		t.Fatalf("expected default collector replicas=1, got %d", got)
+	}
+	defaultResources := defaultDep.Spec.Template.Spec.Containers[0].Resources
+	if got := defaultResources.Requests.Memory().String(); got != "64Mi" {
+		t.Fatalf("expected default collector memory request 64Mi, got %s", got)
+	}
+	if got := defaultResources.Limits.Memory().String(); got != "512Mi" {
+		t.Fatalf("expected default collector memory limit 512Mi, got %s", got)
+	}
+
+	replicas := int32(3)
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				Replicas: &replicas,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceCPU:    resource.MustParse("200m"),
+						corev1.ResourceMemory: resource.MustParse("256Mi"),
+					},
+				},
+			},
+		},
+	}
+
+	dep := DesiredCollectorDeployment(cr)
+	if got := *dep.Spec.Replicas; got != 3 {
+		t.Fatalf("expected collector replicas=3, got %d", got)
+	}
+	resources := dep.Spec.Template.Spec.Containers[0].Resources
+	if got := resources.Requests.Cpu().String(); got != "200m" {
+		t.Fatalf("expected collector cpu request 200m, got %s", got)
+	}
+	if got := resources.Requests.Memory().String(); got != "256Mi" {
+		t.Fatalf("expected collector memory request 256Mi, got %s", got)
+	}
+	if got := resources.Limits.Memory().String(); got != "512Mi" {
+		t.Fatalf("expected unset limits to keep default 512Mi, got %s", got)
+	}
+	if _, ok := cr.Spec.Collector.Resources.Limits[corev1.ResourceMemory]; ok {
+		t.Fatalf("expected defaulting not to mutate the OvnRecon spec")
+	}
+}
+
+func TestCollectorPodDisruptionBudgetWithMultipleReplicas(t *testing.T) {
+	replicas := int32(3)
+	minAvailable := intstr.FromInt32(2)
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "recon",
+			Collector: reconv1beta1.CollectorSpec{
+				Replicas: &replicas,
+				PodDisruptionBudget: reconv1beta1.PodDisruptionBudgetSpec{
+					Enabled:      true,
+					MinAvailable: &minAvailable,
+				},
+			},
+		},
+	}
+
+	pdb := DesiredCollectorPodDisruptionBudget(cr)
+	if pdb.Name != "ovn-recon-collector" || pdb.Namespace != "recon" {
+		t.Fatalf("unexpected collector PDB identity %s/%s", pdb.Namespace, pdb.Name)
+	}
+	if got := pdb.Spec.MinAvailable; got == nil || got.IntValue() != 2 {
+		t.Fatalf("expected collector PDB minAvailable=2, got %v", got)
+	}
+	dep := DesiredCollectorDeployment(cr)
+	if !reflect.DeepEqual(pdb.Spec.Selector.MatchLabels, dep.Spec.Selector.MatchLabels) {
+		t.Fatalf("expected PDB selector %v to match collector Deployment selector %v", pdb.Spec.Selector.MatchLabels, dep.Spec.Selector.MatchLabels)
+	}
+
+	pluginPDB := DesiredPluginPodDisruptionBudget(cr)
+	if got := pluginPDB.Spec.MinAvailable; got == nil || got.IntValue() != 1 {
+		t.Fatalf("expected plugin PDB minAvailable to default to 1, got %v", got)
+	}
+	if !reflect.DeepEqual(pluginPDB.Spec.Selector.MatchLabels, DesiredDeployment(cr).Spec.Selector.MatchLabels) {
+		t.Fatalf("expected plugin PDB selector to match the plugin Deployment selector, got %v", pluginPDB.Spec.Selector.MatchLabels)
+	}
+}
+
+func TestCollectorResourcesOverrideLeavesPluginDefaults(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("1Gi"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+		},
+	}
+
+	collector := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0].Resources
+	if got := collector.Requests.Memory().String(); got != "1Gi" {
+		t.Fatalf("expected collector memory request 1Gi, got %s", got)
+	}
+	if got := collector.Limits.Memory().String(); got != "2Gi" {
+		t.Fatalf("expected collector memory limit 2Gi, got %s", got)
+	}
+
+	plugin := DesiredDeployment(cr).Spec.Template.Spec.Containers[0].Resources
+	if got := plugin.Requests.Memory().String(); got != "32Mi" {
+		t.Fatalf("expected plugin memory request to keep 32Mi default, got %s", got)
+	}
+	if got := plugin.Limits.Memory().String(); got != "512Mi" {
+		t.Fatalf("expected plugin memory limit to keep 512Mi default, got %s", got)
+	}
+	if got := plugin.Requests.Cpu().String(); got != "50m" {
+		t.Fatalf("expected plugin cpu request to keep 50m default, got %s", got)
+	}
+
+	defaults := DesiredCollectorDeployment(&reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	}).Spec.Template.Spec.Containers[0].Resources
+	if defaults.Requests.Memory().Cmp(*plugin.Requests.Memory()) <= 0 {
+		t.Fatalf("expected collector default memory request %s to exceed plugin default %s",
+			defaults.Requests.Memory(), plugin.Requests.Memory())
+	}
+}
+
+func TestCollectorAuthTokenReferencedByCollectorAndPluginProxy(t *testing.T) {
+	enabled := true
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "ovn-recon",
+			Annotations: map[string]string{collectorAuthTokenRotationAnnotation: "r1"},
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector:       reconv1beta1.CollectorSpec{Enabled: &enabled},
+		},
+	}
+
+	secret := DesiredCollectorAuthSecret(cr, []byte("tok"))
+	if secret.Name != "ovn-recon-collector-auth" || secret.Namespace != "ovn-recon" {
+		t.Fatalf("unexpected collector auth secret %s/%s", secret.Namespace, secret.Name)
+	}
+	if string(secret.Data[collectorAuthTokenKey]) != "tok" {
+		t.Fatalf("expected token under key %q, got %#v", collectorAuthTokenKey, secret.Data)
+	}
+
+	assertSecretRef := func(t *testing.T, env []corev1.EnvVar, name string) {
+		t.Helper()
+		for _, item := range env {
+			if item.Name != name {
+				continue
+			}
+			if item.ValueFrom == nil || item.ValueFrom.SecretKeyRef == nil {
+				t.Fatalf("expected %s to come from a Secret, got %#v", name, item)
+			}
+			ref := item.ValueFrom.SecretKeyRef
+			if ref.Name != secret.Name || ref.Key != collectorAuthTokenKey {
+				t.Fatalf("expected %s to reference %s/%s, got %s/%s", name, secret.Name, collectorAuthTokenKey, ref.Name, ref.Key)
+			}
+			return
+		}
+		t.Fatalf("expected env %s to be set", name)
+	}
+
+	collector := DesiredCollectorDeployment(cr)
+	assertSecretRef(t, collector.Spec.Template.Spec.Containers[0].Env, "COLLECTOR_AUTH_TOKEN")
+	plugin := DesiredDeployment(cr)
+	assertSecretRef(t, plugin.Spec.Template.Spec.Containers[0].Env, "OVN_RECON_NGINX_COLLECTOR_AUTH_TOKEN")
+
+	if got := collector.Spec.Template.Annotations[collectorAuthTokenRotationAnnotation]; got != "r1" {
+		t.Fatalf("expected collector pod template to carry rotation annotation, got %q", got)
+	}
+	if got := plugin.Spec.Template.Annotations[collectorAuthTokenRotationAnnotation]; got != "r1" {
+		t.Fatalf("expected plugin pod template to carry rotation annotation, got %q", got)
+	}
+
+	disabled := false
+	cr.Spec.Collector.Enabled = &disabled
+	if _, ok := envValue(DesiredDeployment(cr).Spec.Template.Spec.Containers[0].Env, "OVN_RECON_NGINX_COLLECTOR_AUTH_TOKEN"); ok {
+		t.Fatalf("expected no collector token env on the plugin when the collector is disabled")
+	}
+}
+
+func TestDeploymentsApplySchedulingConstraints(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	}
+	for _, podSpec := range []corev1.PodSpec{
+		DesiredDeployment(defaultCR).Spec.Template.Spec,
+		DesiredCollectorDeployment(defaultCR).Spec.Template.Spec,
+	} {
+		if podSpec.NodeSelector != nil || podSpec.Tolerations != nil || podSpec.Affinity != nil {
+			t.Fatalf("expected no scheduling constraints by default, got %#v %#v %#v", podSpec.NodeSelector, podSpec.Tolerations, podSpec.Affinity)
+		}
+	}
+
+	infra := reconv1beta1.SchedulingSpec{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/infra": ""},
+		Tolerations: []corev1.Toleration{{
+			Key:      "node-role.kubernetes.io/infra",
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		}},
+		Affinity: &corev1.Affinity{
+			NodeAffinity: &corev1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+						MatchExpressions: []corev1.NodeSelectorRequirement{{
+							Key:      "kubernetes.io/arch",
+							Operator: corev1.NodeSelectorOpIn,
+							Values:   []string{"amd64"},
+						}},
+					}},
+				},
+			},
+		},
+	}
+	collectorScheduling := reconv1beta1.SchedulingSpec{
+		NodeSelector: map[string]string{"node-role.kubernetes.io/worker": ""},
+	}
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{Scheduling: infra},
+			Collector:     reconv1beta1.CollectorSpec{Scheduling: collectorScheduling},
+		},
+	}
+
+	plugin := DesiredDeployment(cr).Spec.Template.Spec
+	if !reflect.DeepEqual(plugin.NodeSelector, infra.NodeSelector) {
+		t.Fatalf("plugin nodeSelector not copied: %#v", plugin.NodeSelector)
+	}
+	if !reflect.DeepEqual(plugin.Tolerations, infra.Tolerations) {
+		t.Fatalf("plugin tolerations not copied: %#v", plugin.Tolerations)
+	}
+	if !reflect.DeepEqual(plugin.Affinity, infra.Affinity) {
+		t.Fatalf("plugin affinity not copied: %#v", plugin.Affinity)
+	}
+	if plugin.Affinity == infra.Affinity {
+		t.Fatalf("expected plugin affinity to be a copy of the spec")
+	}
+
+	collector := DesiredCollectorDeployment(cr).Spec.Template.Spec
+	if !reflect.DeepEqual(collector.NodeSelector, collectorScheduling.NodeSelector) {
+		t.Fatalf("collector nodeSelector not copied: %#v", collector.NodeSelector)
+	}
+	if collector.Tolerations != nil || collector.Affinity != nil {
+		t.Fatalf("expected collector to use only its own scheduling, got %#v %#v", collector.Tolerations, collector.Affinity)
+	}
+}
+
+func TestPullSecretNameAppliesToBothDeployments(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	if secrets := DesiredDeployment(defaultCR).Spec.Template.Spec.ImagePullSecrets; secrets != nil {
+		t.Fatalf("expected no plugin pull secrets by default, got %#v", secrets)
+	}
+
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec:       reconv1beta1.OvnReconSpec{PullSecretName: "quay-creds"},
+	}
+	want := []corev1.LocalObjectReference{{Name: "quay-creds"}}
+	if got := DesiredDeployment(cr).Spec.Template.Spec.ImagePullSecrets; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected plugin pull secrets %#v, got %#v", want, got)
+	}
+	if got := DesiredCollectorDeployment(cr).Spec.Template.Spec.ImagePullSecrets; !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected collector pull secrets %#v, got %#v", want, got)
+	}
+}
+
+func TestCollectorClusterIDEnv(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	}
+	if got, ok := envValue(DesiredCollectorDeployment(defaultCR).Spec.Template.Spec.Containers[0].Env, "COLLECTOR_CLUSTER_ID"); ok {
+		t.Fatalf("expected no cluster id env by default, got %q", got)
+	}
+
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			ClusterID: "east-prod",
+		},
+	}
+	env := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0].Env
+	if got, ok := envValue(env, "COLLECTOR_CLUSTER_ID"); !ok || got != "east-prod" {
+		t.Fatalf("expected collector cluster id env=east-prod, got %q (present=%v)", got, ok)
+	}
+}
+
+func TestCollectorNbctlArgsEnv(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	if got, ok := envValue(DesiredCollectorDeployment(defaultCR).Spec.Template.Spec.Containers[0].Env, "COLLECTOR_NBCTL_EXTRA_ARGS"); ok {
+		t.Fatalf("expected no nbctl args env by default, got %q", got)
+	}
+
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				NbctlArgs: []string{"--db=unix:/var/run/ovn/ovnnb_db.sock", "--no-leader-only"},
+			},
+		},
+	}
+	env := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0].Env
+	want := "--db=unix:/var/run/ovn/ovnnb_db.sock --no-leader-only"
+	if got, ok := envValue(env, "COLLECTOR_NBCTL_EXTRA_ARGS"); !ok || got != want {
+		t.Fatalf("expected COLLECTOR_NBCTL_EXTRA_ARGS=%q, got %q (present=%v)", want, got, ok)
+	}
+}
+
+func TestCollectorPodSelectorEnv(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	if got, ok := envValue(DesiredCollectorDeployment(defaultCR).Spec.Template.Spec.Containers[0].Env, "COLLECTOR_POD_SELECTOR"); ok {
+		t.Fatalf("expected no pod selector env by default, got %q", got)
+	}
+
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				PodSelector: map[string]string{"component": "network", "app": "ovnkube-node"},
+			},
+		},
+	}
+	env := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0].Env
+	want := "app=ovnkube-node,component=network"
+	if got, ok := envValue(env, "COLLECTOR_POD_SELECTOR"); !ok || got != want {
+		t.Fatalf("expected COLLECTOR_POD_SELECTOR=%q, got %q (present=%v)", want, got, ok)
+	}
+}
+
+func TestCollectorCommandOverride(t *testing.T) {
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	defaultContainer := DesiredCollectorDeployment(defaultCR).Spec.Template.Spec.Containers[0]
+	if defaultContainer.Command != nil || defaultContainer.Args != nil {
+		t.Fatalf("expected the image entrypoint and arguments by default, got command %q args %q", defaultContainer.Command, defaultContainer.Args)
+	}
+
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				Command: []string{"/usr/local/bin/debug-wrapper", "/usr/local/bin/ovn-collector"},
+				Args:    []string{"--trace-probes", "--verbose"},
+			},
+		},
+	}
+	container := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0]
+	want := []string{"/usr/local/bin/debug-wrapper", "/usr/local/bin/ovn-collector"}
+	if !reflect.DeepEqual(container.Command, want) {
+		t.Fatalf("expected command %q, got %q", want, container.Command)
+	}
+	wantArgs := []string{"--trace-probes", "--verbose"}
+	if !reflect.DeepEqual(container.Args, wantArgs) {
+		t.Fatalf("expected args %q, got %q", wantArgs, container.Args)
+	}
+
+	// Args alone keep the image entrypoint.
+	cr.Spec.Collector.Command = nil
+	container = DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0]
+	if container.Command != nil || !reflect.DeepEqual(container.Args, wantArgs) {
+		t.Fatalf("expected only args to be set, got command %q args %q", container.Command, container.Args)
+	}
+}
+
+func TestUserEnvIsAppendedAfterOperatorEnv(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				Env: []corev1.EnvVar{
+					{Name: "HTTPS_PROXY", Value: "http://proxy.example.com:3128"},
+					{Name: "OVN_RECON_NGINX_ERROR_LOG_LEVEL", Value: "debug"},
+				},
+			},
+			Collector: reconv1beta1.CollectorSpec{
+				Logging: reconv1beta1.CollectorLoggingSpec{Level: "warn"},
+				Env: []corev1.EnvVar{
+					{Name: "COLLECTOR_LOG_LEVEL", Value: "debug"},
+					{Name: "COLLECTOR_DETECT_CYCLES", Value: "true"},
+				},
+			},
+		},
+	}
+
+	collectorEnv := DesiredCollectorDeployment(cr).Spec.Template.Spec.Containers[0].Env
+	if got, ok := envValue(collectorEnv, "COLLECTOR_LOG_LEVEL"); !ok || got != "warn" {
+		t.Fatalf("expected the operator COLLECTOR_LOG_LEVEL to win, got %q (present=%v)", got, ok)
+	}
+	if last := collectorEnv[len(collectorEnv)-1]; last.Name != "COLLECTOR_DETECT_CYCLES" || last.Value != "true" {
+		t.Fatalf("expected user env to be appended, got %#v", collectorEnv)
+	}
+	count := 0
+	for _, envVar := range collectorEnv {
+		if envVar.Name == "COLLECTOR_LOG_LEVEL" {
+			count++
+		}
+	}
+	if count != 1 {
+		t.Fatalf("expected COLLECTOR_LOG_LEVEL once, got %d times", count)
+	}
+
+	pluginEnv := DesiredDeployment(cr).Spec.Template.Spec.Containers[0].Env
+	if got, ok := envValue(pluginEnv, "HTTPS_PROXY"); !ok || got != "http://proxy.example.com:3128" {
+		t.Fatalf("expected user HTTPS_PROXY on the plugin container, got %q (present=%v)", got, ok)
+	}
+	if got, _ := envValue(pluginEnv, "OVN_RECON_NGINX_ERROR_LOG_LEVEL"); got != consolePluginErrorLogLevelFor(cr) {
+		t.Fatalf("expected the operator nginx error log level to win, got %q", got)
+	}
+
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	if got, want := len(DesiredCollectorDeployment(defaultCR).Spec.Template.Spec.Containers[0].Env), len(collectorEnvFor(defaultCR)); got != want {
+		t.Fatalf("expected no extra env by default, got %d variables, want %d", got, want)
+	}
+}
+
 func TestCollectorProbeNamespacesDefaultsAndOverrides(t *testing.T) {
 	defaultCR := &reconv1beta1.OvnRecon{
 		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
@@ -0,0 +1,92 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+// ResolvedSpec is an OvnRecon spec with every operator default applied. It reports the
+// values the controller actually reconciles rather than what the user wrote.
+type ResolvedSpec struct {
+	TargetNamespace  string                `json:"targetNamespace"`
+	OperatorLogLevel string                `json:"operatorLogLevel"`
+	ConsolePlugin    ResolvedConsolePlugin `json:"consolePlugin"`
+	Collector        ResolvedCollector     `json:"collector"`
+}
+
+// ResolvedConsolePlugin holds the effective console plugin settings.
+type ResolvedConsolePlugin struct {
+	DisplayName       string             `json:"displayName"`
+	Image             string             `json:"image"`
+	ImagePullPolicy   corev1.PullPolicy  `json:"imagePullPolicy"`
+	Replicas          int32              `json:"replicas"`
+	ReconcileStrategy string             `json:"reconcileStrategy"`
+	ServiceType       corev1.ServiceType `json:"serviceType"`
+	ServicePort       int32              `json:"servicePort"`
+	ErrorLogLevel     string             `json:"errorLogLevel"`
+	AccessLogEnabled  bool               `json:"accessLogEnabled"`
+}
+
+// ResolvedCollector holds the effective collector settings.
+type ResolvedCollector struct {
+	Enabled            bool              `json:"enabled"`
+	Image              string            `json:"image"`
+	ImagePullPolicy    corev1.PullPolicy `json:"imagePullPolicy"`
+	Replicas           int32             `json:"replicas"`
+	ProbeNamespaces    []string          `json:"probeNamespaces"`
+	RBACNamespaces     []string          `json:"rbacNamespaces"`
+	LogLevel           string            `json:"logLevel"`
+	IncludeProbeOutput bool              `json:"includeProbeOutput"`
+}
+
+// EffectiveSpec resolves the defaulted spec for an OvnRecon using the same helpers the
+// controller uses to render its resources.
+func EffectiveSpec(ovnRecon *reconv1beta1.OvnRecon) ResolvedSpec {
+	strategy := ovnRecon.Spec.ConsolePlugin.ReconcileStrategy
+	if strategy == "" {
+		strategy = reconv1beta1.ReconcileStrategyManaged
+	}
+
+	return ResolvedSpec{
+		TargetNamespace:  targetNamespace(ovnRecon),
+		OperatorLogLevel: operatorLogLevelFor(ovnRecon).String(),
+		ConsolePlugin: ResolvedConsolePlugin{
+			DisplayName:       consolePluginDisplayNameFor(ovnRecon),
+			Image:             pluginImageFor(ovnRecon),
+			ImagePullPolicy:   imagePullPolicyFor(ovnRecon),
+			Replicas:          pluginReplicas,
+			ReconcileStrategy: strategy,
+			ServiceType:       pluginServiceTypeFor(ovnRecon),
+			ServicePort:       pluginServicePortFor(ovnRecon),
+			ErrorLogLevel:     consolePluginErrorLogLevelFor(ovnRecon),
+			AccessLogEnabled:  ovnRecon.Spec.ConsolePlugin.Logging.AccessLog.Enabled,
+		},
+		Collector: ResolvedCollector{
+			Enabled:            collectorFeatureEnabled(ovnRecon),
+			Image:              collectorImageFor(ovnRecon),
+			ImagePullPolicy:    collectorImagePullPolicyFor(ovnRecon),
+			Replicas:           collectorReplicasFor(ovnRecon),
+			ProbeNamespaces:    collectorProbeNamespacesFor(ovnRecon),
+			RBACNamespaces:     collectorRBACNamespacesFor(ovnRecon),
+			LogLevel:           collectorLogLevelFor(ovnRecon),
+			IncludeProbeOutput: collectorIncludeProbeOutputFor(ovnRecon),
+		},
+	}
+}
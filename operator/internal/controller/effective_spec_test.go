@@ -0,0 +1,91 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestEffectiveSpecAppliesDefaults(t *testing.T) {
+	t.Setenv("OPERATOR_VERSION", "")
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+	}
+
+	want := ResolvedSpec{
+		TargetNamespace:  "ovn-recon",
+		OperatorLogLevel: "info",
+		ConsolePlugin: ResolvedConsolePlugin{
+			DisplayName:       "OVN Recon",
+			Image:             "quay.io/dbewley/ovn-recon:latest",
+			ImagePullPolicy:   corev1.PullIfNotPresent,
+			Replicas:          1,
+			ReconcileStrategy: reconv1beta1.ReconcileStrategyManaged,
+			ServiceType:       corev1.ServiceTypeClusterIP,
+			ServicePort:       9443,
+			ErrorLogLevel:     "info",
+		},
+		Collector: ResolvedCollector{
+			Image:           "quay.io/dbewley/ovn-collector:latest",
+			ImagePullPolicy: corev1.PullIfNotPresent,
+			Replicas:        1,
+			ProbeNamespaces: []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+			RBACNamespaces:  []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+			LogLevel:        "info",
+		},
+	}
+	if got := EffectiveSpec(cr); !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected effective spec:\n got: %#v\nwant: %#v", got, want)
+	}
+}
+
+func TestEffectiveSpecReflectsOverrides(t *testing.T) {
+	t.Setenv("OPERATOR_VERSION", "")
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "custom-ns",
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				DisplayName: "Network Recon",
+				Image: reconv1beta1.ImageSpec{
+					Repository: "example.com/plugin",
+					Tag:        "v2",
+				},
+				Service: reconv1beta1.ConsolePluginServiceSpec{Port: 8443},
+			},
+			Collector: reconv1beta1.CollectorSpec{
+				Enabled:         ptr.To(true),
+				Replicas:        ptr.To[int32](2),
+				ProbeNamespaces: []string{"probe-a"},
+				Image: reconv1beta1.CollectorImageSpec{
+					Tag: "v3",
+				},
+			},
+		},
+	}
+
+	got := EffectiveSpec(cr)
+	if got.TargetNamespace != "custom-ns" {
+		t.Fatalf("unexpected target namespace: %s", got.TargetNamespace)
+	}
+	if got.ConsolePlugin.DisplayName != "Network Recon" || got.ConsolePlugin.Image != "example.com/plugin:v2" {
+		t.Fatalf("unexpected console plugin settings: %#v", got.ConsolePlugin)
+	}
+	if got.ConsolePlugin.ServicePort != 8443 {
+		t.Fatalf("unexpected service port: %d", got.ConsolePlugin.ServicePort)
+	}
+	if !got.Collector.Enabled || got.Collector.Replicas != 2 {
+		t.Fatalf("unexpected collector enablement or replicas: %#v", got.Collector)
+	}
+	if got.Collector.Image != "quay.io/dbewley/ovn-collector:v3" {
+		t.Fatalf("unexpected collector image: %s", got.Collector.Image)
+	}
+	if !reflect.DeepEqual(got.Collector.RBACNamespaces, []string{"probe-a"}) {
+		t.Fatalf("expected RBAC namespaces to follow probe namespaces, got %#v", got.Collector.RBACNamespaces)
+	}
+}
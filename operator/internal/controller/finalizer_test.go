@@ -0,0 +1,266 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestFinalizerForDefaultsAndOverride(t *testing.T) {
+	t.Parallel()
+
+	defaultCR := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	if got := finalizerFor(defaultCR); got != "ovnrecon.bewley.net/finalizer" {
+		t.Fatalf("unexpected default finalizer: %s", got)
+	}
+
+	customCR := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Operator: reconv1beta1.OperatorSpec{
+				FinalizerName: "fork.example.com/finalizer",
+			},
+		},
+	}
+	if got := finalizerFor(customCR); got != "fork.example.com/finalizer" {
+		t.Fatalf("unexpected custom finalizer: %s", got)
+	}
+}
+
+func TestCustomFinalizerIsAddedAndRemovedWithoutTouchingForeignFinalizers(t *testing.T) {
+	t.Parallel()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		appsv1.AddToScheme,
+		corev1.AddToScheme,
+		networkingv1.AddToScheme,
+		policyv1.AddToScheme,
+		rbacv1.AddToScheme,
+		reconv1beta1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	const customFinalizer = "fork.example.com/finalizer"
+	const foreignFinalizer = "other.example.com/finalizer"
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ovn-recon",
+			Finalizers: []string{foreignFinalizer},
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator: reconv1beta1.OperatorSpec{
+				FinalizerName: customFinalizer,
+			},
+		},
+	}
+
+	reconciler := &OvnReconReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(ovnRecon).
+			Build(),
+		Scheme: scheme,
+	}
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	added, err := reconciler.ensureFinalizer(context.Background(), current)
+	if err != nil {
+		t.Fatalf("ensureFinalizer failed: %v", err)
+	}
+	if !added {
+		t.Fatalf("expected custom finalizer to be added")
+	}
+	if added, err := reconciler.ensureFinalizer(context.Background(), current); err != nil || added {
+		t.Fatalf("expected second ensureFinalizer to be a no-op, added=%v err=%v", added, err)
+	}
+
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if !controllerutil.ContainsFinalizer(current, customFinalizer) {
+		t.Fatalf("expected custom finalizer on OvnRecon, got %v", current.Finalizers)
+	}
+	if controllerutil.ContainsFinalizer(current, defaultFinalizerName) {
+		t.Fatalf("expected default finalizer to be absent when a custom name is set, got %v", current.Finalizers)
+	}
+
+	// Deleting marks the object for deletion; foreign finalizers keep it around.
+	if err := reconciler.Delete(context.Background(), current); err != nil {
+		t.Fatalf("failed to delete OvnRecon: %v", err)
+	}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if current.DeletionTimestamp.IsZero() {
+		t.Fatalf("expected deletion timestamp to be set")
+	}
+
+	if _, err := reconciler.handleDeletion(context.Background(), current); err != nil {
+		t.Fatalf("handleDeletion failed: %v", err)
+	}
+
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("expected OvnRecon to remain while a foreign finalizer is present: %v", err)
+	}
+	if controllerutil.ContainsFinalizer(current, customFinalizer) {
+		t.Fatalf("expected custom finalizer to be removed, got %v", current.Finalizers)
+	}
+	if !controllerutil.ContainsFinalizer(current, foreignFinalizer) {
+		t.Fatalf("expected foreign finalizer to remain, got %v", current.Finalizers)
+	}
+}
+
+func TestRenamedFinalizerIsReplacedAndRemovedOnDeletion(t *testing.T) {
+	t.Parallel()
+
+	const firstFinalizer = "fork.example.com/finalizer"
+	const secondFinalizer = "fork.example.com/renamed"
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator:        reconv1beta1.OperatorSpec{FinalizerName: firstFinalizer},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ovn-recon"}
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, key, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if _, err := reconciler.ensureFinalizer(ctx, current); err != nil {
+		t.Fatalf("ensureFinalizer failed: %v", err)
+	}
+
+	// A reconcile after a rename swaps the applied finalizer for the new one.
+	current.Spec.Operator.FinalizerName = secondFinalizer
+	if err := reconciler.Update(ctx, current); err != nil {
+		t.Fatalf("failed to rename finalizer: %v", err)
+	}
+	if added, err := reconciler.ensureFinalizer(ctx, current); err != nil || !added {
+		t.Fatalf("expected renamed finalizer to be added, added=%v err=%v", added, err)
+	}
+	if controllerutil.ContainsFinalizer(current, firstFinalizer) || !controllerutil.ContainsFinalizer(current, secondFinalizer) {
+		t.Fatalf("expected only the renamed finalizer, got %v", current.Finalizers)
+	}
+
+	// Clearing the override and deleting before any reconcile must not leave the renamed
+	// finalizer behind.
+	current.Spec.Operator.FinalizerName = ""
+	if err := reconciler.Update(ctx, current); err != nil {
+		t.Fatalf("failed to clear finalizer name: %v", err)
+	}
+	if err := reconciler.Delete(ctx, current); err != nil {
+		t.Fatalf("failed to delete OvnRecon: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, current); err != nil {
+		t.Fatalf("expected OvnRecon to wait on its finalizer: %v", err)
+	}
+	if _, err := reconciler.handleDeletion(ctx, current); err != nil {
+		t.Fatalf("handleDeletion failed: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, current); !apierrors.IsNotFound(err) {
+		t.Fatalf("expected OvnRecon to be gone once its finalizers were removed, got err=%v finalizers=%v", err, current.Finalizers)
+	}
+}
+
+func TestCustomFinalizerLeavesDefaultFinalizerOnDeletion(t *testing.T) {
+	t.Parallel()
+
+	const customFinalizer = "fork.example.com/finalizer"
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator:        reconv1beta1.OperatorSpec{FinalizerName: customFinalizer},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon)
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "ovn-recon"}
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, key, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if _, err := reconciler.ensureFinalizer(ctx, current); err != nil {
+		t.Fatalf("ensureFinalizer failed: %v", err)
+	}
+
+	// Another operator instance running with the default name also finalizes the object.
+	controllerutil.AddFinalizer(current, defaultFinalizerName)
+	if err := reconciler.Update(ctx, current); err != nil {
+		t.Fatalf("failed to add default finalizer: %v", err)
+	}
+	if err := reconciler.Delete(ctx, current); err != nil {
+		t.Fatalf("failed to delete OvnRecon: %v", err)
+	}
+	if err := reconciler.Get(ctx, key, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if _, err := reconciler.handleDeletion(ctx, current); err != nil {
+		t.Fatalf("handleDeletion failed: %v", err)
+	}
+
+	if err := reconciler.Get(ctx, key, current); err != nil {
+		t.Fatalf("expected OvnRecon to remain while the default finalizer is present: %v", err)
+	}
+	if controllerutil.ContainsFinalizer(current, customFinalizer) || !controllerutil.ContainsFinalizer(current, defaultFinalizerName) {
+		t.Fatalf("expected only the default finalizer to remain, got %v", current.Finalizers)
+	}
+}
+
+func TestCustomFinalizerReplacesUnrecordedDefaultFinalizer(t *testing.T) {
+	t.Parallel()
+
+	const customFinalizer = "fork.example.com/finalizer"
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ovn-recon",
+			Finalizers: []string{defaultFinalizerName},
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator:        reconv1beta1.OperatorSpec{FinalizerName: customFinalizer},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon)
+	ctx := context.Background()
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to fetch OvnRecon: %v", err)
+	}
+	if added, err := reconciler.ensureFinalizer(ctx, current); err != nil || !added {
+		t.Fatalf("expected custom finalizer to be added, added=%v err=%v", added, err)
+	}
+	if controllerutil.ContainsFinalizer(current, defaultFinalizerName) || !controllerutil.ContainsFinalizer(current, customFinalizer) {
+		t.Fatalf("expected the default finalizer applied before the annotation existed to be replaced, got %v", current.Finalizers)
+	}
+	if got := current.Annotations[appliedFinalizerAnnotation]; got != customFinalizer {
+		t.Fatalf("expected applied finalizer annotation %q, got %q", customFinalizer, got)
+	}
+}
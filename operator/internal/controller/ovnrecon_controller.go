@@ -20,19 +20,27 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
+	"golang.org/x/time/rate"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
@@ -41,6 +49,7 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -48,13 +57,40 @@ import (
 )
 
 const (
-	finalizerName           = "ovnrecon.bewley.net/finalizer"
+	defaultFinalizerName    = "ovnrecon.bewley.net/finalizer"
 	defaultNamespace        = "ovn-recon"
 	defaultImageRepository  = "quay.io/dbewley/ovn-recon"
 	defaultImageTag         = "latest"
 	defaultOperatorLogLevel = "info"
 	defaultEventMinType     = corev1.EventTypeNormal
 	defaultEventDedupe      = 5 * time.Minute
+
+	// Reconcile steps that can be skipped through spec.operator.disabledSteps.
+	reconcileStepDeployment          = "deployment"
+	reconcileStepService             = "service"
+	reconcileStepCollectorService    = "collectorService"
+	reconcileStepCollectorRBAC       = "collectorRBAC"
+	reconcileStepCollectorDeployment = "collectorDeployment"
+	reconcileStepCollectorHealth     = "collectorHealth"
+	reconcileStepNetworkPolicy       = "networkPolicy"
+	reconcileStepConsolePlugin       = "consolePlugin"
+	reconcileStepConsoleOperator     = "consoleOperator"
+
+	// namespaceCreatedByAnnotation marks a target namespace created by the operator and
+	// records the owning OvnRecon name so cleanup never removes a pre-existing namespace.
+	namespaceCreatedByAnnotation = "ovnrecon.bewley.net/created-by"
+
+	// appliedFinalizerAnnotation on an OvnRecon records the finalizer name the operator added, so
+	// the finalizer can still be found and removed after spec.operator.finalizerName changes.
+	appliedFinalizerAnnotation = "ovnrecon.bewley.net/applied-finalizer"
+
+	// consoleAPIRetryInterval is how often reconcile rechecks for the console.openshift.io API
+	// on clusters that do not serve it.
+	consoleAPIRetryInterval = 10 * time.Minute
+
+	// collectorStatusRefreshInterval is how often a reconcile that checked collector health is
+	// repeated, so status.collector stays current without a spec change.
+	collectorStatusRefreshInterval = 2 * time.Minute
 )
 
 // OvnReconReconciler reconciles a OvnRecon object
@@ -63,6 +99,10 @@ type OvnReconReconciler struct {
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
 
+	// ServiceCAFile overrides the service CA bundle trusted for https collector health
+	// checks when no CA ConfigMap is configured.
+	ServiceCAFile string
+
 	eventDedupeMu sync.Mutex
 	eventDedupe   map[string]time.Time
 }
@@ -250,11 +290,15 @@ func (r *OvnReconReconciler) shouldEmitNormalEvent(ovnRecon *reconv1beta1.OvnRec
 // +kubebuilder:rbac:groups=recon.bewley.net,resources=ovnrecons/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=recon.bewley.net,resources=ovnrecons/finalizers,verbs=update
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=pods/exec,verbs=create
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=clusterroles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=console.openshift.io,resources=consoleplugins,verbs=get;list;watch;create;update;patch;delete
@@ -284,10 +328,10 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	primaryCtx := withReconcilePhase(ctx, "primary-detection")
-	primary, err := r.primaryInstance(primaryCtx)
+	primary, err := r.primaryInstance(primaryCtx, ovnRecon)
 	if err != nil {
 		log.FromContext(primaryCtx).Error(err, "Failed to determine primary OvnRecon instance")
-		return reconcile.Result{RequeueAfter: time.Second * 30}, err
+		return reconcile.Result{}, err
 	}
 	policy, configuredLevel, policySource := resolveOperatorLogPolicy(ovnRecon, primary)
 	eventPolicy := resolveOperatorEventPolicy(ovnRecon, primary)
@@ -311,25 +355,27 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	isPrimary := primary == nil || (ovnRecon.Namespace == primary.Namespace && ovnRecon.Name == primary.Name)
+	r.updateRole(withReconcilePhase(ctx, "primary-check"), ovnRecon, isPrimary)
 	if !isPrimary {
 		nonPrimaryCtx := withReconcilePhase(ctx, "primary-check")
-		r.recordEvent(nonPrimaryCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "NotPrimary", "Another OvnRecon instance is already active")
-		r.updateCondition(nonPrimaryCtx, ovnRecon, "Available", metav1.ConditionFalse, "NotPrimary", "Another OvnRecon instance is already active")
-		r.updateCondition(nonPrimaryCtx, ovnRecon, "PluginEnabled", metav1.ConditionFalse, "NotPrimary", "Another OvnRecon instance is already active")
-		r.logMessage(nonPrimaryCtx, policy, operatorLogLevelInfo, "Skipping reconcile for non-primary OvnRecon", "primary", ovnReconRef(primary))
+		message := fmt.Sprintf("OvnRecon %s is already active for target namespace %q", primary.Name, targetNamespace(ovnRecon))
+		r.recordEvent(nonPrimaryCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "NotPrimary", message)
+		r.updateCondition(nonPrimaryCtx, ovnRecon, "Available", metav1.ConditionFalse, "NotPrimary", message)
+		r.updateCondition(nonPrimaryCtx, ovnRecon, "PluginEnabled", metav1.ConditionFalse, "NotPrimary", message)
+		r.logMessage(nonPrimaryCtx, policy, operatorLogLevelInfo, "Skipping reconcile for non-primary OvnRecon", "primary", ovnReconRef(primary), "targetNamespace", targetNamespace(ovnRecon))
 		return reconcile.Result{RequeueAfter: time.Minute * 2}, nil
 	}
 	r.logMessage(withReconcilePhase(ctx, "start"), policy, operatorLogLevelDebug, "Starting reconcile")
 
 	// Add finalizer if not present
-	if !controllerutil.ContainsFinalizer(ovnRecon, finalizerName) {
-		finalizerCtx := withReconcilePhase(ctx, "finalizer")
-		controllerutil.AddFinalizer(ovnRecon, finalizerName)
-		if err := r.Update(finalizerCtx, ovnRecon); err != nil {
-			log.FromContext(finalizerCtx).Error(err, "Failed to add finalizer")
-			return reconcile.Result{}, err
-		}
-		r.logMessage(finalizerCtx, policy, operatorLogLevelTrace, "Added finalizer")
+	finalizerCtx := withReconcilePhase(ctx, "finalizer")
+	added, err := r.ensureFinalizer(finalizerCtx, ovnRecon)
+	if err != nil {
+		log.FromContext(finalizerCtx).Error(err, "Failed to add finalizer")
+		return reconcile.Result{}, err
+	}
+	if added {
+		r.logMessage(finalizerCtx, policy, operatorLogLevelTrace, "Added finalizer", "finalizer", finalizerFor(ovnRecon))
 	}
 
 	// Initialize status conditions if needed
@@ -339,95 +385,251 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	// Require target namespace to exist for namespaced resources.
 	namespaceCtx := withReconcilePhase(ctx, "namespace-check")
-	if err := r.ensureTargetNamespaceExists(namespaceCtx, ovnRecon); err != nil {
+	namespaceCreated, err := r.ensureTargetNamespaceExists(namespaceCtx, ovnRecon)
+	if err != nil {
 		log.FromContext(namespaceCtx).Error(err, "Target namespace does not exist")
 		r.recordEvent(namespaceCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "NamespaceNotFound", err.Error())
 		r.updateCondition(namespaceCtx, ovnRecon, "NamespaceReady", metav1.ConditionFalse, "NamespaceNotFound", err.Error())
 		return reconcile.Result{RequeueAfter: time.Minute}, nil
 	}
+	if namespaceCreated {
+		r.recordEvent(namespaceCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "NamespaceCreated", "Target namespace created")
+	}
 	if r.updateCondition(namespaceCtx, ovnRecon, "NamespaceReady", metav1.ConditionTrue, "NamespaceFound", "Target namespace exists") {
 		r.recordEvent(namespaceCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "NamespaceFound", "Target namespace exists")
 	}
 
+	// Surface legacy fields that are silently overridden by hierarchical ones.
+	imageConfigCtx := withReconcilePhase(ctx, "image-config")
+	if conflicts := ConflictingImageFields(ovnRecon); len(conflicts) > 0 {
+		message := "Conflicting legacy and hierarchical image fields: " + strings.Join(conflicts, "; ")
+		if r.updateCondition(imageConfigCtx, ovnRecon, "ImageConfigConflict", metav1.ConditionTrue, "ConflictingImageConfig", message) {
+			r.recordEvent(imageConfigCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "ConflictingImageConfig", message)
+		}
+	} else {
+		r.updateCondition(imageConfigCtx, ovnRecon, "ImageConfigConflict", metav1.ConditionFalse, "ImageConfigConsistent", "Legacy and hierarchical image fields do not conflict")
+	}
+
 	// 1. Reconcile Deployment
 	deploymentCtx := withReconcilePhase(ctx, "reconcile-deployment")
-	if err := r.reconcileDeployment(deploymentCtx, ovnRecon); err != nil {
-		log.FromContext(deploymentCtx).Error(err, "Failed to reconcile Deployment")
-		r.recordEvent(deploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "DeploymentReconcileFailed", err.Error())
-		r.updateCondition(deploymentCtx, ovnRecon, "Available", metav1.ConditionFalse, "DeploymentReconcileFailed", err.Error())
-		return reconcile.Result{RequeueAfter: time.Second * 30}, err
+	if !r.skipDisabledStep(deploymentCtx, policy, ovnRecon, reconcileStepDeployment) {
+		driftCorrected, err := r.reconcileDeployment(deploymentCtx, ovnRecon)
+		if err != nil {
+			log.FromContext(deploymentCtx).Error(err, "Failed to reconcile Deployment")
+			r.recordEvent(deploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "DeploymentReconcileFailed", err.Error())
+			r.updateCondition(deploymentCtx, ovnRecon, "Available", metav1.ConditionFalse, "DeploymentReconcileFailed", err.Error())
+			return reconcile.Result{}, err
+		}
+		if driftCorrected {
+			r.recordEvent(deploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "DeploymentDriftCorrected",
+				fmt.Sprintf("Restored managed spec of Deployment %s edited outside the operator", ovnRecon.Name))
+		}
+		if err := r.reconcilePluginPodDisruptionBudget(deploymentCtx, ovnRecon); err != nil {
+			log.FromContext(deploymentCtx).Error(err, "Failed to reconcile plugin PodDisruptionBudget")
+			r.recordEvent(deploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "PodDisruptionBudgetReconcileFailed", err.Error())
+			r.updateCondition(deploymentCtx, ovnRecon, "Available", metav1.ConditionFalse, "PodDisruptionBudgetReconcileFailed", err.Error())
+			return reconcile.Result{}, err
+		}
+		r.logMessage(deploymentCtx, policy, operatorLogLevelTrace, "Deployment reconciled")
 	}
-	r.logMessage(deploymentCtx, policy, operatorLogLevelTrace, "Deployment reconciled")
 
 	// 2. Reconcile Service
 	serviceCtx := withReconcilePhase(ctx, "reconcile-service")
-	if err := r.reconcileService(serviceCtx, ovnRecon); err != nil {
-		log.FromContext(serviceCtx).Error(err, "Failed to reconcile Service")
-		r.recordEvent(serviceCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ServiceReconcileFailed", err.Error())
-		r.updateCondition(serviceCtx, ovnRecon, "ServiceReady", metav1.ConditionFalse, "ServiceReconcileFailed", err.Error())
-		return reconcile.Result{RequeueAfter: time.Second * 30}, err
-	}
-	if r.updateCondition(serviceCtx, ovnRecon, "ServiceReady", metav1.ConditionTrue, "ServiceReady", "Service is ready") {
-		r.recordEvent(serviceCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "ServiceReady", "Service is ready")
+	if !r.skipDisabledStep(serviceCtx, policy, ovnRecon, reconcileStepService) {
+		if err := r.reconcileService(serviceCtx, ovnRecon); err != nil {
+			log.FromContext(serviceCtx).Error(err, "Failed to reconcile Service")
+			r.recordEvent(serviceCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ServiceReconcileFailed", err.Error())
+			r.updateCondition(serviceCtx, ovnRecon, "ServiceReady", metav1.ConditionFalse, "ServiceReconcileFailed", err.Error())
+			return reconcile.Result{}, err
+		}
+		if r.updateCondition(serviceCtx, ovnRecon, "ServiceReady", metav1.ConditionTrue, "ServiceReady", "Service is ready") {
+			r.recordEvent(serviceCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "ServiceReady", "Service is ready")
+		}
+		r.logMessage(serviceCtx, policy, operatorLogLevelTrace, "Service reconciled")
 	}
-	r.logMessage(serviceCtx, policy, operatorLogLevelTrace, "Service reconciled")
 
 	// 2.5 Reconcile collector service and collector resources behind feature gate.
 	// Keep the collector Service present even when collector is disabled so plugin nginx
 	// can resolve the backend DNS name at startup.
 	collectorServiceCtx := withReconcilePhase(ctx, "reconcile-collector-service")
-	if err := r.reconcileCollectorService(collectorServiceCtx, ovnRecon); err != nil {
-		log.FromContext(collectorServiceCtx).Error(err, "Failed to reconcile collector Service")
-		r.recordEvent(collectorServiceCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorServiceReconcileFailed", err.Error())
-		r.updateCondition(collectorServiceCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorServiceReconcileFailed", err.Error())
-		return reconcile.Result{RequeueAfter: time.Second * 30}, err
+	if !r.skipDisabledStep(collectorServiceCtx, policy, ovnRecon, reconcileStepCollectorService) {
+		if err := r.reconcileCollectorService(collectorServiceCtx, ovnRecon); err != nil {
+			log.FromContext(collectorServiceCtx).Error(err, "Failed to reconcile collector Service")
+			r.recordEvent(collectorServiceCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorServiceReconcileFailed", err.Error())
+			r.updateCondition(collectorServiceCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorServiceReconcileFailed", err.Error())
+			return reconcile.Result{}, err
+		}
 	}
 
+	collectorHealthChecked := false
 	if collectorFeatureEnabled(ovnRecon) {
 		collectorRBACCtx := withReconcilePhase(ctx, "reconcile-collector-rbac")
-		if err := r.reconcileCollectorAccessControls(collectorRBACCtx, ovnRecon); err != nil {
-			log.FromContext(collectorRBACCtx).Error(err, "Failed to reconcile collector access controls")
-			r.recordEvent(collectorRBACCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorRBACReconcileFailed", err.Error())
-			r.updateCondition(collectorRBACCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorRBACReconcileFailed", err.Error())
-			return reconcile.Result{RequeueAfter: time.Second * 30}, err
+		if !r.skipDisabledStep(collectorRBACCtx, policy, ovnRecon, reconcileStepCollectorRBAC) {
+			if err := r.reconcileCollectorAccessControls(collectorRBACCtx, ovnRecon); err != nil {
+				log.FromContext(collectorRBACCtx).Error(err, "Failed to reconcile collector access controls")
+				r.recordEvent(collectorRBACCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorRBACReconcileFailed", err.Error())
+				r.updateCondition(collectorRBACCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorRBACReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			rbacGaps, err := r.collectorRBACGaps(collectorRBACCtx, ovnRecon)
+			if err != nil {
+				log.FromContext(collectorRBACCtx).Error(err, "Failed to verify collector probe RBAC")
+				return reconcile.Result{}, err
+			}
+			if len(rbacGaps) > 0 {
+				message := "Collector probe RBAC is incomplete: " + strings.Join(rbacGaps, "; ")
+				if r.updateCondition(collectorRBACCtx, ovnRecon, "CollectorRBACReady", metav1.ConditionFalse, "CollectorRBACIncomplete", message) {
+					r.recordEvent(collectorRBACCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorRBACIncomplete", message)
+				}
+			} else {
+				r.updateCondition(collectorRBACCtx, ovnRecon, "CollectorRBACReady", metav1.ConditionTrue, "CollectorRBACReady", "Collector RoleBindings exist in every probe namespace")
+			}
 		}
 		collectorDeploymentCtx := withReconcilePhase(ctx, "reconcile-collector-deployment")
-		if err := r.reconcileCollectorDeployment(collectorDeploymentCtx, ovnRecon); err != nil {
-			log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector Deployment")
-			r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorDeploymentReconcileFailed", err.Error())
-			r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorDeploymentReconcileFailed", err.Error())
-			return reconcile.Result{RequeueAfter: time.Second * 30}, err
+		if !r.skipDisabledStep(collectorDeploymentCtx, policy, ovnRecon, reconcileStepCollectorDeployment) {
+			if err := r.reconcileCollectorAuthSecret(collectorDeploymentCtx, ovnRecon); err != nil {
+				log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector auth Secret")
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorAuthSecretReconcileFailed", err.Error())
+				r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorAuthSecretReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			if err := r.reconcileCollectorTrustedCABundle(collectorDeploymentCtx, ovnRecon); err != nil {
+				log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector trusted CA bundle ConfigMap")
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorTrustBundleReconcileFailed", err.Error())
+				r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorTrustBundleReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			if err := r.reconcileCollectorConfigMap(collectorDeploymentCtx, ovnRecon); err != nil {
+				log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector config ConfigMap")
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorConfigMapReconcileFailed", err.Error())
+				r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorConfigMapReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			driftCorrected, err := r.reconcileCollectorDeployment(collectorDeploymentCtx, ovnRecon)
+			if err != nil {
+				log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector Deployment")
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorDeploymentReconcileFailed", err.Error())
+				r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorDeploymentReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+			if driftCorrected {
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "DeploymentDriftCorrected",
+					fmt.Sprintf("Restored managed spec of Deployment %s edited outside the operator", collectorName(ovnRecon)))
+			}
+			if err := r.reconcileCollectorPodDisruptionBudget(collectorDeploymentCtx, ovnRecon); err != nil {
+				log.FromContext(collectorDeploymentCtx).Error(err, "Failed to reconcile collector PodDisruptionBudget")
+				r.recordEvent(collectorDeploymentCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorPodDisruptionBudgetReconcileFailed", err.Error())
+				r.updateCondition(collectorDeploymentCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorPodDisruptionBudgetReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
 		}
 
 		if r.updateCondition(collectorServiceCtx, ovnRecon, "CollectorReady", metav1.ConditionTrue, "CollectorReady", "Collector resources are reconciled") {
 			r.recordEvent(collectorServiceCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "CollectorReady", "Collector resources are reconciled")
 		}
+
+		// Probe collector health only once its Deployment is ready; failures are reported
+		// through status and do not block the rest of the reconcile.
+		collectorHealthCtx := withReconcilePhase(ctx, "collector-health")
+		if !r.skipDisabledStep(collectorHealthCtx, policy, ovnRecon, reconcileStepCollectorHealth) {
+			collectorHealthChecked = true
+			var observedNodeCount *int32
+			collectorReady, err := r.collectorDeploymentReady(collectorHealthCtx, ovnRecon)
+			if err != nil {
+				log.FromContext(collectorHealthCtx).Error(err, "Failed to check collector Deployment status")
+			} else if collectorReady {
+				if err := r.checkCollectorHealth(collectorHealthCtx, ovnRecon); err != nil {
+					if r.updateCondition(collectorHealthCtx, ovnRecon, "CollectorHealthy", metav1.ConditionFalse, "CollectorUnhealthy", err.Error()) {
+						r.recordEvent(collectorHealthCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "CollectorUnhealthy", err.Error())
+					}
+				} else {
+					r.updateCondition(collectorHealthCtx, ovnRecon, "CollectorHealthy", metav1.ConditionTrue, "CollectorHealthy", "Collector health endpoint responded")
+					if count, err := r.observeCollectorNodes(collectorHealthCtx, ovnRecon); err != nil {
+						r.logMessage(collectorHealthCtx, policy, operatorLogLevelInfo, "Failed to query collector nodes", "error", err.Error())
+					} else {
+						observedNodeCount = &count
+					}
+				}
+			}
+			r.updateCollectorStatus(collectorHealthCtx, ovnRecon, collectorEndpoint(ovnRecon), observedNodeCount)
+		} else {
+			r.updateCollectorStatus(collectorHealthCtx, ovnRecon, collectorEndpoint(ovnRecon), nil)
+		}
 	} else {
 		collectorDeleteCtx := withReconcilePhase(ctx, "delete-collector-deployment")
 		if err := r.deleteCollectorDeployment(collectorDeleteCtx, ovnRecon); err != nil {
 			log.FromContext(collectorDeleteCtx).Error(err, "Failed to delete collector deployment while feature gate is disabled")
-			return reconcile.Result{RequeueAfter: time.Second * 30}, err
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteCollectorAuthSecret(collectorDeleteCtx, ovnRecon); err != nil {
+			log.FromContext(collectorDeleteCtx).Error(err, "Failed to delete collector auth Secret while feature gate is disabled")
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteCollectorTrustedCABundle(collectorDeleteCtx, ovnRecon); err != nil {
+			log.FromContext(collectorDeleteCtx).Error(err, "Failed to delete collector trusted CA bundle ConfigMap while feature gate is disabled")
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteCollectorConfigMap(collectorDeleteCtx, ovnRecon); err != nil {
+			log.FromContext(collectorDeleteCtx).Error(err, "Failed to delete collector config ConfigMap while feature gate is disabled")
+			return reconcile.Result{}, err
+		}
+		if err := r.deleteCollectorPodDisruptionBudget(collectorDeleteCtx, ovnRecon); err != nil {
+			log.FromContext(collectorDeleteCtx).Error(err, "Failed to delete collector PodDisruptionBudget while feature gate is disabled")
+			return reconcile.Result{}, err
 		}
 		collectorRBACDeleteCtx := withReconcilePhase(ctx, "delete-collector-rbac")
 		if err := r.deleteCollectorAccessControls(collectorRBACDeleteCtx, ovnRecon); err != nil {
 			log.FromContext(collectorRBACDeleteCtx).Error(err, "Failed to delete collector RBAC while feature gate is disabled")
-			return reconcile.Result{RequeueAfter: time.Second * 30}, err
+			return reconcile.Result{}, err
 		}
 		if r.updateCondition(collectorRBACDeleteCtx, ovnRecon, "CollectorReady", metav1.ConditionFalse, "CollectorFeatureDisabled", "Collector feature gate is disabled") {
 			r.recordEvent(collectorRBACDeleteCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "CollectorFeatureDisabled", "Collector feature gate is disabled")
 		}
+		r.updateCondition(collectorRBACDeleteCtx, ovnRecon, "CollectorRBACReady", metav1.ConditionFalse, "CollectorFeatureDisabled", "Collector feature gate is disabled")
+		r.updateCollectorStatus(collectorRBACDeleteCtx, ovnRecon, "", nil)
+	}
+
+	// 2.6 Reconcile the plugin egress NetworkPolicy when NetworkPolicy management is enabled.
+	networkPolicyCtx := withReconcilePhase(ctx, "reconcile-networkpolicy")
+	if !r.skipDisabledStep(networkPolicyCtx, policy, ovnRecon, reconcileStepNetworkPolicy) {
+		if ovnRecon.Spec.NetworkPolicy.Enabled {
+			if err := r.reconcilePluginEgressNetworkPolicy(networkPolicyCtx, ovnRecon); err != nil {
+				log.FromContext(networkPolicyCtx).Error(err, "Failed to reconcile plugin egress NetworkPolicy")
+				r.recordEvent(networkPolicyCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "NetworkPolicyReconcileFailed", err.Error())
+				return reconcile.Result{}, err
+			}
+		} else if err := r.deletePluginEgressNetworkPolicy(networkPolicyCtx, ovnRecon); err != nil {
+			log.FromContext(networkPolicyCtx).Error(err, "Failed to delete plugin egress NetworkPolicy")
+			return reconcile.Result{}, err
+		}
 	}
 
 	// 3. Reconcile ConsolePlugin
 	consolePluginCtx := withReconcilePhase(ctx, "reconcile-consoleplugin")
-	if err := r.reconcileConsolePlugin(consolePluginCtx, ovnRecon); err != nil {
-		log.FromContext(consolePluginCtx).Error(err, "Failed to reconcile ConsolePlugin")
-		r.recordEvent(consolePluginCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ConsolePluginReconcileFailed", err.Error())
-		r.updateCondition(consolePluginCtx, ovnRecon, "ConsolePluginReady", metav1.ConditionFalse, "ConsolePluginReconcileFailed", err.Error())
-		return reconcile.Result{RequeueAfter: time.Second * 30}, err
-	}
-	if r.updateCondition(consolePluginCtx, ovnRecon, "ConsolePluginReady", metav1.ConditionTrue, "ConsolePluginReady", "ConsolePlugin is ready") {
-		r.recordEvent(consolePluginCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "ConsolePluginReady", "ConsolePlugin is ready")
+	consoleAPIUnavailable := false
+	if !r.skipDisabledStep(consolePluginCtx, policy, ovnRecon, reconcileStepConsolePlugin) {
+		err := r.reconcileConsolePlugin(consolePluginCtx, ovnRecon)
+		if isConsoleAPIUnavailable(err) {
+			// Not an OpenShift cluster (or the console is not installed): keep the rest of the
+			// deployment working and recheck on a slow interval instead of failing every reconcile.
+			consoleAPIUnavailable = true
+			message := "ConsolePlugin API (console.openshift.io/v1) is not available on this cluster; the plugin will not be registered with the console"
+			r.logMessage(consolePluginCtx, policy, operatorLogLevelInfo, "ConsolePlugin API unavailable", "error", err.Error())
+			if r.updateCondition(consolePluginCtx, ovnRecon, "ConsoleAPIUnavailable", metav1.ConditionTrue, "ConsoleAPIUnavailable", message) {
+				r.recordEvent(consolePluginCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ConsoleAPIUnavailable", message)
+			}
+			r.updateCondition(consolePluginCtx, ovnRecon, "ConsolePluginReady", metav1.ConditionFalse, "ConsoleAPIUnavailable", message)
+		} else if err != nil {
+			log.FromContext(consolePluginCtx).Error(err, "Failed to reconcile ConsolePlugin")
+			r.recordEvent(consolePluginCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ConsolePluginReconcileFailed", err.Error())
+			r.updateCondition(consolePluginCtx, ovnRecon, "ConsolePluginReady", metav1.ConditionFalse, "ConsolePluginReconcileFailed", err.Error())
+			return reconcile.Result{}, err
+		} else {
+			r.updateCondition(consolePluginCtx, ovnRecon, "ConsoleAPIUnavailable", metav1.ConditionFalse, "ConsoleAPIAvailable", "ConsolePlugin API is available")
+			if r.updateCondition(consolePluginCtx, ovnRecon, "ConsolePluginReady", metav1.ConditionTrue, "ConsolePluginReady", "ConsolePlugin is ready") {
+				r.recordEvent(consolePluginCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "ConsolePluginReady", "ConsolePlugin is ready")
+			}
+		}
 	}
 
 	// Check deployment status after the service is in place.
@@ -435,7 +637,7 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	deploymentReady, err := r.checkDeploymentReady(deploymentStatusCtx, ovnRecon)
 	if err != nil {
 		log.FromContext(deploymentStatusCtx).Error(err, "Failed to check Deployment status")
-		return reconcile.Result{RequeueAfter: time.Second * 10}, err
+		return reconcile.Result{}, err
 	}
 
 	if deploymentReady {
@@ -448,28 +650,30 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 
 	// 4. Auto-enable plugin in Console operator configuration
-	if ovnRecon.Spec.ConsolePlugin.Enabled {
+	if ovnRecon.Spec.ConsolePlugin.Enabled && !consoleAPIUnavailable {
 		consoleOperatorCtx := withReconcilePhase(ctx, "reconcile-console-operator")
-		enabled, err := r.reconcileConsoleOperator(consoleOperatorCtx, ovnRecon)
-		if err != nil {
-			log.FromContext(consoleOperatorCtx).Error(err, "Failed to auto-enable plugin in Console operator")
-			r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ConsoleOperatorUpdateFailed", err.Error())
-			// Retry on conflict
-			if errors.IsConflict(err) {
-				return reconcile.Result{Requeue: true}, nil
-			}
-			return reconcile.Result{RequeueAfter: time.Second * 30}, err
-		}
-		if enabled {
-			if r.updateCondition(consoleOperatorCtx, ovnRecon, "PluginEnabled", metav1.ConditionTrue, "PluginEnabled", "Plugin is enabled in Console operator") {
-				r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "PluginEnabled", "Plugin is enabled in Console operator")
+		if !r.skipDisabledStep(consoleOperatorCtx, policy, ovnRecon, reconcileStepConsoleOperator) {
+			enabled, err := r.reconcileConsoleOperator(consoleOperatorCtx, ovnRecon)
+			if err != nil {
+				log.FromContext(consoleOperatorCtx).Error(err, "Failed to auto-enable plugin in Console operator")
+				r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeWarning, "ConsoleOperatorUpdateFailed", err.Error())
+				// Retry on conflict
+				if errors.IsConflict(err) {
+					return reconcile.Result{Requeue: true}, nil
+				}
+				return reconcile.Result{}, err
 			}
-		} else {
-			if r.updateCondition(consoleOperatorCtx, ovnRecon, "PluginEnabled", metav1.ConditionFalse, "PluginEnabling", "Plugin is being enabled in Console operator") {
-				r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "PluginEnabling", "Plugin is being enabled in Console operator")
+			if enabled {
+				if r.updateCondition(consoleOperatorCtx, ovnRecon, "PluginEnabled", metav1.ConditionTrue, "PluginEnabled", "Plugin is enabled in Console operator") {
+					r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "PluginEnabled", "Plugin is enabled in Console operator")
+				}
+			} else {
+				if r.updateCondition(consoleOperatorCtx, ovnRecon, "PluginEnabled", metav1.ConditionFalse, "PluginEnabling", "Plugin is being enabled in Console operator") {
+					r.recordEvent(consoleOperatorCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "PluginEnabling", "Plugin is being enabled in Console operator")
+				}
 			}
 		}
-	} else {
+	} else if !ovnRecon.Spec.ConsolePlugin.Enabled {
 		pluginDisabledCtx := withReconcilePhase(ctx, "plugin-disabled")
 		if r.updateCondition(pluginDisabledCtx, ovnRecon, "PluginEnabled", metav1.ConditionFalse, "PluginDisabled", "Plugin is disabled") {
 			r.recordEvent(pluginDisabledCtx, ovnRecon, eventPolicy, corev1.EventTypeNormal, "PluginDisabled", "Plugin is disabled")
@@ -477,16 +681,63 @@ func (r *OvnReconReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	}
 	r.logMessage(withReconcilePhase(ctx, "complete"), policy, operatorLogLevelDebug, "Reconcile completed successfully")
 
+	if collectorHealthChecked {
+		// Collector health and node count come from the running collector, not the spec.
+		return reconcile.Result{RequeueAfter: collectorStatusRefreshInterval}, nil
+	}
+	if consoleAPIUnavailable {
+		return reconcile.Result{RequeueAfter: consoleAPIRetryInterval}, nil
+	}
 	return reconcile.Result{}, nil
 }
 
-func (r *OvnReconReconciler) primaryInstance(ctx context.Context) (*reconv1beta1.OvnRecon, error) {
+// isConsoleAPIUnavailable reports whether err means the cluster does not serve the
+// console.openshift.io API, as on non-OpenShift clusters.
+func isConsoleAPIUnavailable(err error) bool {
+	if err == nil {
+		return false
+	}
+	return meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)
+}
+
+// skipDisabledStep reports whether the named reconcile step is listed in
+// spec.operator.disabledSteps, logging a StepSkipped note when it is.
+func (r *OvnReconReconciler) skipDisabledStep(ctx context.Context, policy operatorLogLevel, ovnRecon *reconv1beta1.OvnRecon, step string) bool {
+	if !reconcileStepDisabled(ovnRecon, step) {
+		return false
+	}
+	r.logMessage(ctx, policy, operatorLogLevelInfo, "Skipping disabled reconcile step", "reason", "StepSkipped", "step", step)
+	return true
+}
+
+func reconcileStepDisabled(ovnRecon *reconv1beta1.OvnRecon, step string) bool {
+	for _, disabled := range ovnRecon.Spec.Operator.DisabledSteps {
+		if strings.TrimSpace(disabled) == step {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryInstance returns the primary among the instances that share ovnRecon's target
+// namespace. Instances deploying into different namespaces do not compete.
+func (r *OvnReconReconciler) primaryInstance(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (*reconv1beta1.OvnRecon, error) {
 	list := &reconv1beta1.OvnReconList{}
 	if err := r.List(ctx, list); err != nil {
 		return nil, err
 	}
 
-	return selectPrimaryInstance(list.Items), nil
+	return selectPrimaryInstance(instancesForTargetNamespace(list.Items, targetNamespace(ovnRecon))), nil
+}
+
+func instancesForTargetNamespace(items []reconv1beta1.OvnRecon, namespace string) []reconv1beta1.OvnRecon {
+	matching := make([]reconv1beta1.OvnRecon, 0, len(items))
+	for i := range items {
+		if targetNamespace(&items[i]) == namespace {
+			matching = append(matching, items[i])
+		}
+	}
+	return matching
 }
 
 func selectPrimaryInstance(items []reconv1beta1.OvnRecon) *reconv1beta1.OvnRecon {
@@ -509,25 +760,10 @@ func selectPrimaryInstance(items []reconv1beta1.OvnRecon) *reconv1beta1.OvnRecon
 	return &items[0]
 }
 
-func (r *OvnReconReconciler) reconcileDeployment(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
-	namespace := targetNamespace(ovnRecon)
-
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      ovnRecon.Name,
-			Namespace: namespace,
-		},
-	}
-
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		desired := DesiredDeployment(ovnRecon)
-		deployment.Labels = mergeStringMap(deployment.Labels, desired.Labels)
-		deployment.Annotations = mergeStringMap(deployment.Annotations, desired.Annotations)
-		deployment.Spec = desired.Spec
-
-		return nil
-	})
-	return err
+// reconcileDeployment applies the plugin Deployment and reports whether it corrected drift; see
+// applyDeployment.
+func (r *OvnReconReconciler) reconcileDeployment(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
+	return r.applyDeployment(ctx, DesiredDeployment(ovnRecon))
 }
 
 func (r *OvnReconReconciler) reconcileService(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
@@ -550,25 +786,10 @@ func (r *OvnReconReconciler) reconcileService(ctx context.Context, ovnRecon *rec
 	return err
 }
 
-func (r *OvnReconReconciler) reconcileCollectorDeployment(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
-	namespace := targetNamespace(ovnRecon)
-	name := collectorName(ovnRecon)
-
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-		},
-	}
-
-	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, func() error {
-		desired := DesiredCollectorDeployment(ovnRecon)
-		deployment.Labels = mergeStringMap(deployment.Labels, desired.Labels)
-		deployment.Annotations = mergeStringMap(deployment.Annotations, desired.Annotations)
-		deployment.Spec = desired.Spec
-		return nil
-	})
-	return err
+// reconcileCollectorDeployment applies the collector Deployment and reports whether it corrected
+// drift; see applyDeployment.
+func (r *OvnReconReconciler) reconcileCollectorDeployment(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
+	return r.applyDeployment(ctx, DesiredCollectorDeployment(ovnRecon))
 }
 
 func (r *OvnReconReconciler) reconcileCollectorAccessControls(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
@@ -583,6 +804,13 @@ func (r *OvnReconReconciler) reconcileCollectorAccessControls(ctx context.Contex
 	}
 	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, serviceAccount, func() error {
 		serviceAccount.Labels = mergeStringMap(serviceAccount.Labels, labelsForOvnRecon(ovnRecon.Name))
+		// OpenShift adds its own dockercfg secret to every ServiceAccount, so the shared pull
+		// secret is appended rather than replacing the list.
+		for _, secret := range imagePullSecrets(ovnRecon) {
+			if !slices.Contains(serviceAccount.ImagePullSecrets, secret) {
+				serviceAccount.ImagePullSecrets = append(serviceAccount.ImagePullSecrets, secret)
+			}
+		}
 		return nil
 	}); err != nil {
 		return err
@@ -612,7 +840,7 @@ func (r *OvnReconReconciler) reconcileCollectorAccessControls(ctx context.Contex
 		return err
 	}
 
-	probeNamespaces := collectorProbeNamespacesFor(ovnRecon)
+	probeNamespaces := collectorRBACNamespacesFor(ovnRecon)
 	for _, probeNamespace := range probeNamespaces {
 		probeNamespace = strings.TrimSpace(probeNamespace)
 		if probeNamespace == "" {
@@ -677,6 +905,37 @@ func (r *OvnReconReconciler) reconcileCollectorService(ctx context.Context, ovnR
 	return err
 }
 
+func (r *OvnReconReconciler) reconcilePluginEgressNetworkPolicy(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pluginEgressNetworkPolicyName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		desired := DesiredPluginEgressNetworkPolicy(ovnRecon)
+		policy.Labels = mergeStringMap(policy.Labels, desired.Labels)
+		policy.Annotations = mergeStringMap(policy.Annotations, desired.Annotations)
+		policy.Spec = desired.Spec
+		return nil
+	})
+	return err
+}
+
+func (r *OvnReconReconciler) deletePluginEgressNetworkPolicy(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pluginEgressNetworkPolicyName(ovnRecon),
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+	if err := r.Delete(ctx, policy); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (r *OvnReconReconciler) deleteCollectorAccessControls(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
 	namespace := targetNamespace(ovnRecon)
 
@@ -699,7 +958,7 @@ func (r *OvnReconReconciler) deleteCollectorAccessControls(ctx context.Context,
 		return err
 	}
 
-	for _, probeNamespace := range collectorProbeNamespacesFor(ovnRecon) {
+	for _, probeNamespace := range collectorRBACNamespacesFor(ovnRecon) {
 		roleBinding := &rbacv1.RoleBinding{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:      collectorRoleBindingName(ovnRecon),
@@ -716,11 +975,25 @@ func (r *OvnReconReconciler) deleteCollectorAccessControls(ctx context.Context,
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *OvnReconReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Annotation changes are admitted so a collector token rotation request is acted on.
+	ovnReconPredicate := predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&reconv1beta1.OvnRecon{}).
-		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.reconcileRequestsForProbeNamespace)).
+		For(&reconv1beta1.OvnRecon{}, builder.WithPredicates(ovnReconPredicate)).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.reconcileRequestsForProbeNamespace),
+			builder.WithPredicates(ovnReconPredicate)).
+		// Spec edits and deletions of managed Deployments enqueue their OvnRecon so drift is
+		// corrected without waiting for an unrelated change; status updates are ignored.
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(reconcileRequestsForManagedDeployment),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Named("ovnrecon").
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		// Failed reconciles retry with per-OvnRecon exponential backoff and jitter, alongside the
+		// default overall rate limit.
+		WithOptions(controller.Options{
+			RateLimiter: workqueue.NewTypedMaxOfRateLimiter[reconcile.Request](
+				newReconcileBackoff(reconcileBackoffBase, reconcileBackoffMax, reconcileBackoffJitter),
+				&workqueue.TypedBucketRateLimiter[reconcile.Request]{Limiter: rate.NewLimiter(rate.Limit(10), 100)},
+			),
+		}).
 		Complete(r)
 }
 
@@ -746,7 +1019,7 @@ func (r *OvnReconReconciler) reconcileRequestsForProbeNamespace(ctx context.Cont
 		if !collectorFeatureEnabled(ovnRecon) || ovnRecon.DeletionTimestamp != nil {
 			continue
 		}
-		for _, candidate := range collectorProbeNamespacesFor(ovnRecon) {
+		for _, candidate := range collectorRBACNamespacesFor(ovnRecon) {
 			if strings.TrimSpace(candidate) != probeNamespace {
 				continue
 			}
@@ -773,6 +1046,20 @@ func (r *OvnReconReconciler) reconcileRequestsForProbeNamespace(ctx context.Cont
 	return requests
 }
 
+// reconcileRequestsForManagedDeployment maps a Deployment carrying the operator's managed-by and
+// instance labels to its OvnRecon. OvnRecon is cluster-scoped, so the instance label is its name.
+func reconcileRequestsForManagedDeployment(_ context.Context, object client.Object) []reconcile.Request {
+	labels := object.GetLabels()
+	if labels["app.kubernetes.io/managed-by"] != "ovn-recon-operator" {
+		return nil
+	}
+	name := labels["app.kubernetes.io/instance"]
+	if name == "" {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: name}}}
+}
+
 func labelsForOvnRecon(name string) map[string]string {
 	return map[string]string{
 		"app.kubernetes.io/name":       "ovn-recon",
@@ -781,23 +1068,34 @@ func labelsForOvnRecon(name string) map[string]string {
 	}
 }
 
-func labelsForOvnReconWithVersion(name, version string) map[string]string {
-	labels := labelsForOvnRecon(name)
-	if version != "" {
-		// Ensure version is a valid label value (alphanumeric, -, _, .)
-		// If explicit version has invalid chars, we sanitize or drop it.
-		// Detailed regex: (([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?
-		// For simplicity, we'll replace invalid characters with '-'
-		validVersion := sanitizeLabelValue(version)
-		if validVersion != "" {
-			labels["app.kubernetes.io/version"] = validVersion
-		}
+func labelsForOvnReconWithVersion(ovnRecon *reconv1beta1.OvnRecon, version string) map[string]string {
+	labels := labelsForOvnRecon(ovnRecon.Name)
+	if validVersion := versionLabelValue(ovnRecon, version); validVersion != "" {
+		labels["app.kubernetes.io/version"] = validVersion
 	}
 	labels["app.kubernetes.io/component"] = "plugin"
 	labels["app.kubernetes.io/part-of"] = "openshift-console-plugin"
 	return labels
 }
 
+// versionLabelValue returns the app.kubernetes.io/version label for an image tag, or "" when the
+// label should be left off. Under the Omit policy a tag that is not already a valid label value
+// (a digest, say) is dropped instead of being rewritten into something that looks like a version.
+func versionLabelValue(ovnRecon *reconv1beta1.OvnRecon, version string) string {
+	if version == "" {
+		return ""
+	}
+	if ovnRecon.Spec.Operator.VersionLabelPolicy == reconv1beta1.VersionLabelPolicyOmit {
+		if len(validation.IsValidLabelValue(version)) > 0 {
+			return ""
+		}
+		return version
+	}
+	// Detailed regex: (([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])?
+	// For simplicity, we'll replace invalid characters with '-'
+	return sanitizeLabelValue(version)
+}
+
 func sanitizeLabelValue(value string) string {
 	// A simple sanitizer that keeps alphanumeric, '-', '_', '.'
 	// and ensures start/end are alphanumeric.
@@ -847,6 +1145,13 @@ func targetNamespace(ovnRecon *reconv1beta1.OvnRecon) string {
 	return defaultNamespace
 }
 
+func finalizerFor(ovnRecon *reconv1beta1.OvnRecon) string {
+	if name := strings.TrimSpace(ovnRecon.Spec.Operator.FinalizerName); name != "" {
+		return name
+	}
+	return defaultFinalizerName
+}
+
 func collectorName(ovnRecon *reconv1beta1.OvnRecon) string {
 	return ovnRecon.Name + "-collector"
 }
@@ -863,6 +1168,10 @@ func collectorRoleBindingName(ovnRecon *reconv1beta1.OvnRecon) string {
 	return collectorName(ovnRecon)
 }
 
+func pluginEgressNetworkPolicyName(ovnRecon *reconv1beta1.OvnRecon) string {
+	return ovnRecon.Name + "-plugin-egress"
+}
+
 func collectorFeatureEnabled(ovnRecon *reconv1beta1.OvnRecon) bool {
 	if ovnRecon.Spec.Collector.Enabled != nil {
 		return *ovnRecon.Spec.Collector.Enabled
@@ -926,6 +1235,10 @@ func (r *OvnReconReconciler) reconcileConsolePlugin(ctx context.Context, ovnReco
 	})
 	plugin.SetName(ovnRecon.Name)
 
+	if ovnRecon.Spec.ConsolePlugin.ReconcileStrategy == reconv1beta1.ReconcileStrategyCreateOnly {
+		return r.createConsolePluginIfAbsent(ctx, ovnRecon, plugin, operatorAnnotations)
+	}
+
 	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, plugin, func() error {
 		desired := DesiredConsolePlugin(ovnRecon)
 		if spec, ok := desired.Object["spec"]; ok {
@@ -943,6 +1256,29 @@ func (r *OvnReconReconciler) reconcileConsolePlugin(ctx context.Context, ovnReco
 	return err
 }
 
+// createConsolePluginIfAbsent creates the ConsolePlugin from the desired state when it does not
+// exist yet and otherwise leaves the live object untouched.
+func (r *OvnReconReconciler) createConsolePluginIfAbsent(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon, plugin *unstructured.Unstructured, operatorAnnotations map[string]string) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(plugin.GroupVersionKind())
+	err := r.Get(ctx, client.ObjectKey{Name: plugin.GetName()}, existing)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	desired := DesiredConsolePlugin(ovnRecon)
+	if spec, ok := desired.Object["spec"]; ok {
+		plugin.Object["spec"] = spec
+	}
+	if len(operatorAnnotations) > 0 {
+		plugin.SetAnnotations(operatorAnnotations)
+	}
+	return r.Create(ctx, plugin)
+}
+
 func (r *OvnReconReconciler) reconcileConsoleOperator(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
 	console := &unstructured.Unstructured{}
 	console.SetGroupVersionKind(schema.GroupVersionKind{
@@ -1027,21 +1363,69 @@ func (r *OvnReconReconciler) checkDeploymentReady(ctx context.Context, ovnRecon
 	return false, nil
 }
 
+// ensureFinalizer adds the configured finalizer and records it in appliedFinalizerAnnotation.
+// When the configured name changed since the last apply, the previously applied finalizer is
+// replaced so a rename never leaves an orphaned finalizer behind. Objects finalized before the
+// annotation existed carry defaultFinalizerName, which is treated as the applied finalizer. It
+// reports whether the configured finalizer was newly added.
+func (r *OvnReconReconciler) ensureFinalizer(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
+	finalizer := finalizerFor(ovnRecon)
+	applied := ovnRecon.Annotations[appliedFinalizerAnnotation]
+	if applied == "" && controllerutil.ContainsFinalizer(ovnRecon, defaultFinalizerName) {
+		applied = defaultFinalizerName
+	}
+	present := controllerutil.ContainsFinalizer(ovnRecon, finalizer)
+	if present && applied == finalizer {
+		return false, nil
+	}
+	if applied != "" && applied != finalizer {
+		controllerutil.RemoveFinalizer(ovnRecon, applied)
+	}
+	controllerutil.AddFinalizer(ovnRecon, finalizer)
+	if ovnRecon.Annotations == nil {
+		ovnRecon.Annotations = map[string]string{}
+	}
+	ovnRecon.Annotations[appliedFinalizerAnnotation] = finalizer
+	if err := r.Update(ctx, ovnRecon); err != nil {
+		return false, err
+	}
+	return !present, nil
+}
+
+// ownedFinalizers lists the finalizers this operator placed on ovnRecon: the configured one and
+// the one recorded in appliedFinalizerAnnotation.
+func ownedFinalizers(ovnRecon *reconv1beta1.OvnRecon) []string {
+	finalizers := []string{finalizerFor(ovnRecon)}
+	if applied := ovnRecon.Annotations[appliedFinalizerAnnotation]; applied != "" && applied != finalizers[0] {
+		finalizers = append(finalizers, applied)
+	}
+	return finalizers
+}
+
 func (r *OvnReconReconciler) handleDeletion(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (reconcile.Result, error) {
 	log := log.FromContext(ctx)
+	finalizers := ownedFinalizers(ovnRecon)
 
-	if controllerutil.ContainsFinalizer(ovnRecon, finalizerName) {
+	if slices.ContainsFunc(finalizers, func(finalizer string) bool {
+		return controllerutil.ContainsFinalizer(ovnRecon, finalizer)
+	}) {
 		// Delete namespaced resources (no owner refs with cluster-scoped CRs).
 		if err := r.deleteNamespacedResources(ctx, ovnRecon); err != nil {
 			log.Error(err, "Failed to delete namespaced resources")
-			return reconcile.Result{RequeueAfter: time.Second * 10}, err
+			return reconcile.Result{}, err
+		}
+
+		// Delete the target namespace only when this OvnRecon created it.
+		if err := r.deleteOwnedTargetNamespace(ctx, ovnRecon); err != nil {
+			log.Error(err, "Failed to delete target namespace")
+			return reconcile.Result{}, err
 		}
 
 		// Remove plugin from Console operator
 		if ovnRecon.Spec.ConsolePlugin.Enabled {
 			if err := r.removePluginFromConsole(ctx, ovnRecon); err != nil {
 				log.Error(err, "Failed to remove plugin from Console operator")
-				return reconcile.Result{RequeueAfter: time.Second * 10}, err
+				return reconcile.Result{}, err
 			}
 		}
 
@@ -1057,12 +1441,15 @@ func (r *OvnReconReconciler) handleDeletion(ctx context.Context, ovnRecon *recon
 		if err := r.Get(ctx, client.ObjectKey{Name: ovnRecon.Name}, plugin); err == nil {
 			if err := r.Delete(ctx, plugin); err != nil && !errors.IsNotFound(err) {
 				log.Error(err, "Failed to delete ConsolePlugin")
-				return reconcile.Result{RequeueAfter: time.Second * 10}, err
+				return reconcile.Result{}, err
 			}
 		}
 
-		// Remove finalizer
-		controllerutil.RemoveFinalizer(ovnRecon, finalizerName)
+		// Remove only our finalizers, including one applied under an earlier finalizerName;
+		// finalizers owned by other operators stay in place.
+		for _, finalizer := range finalizers {
+			controllerutil.RemoveFinalizer(ovnRecon, finalizer)
+		}
 		if err := r.Update(ctx, ovnRecon); err != nil {
 			return reconcile.Result{}, err
 		}
@@ -1071,13 +1458,54 @@ func (r *OvnReconReconciler) handleDeletion(ctx context.Context, ovnRecon *recon
 	return reconcile.Result{}, nil
 }
 
-func (r *OvnReconReconciler) ensureTargetNamespaceExists(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+// ensureTargetNamespaceExists verifies the target namespace exists. When spec.createTargetNamespace
+// is set and the namespace is missing, it is created with managed labels and an ownership
+// annotation, and the returned bool reports that it was created.
+func (r *OvnReconReconciler) ensureTargetNamespaceExists(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) (bool, error) {
 	ns := &corev1.Namespace{}
 	err := r.Get(ctx, client.ObjectKey{Name: targetNamespace(ovnRecon)}, ns)
 	if err == nil {
+		return false, nil
+	}
+	if !errors.IsNotFound(err) || !ovnRecon.Spec.CreateTargetNamespace {
+		return false, err
+	}
+
+	ns = &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   targetNamespace(ovnRecon),
+			Labels: labelsForOvnRecon(ovnRecon.Name),
+			Annotations: map[string]string{
+				namespaceCreatedByAnnotation: ovnRecon.Name,
+			},
+		},
+	}
+	if err := r.Create(ctx, ns); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// deleteOwnedTargetNamespace removes the target namespace only if it carries this OvnRecon's
+// ownership annotation; pre-existing namespaces are left untouched.
+func (r *OvnReconReconciler) deleteOwnedTargetNamespace(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	ns := &corev1.Namespace{}
+	if err := r.Get(ctx, client.ObjectKey{Name: targetNamespace(ovnRecon)}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if ns.Annotations[namespaceCreatedByAnnotation] != ovnRecon.Name {
 		return nil
 	}
-	return err
+	if err := r.Delete(ctx, ns); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
 }
 
 func (r *OvnReconReconciler) deleteNamespacedResources(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
@@ -1102,12 +1530,19 @@ func (r *OvnReconReconciler) deleteNamespacedResources(ctx context.Context, ovnR
 		return err
 	}
 
+	if err := r.deletePodDisruptionBudget(ctx, ovnRecon, ovnRecon.Name); err != nil {
+		return err
+	}
+
 	if err := r.deleteCollectorResources(ctx, ovnRecon); err != nil {
 		return err
 	}
 	if err := r.deleteCollectorAccessControls(ctx, ovnRecon); err != nil {
 		return err
 	}
+	if err := r.deletePluginEgressNetworkPolicy(ctx, ovnRecon); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -1153,7 +1588,16 @@ func (r *OvnReconReconciler) deleteCollectorResources(ctx context.Context, ovnRe
 		return err
 	}
 
-	return nil
+	if err := r.deleteCollectorAuthSecret(ctx, ovnRecon); err != nil {
+		return err
+	}
+	if err := r.deleteCollectorPodDisruptionBudget(ctx, ovnRecon); err != nil {
+		return err
+	}
+	if err := r.deleteCollectorConfigMap(ctx, ovnRecon); err != nil {
+		return err
+	}
+	return r.deleteCollectorTrustedCABundle(ctx, ovnRecon)
 }
 
 func (r *OvnReconReconciler) removePluginFromConsole(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
@@ -1199,6 +1643,35 @@ func (r *OvnReconReconciler) removePluginFromConsole(ctx context.Context, ovnRec
 	return nil
 }
 
+// updateRole records in status whether ovnRecon is the primary instance. It only writes when the
+// role changes.
+func (r *OvnReconReconciler) updateRole(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon, isPrimary bool) {
+	role := reconv1beta1.RoleSecondary
+	if isPrimary {
+		role = reconv1beta1.RolePrimary
+	}
+	if ovnRecon.Status.Role == role {
+		return
+	}
+	ovnRecon.Status.Role = role
+	if err := r.Status().Update(ctx, ovnRecon); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update status role", "role", role)
+	}
+}
+
+// updateCollectorStatus records the collector endpoint and live node count in status. It only
+// writes when either changes.
+func (r *OvnReconReconciler) updateCollectorStatus(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon, endpoint string, observedNodeCount *int32) {
+	if ovnRecon.Status.CollectorEndpoint == endpoint && ptr.Equal(ovnRecon.Status.ObservedNodeCount, observedNodeCount) {
+		return
+	}
+	ovnRecon.Status.CollectorEndpoint = endpoint
+	ovnRecon.Status.ObservedNodeCount = observedNodeCount
+	if err := r.Status().Update(ctx, ovnRecon); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to update collector status", "collectorEndpoint", endpoint)
+	}
+}
+
 func (r *OvnReconReconciler) updateCondition(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon, conditionType string, status metav1.ConditionStatus, reason, message string) bool {
 	now := metav1.Now()
 	condition := metav1.Condition{
@@ -1214,7 +1687,9 @@ func (r *OvnReconReconciler) updateCondition(ctx context.Context, ovnRecon *reco
 	found := false
 	for i, c := range ovnRecon.Status.Conditions {
 		if c.Type == conditionType {
-			if c.Status == status && c.Reason == reason && c.Message == message && c.ObservedGeneration == ovnRecon.Generation {
+			// A generation bump alone does not rewrite an otherwise identical condition, so
+			// no-op reconciles after spec edits do not cost a status write per condition.
+			if c.Status == status && c.Reason == reason && c.Message == message {
 				return false
 			}
 			if c.Status == status {
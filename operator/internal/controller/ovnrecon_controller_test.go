@@ -21,10 +21,16 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -88,7 +94,7 @@ var _ = Describe("OvnRecon Controller", func() {
 				Expect(k8sClient.Delete(ctx, ns)).To(Succeed())
 			}
 		})
-		It("should return an OpenShift API error on envtest without panicking", func() {
+		It("should report the missing ConsolePlugin API on envtest instead of failing", func() {
 			By("Reconciling the created resource")
 			controllerReconciler := &OvnReconReconciler{
 				Client:   k8sClient,
@@ -99,8 +105,73 @@ var _ = Describe("OvnRecon Controller", func() {
 			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: typeNamespacedName,
 			})
-			Expect(err).To(HaveOccurred())
-			Expect(err.Error()).To(ContainSubstring("ConsolePlugin"))
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := &reconv1beta1.OvnRecon{}
+			Expect(k8sClient.Get(ctx, typeNamespacedName, updated)).To(Succeed())
+			condition := meta.FindStatusCondition(updated.Status.Conditions, "ConsoleAPIUnavailable")
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	Context("When a managed Deployment is edited outside the operator", func() {
+		const resourceName = "drift-resource"
+		const targetNamespace = "ovn-recon-drift-test"
+
+		It("should restore the Deployment without a change to the OvnRecon", func() {
+			By("running the controller in a manager")
+			mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+				Scheme:  k8sClient.Scheme(),
+				Metrics: metricsserver.Options{BindAddress: "0"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect((&OvnReconReconciler{
+				Client:   mgr.GetClient(),
+				Scheme:   mgr.GetScheme(),
+				Recorder: record.NewFakeRecorder(100),
+			}).SetupWithManager(mgr)).To(Succeed())
+			mgrCtx, stopManager := context.WithCancel(ctx)
+			defer stopManager()
+			go func() {
+				defer GinkgoRecover()
+				Expect(mgr.Start(mgrCtx)).To(Succeed())
+			}()
+
+			Expect(k8sClient.Create(ctx, &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{Name: targetNamespace},
+			})).To(Succeed())
+			resource := &reconv1beta1.OvnRecon{
+				ObjectMeta: metav1.ObjectMeta{Name: resourceName},
+				Spec:       reconv1beta1.OvnReconSpec{TargetNamespace: targetNamespace},
+			}
+			Expect(k8sClient.Create(ctx, resource)).To(Succeed())
+			defer func() {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, resource))).To(Succeed())
+			}()
+
+			deploymentKey := types.NamespacedName{Name: resourceName, Namespace: targetNamespace}
+			deployment := &appsv1.Deployment{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, deploymentKey, deployment)
+			}, "20s", "250ms").Should(Succeed())
+
+			By("scaling the Deployment by hand")
+			generation := resource.Generation
+			deployment.Spec.Replicas = ptr.To[int32](3)
+			Expect(k8sClient.Update(ctx, deployment)).To(Succeed())
+
+			Eventually(func() int32 {
+				live := &appsv1.Deployment{}
+				if err := k8sClient.Get(ctx, deploymentKey, live); err != nil || live.Spec.Replicas == nil {
+					return -1
+				}
+				return *live.Spec.Replicas
+			}, "20s", "250ms").Should(Equal(int32(1)))
+
+			current := &reconv1beta1.OvnRecon{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: resourceName}, current)).To(Succeed())
+			Expect(current.Generation).To(Equal(generation))
 		})
 	})
 })
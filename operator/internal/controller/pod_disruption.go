@@ -0,0 +1,80 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+// reconcilePluginPodDisruptionBudget ensures the plugin PodDisruptionBudget exists when enabled
+// and removes it otherwise.
+func (r *OvnReconReconciler) reconcilePluginPodDisruptionBudget(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	if !ovnRecon.Spec.ConsolePlugin.PodDisruptionBudget.Enabled {
+		return r.deletePodDisruptionBudget(ctx, ovnRecon, ovnRecon.Name)
+	}
+	return r.applyPodDisruptionBudget(ctx, DesiredPluginPodDisruptionBudget(ovnRecon))
+}
+
+// reconcileCollectorPodDisruptionBudget ensures the collector PodDisruptionBudget exists when
+// enabled and removes it otherwise.
+func (r *OvnReconReconciler) reconcileCollectorPodDisruptionBudget(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	if !ovnRecon.Spec.Collector.PodDisruptionBudget.Enabled {
+		return r.deleteCollectorPodDisruptionBudget(ctx, ovnRecon)
+	}
+	return r.applyPodDisruptionBudget(ctx, DesiredCollectorPodDisruptionBudget(ovnRecon))
+}
+
+func (r *OvnReconReconciler) applyPodDisruptionBudget(ctx context.Context, desired *policyv1.PodDisruptionBudget) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      desired.Name,
+			Namespace: desired.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, r.Client, pdb, func() error {
+		pdb.Labels = mergeStringMap(pdb.Labels, desired.Labels)
+		pdb.Annotations = mergeStringMap(pdb.Annotations, desired.Annotations)
+		pdb.Spec = desired.Spec
+		return nil
+	})
+	return err
+}
+
+func (r *OvnReconReconciler) deleteCollectorPodDisruptionBudget(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon) error {
+	return r.deletePodDisruptionBudget(ctx, ovnRecon, collectorName(ovnRecon))
+}
+
+func (r *OvnReconReconciler) deletePodDisruptionBudget(ctx context.Context, ovnRecon *reconv1beta1.OvnRecon, name string) error {
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: targetNamespace(ovnRecon),
+		},
+	}
+	if err := r.Delete(ctx, pdb); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
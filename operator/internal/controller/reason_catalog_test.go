@@ -39,23 +39,39 @@ func TestOperatorEventReasonCatalogIsStable(t *testing.T) {
 	})
 
 	expected := []string{
+		"CollectorAuthSecretReconcileFailed",
+		"CollectorConfigMapReconcileFailed",
 		"CollectorDeploymentReconcileFailed",
 		"CollectorFeatureDisabled",
+		"CollectorHealthy",
+		"CollectorPodDisruptionBudgetReconcileFailed",
+		"CollectorRBACIncomplete",
+		"CollectorRBACReady",
 		"CollectorRBACReconcileFailed",
 		"CollectorReady",
 		"CollectorServiceReconcileFailed",
+		"CollectorTrustBundleReconcileFailed",
+		"CollectorUnhealthy",
+		"ConflictingImageConfig",
+		"ConsoleAPIAvailable",
+		"ConsoleAPIUnavailable",
 		"ConsoleOperatorUpdateFailed",
 		"ConsolePluginReady",
 		"ConsolePluginReconcileFailed",
+		"DeploymentDriftCorrected",
 		"DeploymentNotReady",
 		"DeploymentReady",
 		"DeploymentReconcileFailed",
+		"ImageConfigConsistent",
+		"NamespaceCreated",
 		"NamespaceFound",
 		"NamespaceNotFound",
+		"NetworkPolicyReconcileFailed",
 		"NotPrimary",
 		"PluginDisabled",
 		"PluginEnabled",
 		"PluginEnabling",
+		"PodDisruptionBudgetReconcileFailed",
 		"ServiceReady",
 		"ServiceReconcileFailed",
 	}
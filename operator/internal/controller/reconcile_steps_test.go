@@ -0,0 +1,539 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestReconcileStepDisabled(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		Spec: reconv1beta1.OvnReconSpec{
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsoleOperator, " networkPolicy "},
+			},
+		},
+	}
+
+	for _, step := range []string{reconcileStepConsoleOperator, reconcileStepNetworkPolicy} {
+		if !reconcileStepDisabled(ovnRecon, step) {
+			t.Fatalf("expected step %q to be disabled", step)
+		}
+	}
+	if reconcileStepDisabled(ovnRecon, reconcileStepDeployment) {
+		t.Fatalf("expected step %q to be enabled", reconcileStepDeployment)
+	}
+}
+
+func TestReconcileSkipsDisabledSteps(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepDeployment, reconcileStepConsolePlugin},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace)
+
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	deployment := &appsv1.Deployment{}
+	err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon", Namespace: "ovn-recon"}, deployment)
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected disabled deployment step to leave no Deployment, got err=%v", err)
+	}
+
+	service := &corev1.Service{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon", Namespace: "ovn-recon"}, service); err != nil {
+		t.Fatalf("expected enabled service step to create the plugin Service: %v", err)
+	}
+}
+
+func TestReconcileSelectsPrimaryPerTargetNamespace(t *testing.T) {
+	t.Parallel()
+
+	oldest := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newInstance := func(name, namespace string, created time.Time) *reconv1beta1.OvnRecon {
+		return &reconv1beta1.OvnRecon{
+			ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)},
+			Spec: reconv1beta1.OvnReconSpec{
+				TargetNamespace: namespace,
+				Operator: reconv1beta1.OperatorSpec{
+					DisabledSteps: []string{reconcileStepConsolePlugin},
+				},
+			},
+		}
+	}
+	reconciler := newTargetNamespaceTestReconciler(t,
+		newInstance("alpha", "recon-a", oldest),
+		newInstance("beta", "recon-b", oldest.Add(time.Hour)),
+		newInstance("gamma", "recon-a", oldest.Add(2*time.Hour)),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "recon-a"}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "recon-b"}},
+	)
+
+	wantRoles := map[string]string{
+		"alpha": reconv1beta1.RolePrimary,
+		"beta":  reconv1beta1.RolePrimary,
+		"gamma": reconv1beta1.RoleSecondary,
+	}
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: name}}); err != nil {
+			t.Fatalf("Reconcile(%s) failed: %v", name, err)
+		}
+	}
+	for name, want := range wantRoles {
+		updated := &reconv1beta1.OvnRecon{}
+		if err := reconciler.Get(context.Background(), types.NamespacedName{Name: name}, updated); err != nil {
+			t.Fatalf("failed to get OvnRecon %s: %v", name, err)
+		}
+		if updated.Status.Role != want {
+			t.Fatalf("%s role = %q, want %q", name, updated.Status.Role, want)
+		}
+	}
+
+	gamma := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "gamma"}, gamma); err != nil {
+		t.Fatalf("failed to get OvnRecon gamma: %v", err)
+	}
+	condition := meta.FindStatusCondition(gamma.Status.Conditions, "Available")
+	if condition == nil || condition.Reason != "NotPrimary" || !strings.Contains(condition.Message, "alpha") || !strings.Contains(condition.Message, "recon-a") {
+		t.Fatalf("expected gamma Available=False/NotPrimary naming alpha and recon-a, got %#v", condition)
+	}
+
+	for _, ref := range []types.NamespacedName{{Name: "alpha", Namespace: "recon-a"}, {Name: "beta", Namespace: "recon-b"}} {
+		if err := reconciler.Get(context.Background(), ref, &corev1.Service{}); err != nil {
+			t.Fatalf("expected primary %s to reconcile its plugin Service: %v", ref.Name, err)
+		}
+	}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "gamma", Namespace: "recon-a"}, &corev1.Service{}); !errors.IsNotFound(err) {
+		t.Fatalf("expected secondary gamma to reconcile nothing, got err=%v", err)
+	}
+}
+
+func TestReconcileReportsConflictingImageConfig(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Image:           reconv1beta1.ImageSpec{Repository: "quay.io/legacy/ovn-recon"},
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				Image: reconv1beta1.ImageSpec{Repository: "quay.io/new/ovn-recon"},
+			},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace)
+	recorder := record.NewFakeRecorder(20)
+	reconciler.Recorder = recorder
+
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	found := false
+	for len(recorder.Events) > 0 {
+		event := <-recorder.Events
+		if strings.HasPrefix(event, "Normal ConflictingImageConfig") && strings.Contains(event, "consolePlugin.image.repository") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a Normal ConflictingImageConfig event naming consolePlugin.image.repository")
+	}
+
+	updated := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, updated); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, "ImageConfigConflict")
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "ConflictingImageConfig" {
+		t.Fatalf("expected ImageConfigConflict=True/ConflictingImageConfig, got %#v", condition)
+	}
+
+	if conflicts := ConflictingImageFields(&reconv1beta1.OvnRecon{
+		Spec: reconv1beta1.OvnReconSpec{
+			Image:         reconv1beta1.ImageSpec{Repository: "quay.io/same/ovn-recon"},
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{Repository: "quay.io/same/ovn-recon"}},
+		},
+	}); len(conflicts) != 0 {
+		t.Fatalf("expected matching legacy and hierarchical values not to conflict, got %v", conflicts)
+	}
+}
+
+func TestReconcileSetsPrimaryAndSecondaryRole(t *testing.T) {
+	t.Parallel()
+
+	created := metav1.NewTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	primary := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", CreationTimestamp: created},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin},
+			},
+		},
+	}
+	secondary := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon-b", CreationTimestamp: metav1.NewTime(created.Add(time.Hour))},
+		Spec:       reconv1beta1.OvnReconSpec{TargetNamespace: "ovn-recon"},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, primary, secondary, namespace)
+
+	for _, tc := range []struct {
+		name     string
+		wantRole string
+	}{
+		{name: "ovn-recon", wantRole: reconv1beta1.RolePrimary},
+		{name: "ovn-recon-b", wantRole: reconv1beta1.RoleSecondary},
+	} {
+		if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: tc.name}}); err != nil {
+			t.Fatalf("Reconcile %s failed: %v", tc.name, err)
+		}
+		updated := &reconv1beta1.OvnRecon{}
+		if err := reconciler.Get(context.Background(), types.NamespacedName{Name: tc.name}, updated); err != nil {
+			t.Fatalf("failed to get %s: %v", tc.name, err)
+		}
+		if updated.Status.Role != tc.wantRole {
+			t.Fatalf("expected %s role %q, got %q", tc.name, tc.wantRole, updated.Status.Role)
+		}
+	}
+}
+
+func TestReconcileReportsCollectorEndpointInStatus(t *testing.T) {
+	t.Parallel()
+
+	enabled := true
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector:       reconv1beta1.CollectorSpec{Enabled: &enabled},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepConsolePlugin},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace)
+	request := ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}}
+
+	if _, err := reconciler.Reconcile(context.Background(), request); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	updated := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), request.NamespacedName, updated); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	if updated.Status.CollectorEndpoint != "http://ovn-recon-collector.ovn-recon.svc:8090" {
+		t.Fatalf("unexpected collector endpoint %q", updated.Status.CollectorEndpoint)
+	}
+	// The collector Deployment never becomes ready here, so the collector is not queried.
+	if updated.Status.ObservedNodeCount != nil {
+		t.Fatalf("expected no observed node count before the collector is healthy, got %d", *updated.Status.ObservedNodeCount)
+	}
+
+	enabled = false
+	updated.Spec.Collector.Enabled = &enabled
+	if err := reconciler.Update(context.Background(), updated); err != nil {
+		t.Fatalf("failed to disable collector: %v", err)
+	}
+	if _, err := reconciler.Reconcile(context.Background(), request); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+	if err := reconciler.Get(context.Background(), request.NamespacedName, updated); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	if updated.Status.CollectorEndpoint != "" {
+		t.Fatalf("expected collector endpoint cleared once disabled, got %q", updated.Status.CollectorEndpoint)
+	}
+}
+
+func TestUpdateCollectorStatusSkipsUnchangedWrites(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon)
+	count := int32(3)
+	reconciler.updateCollectorStatus(context.Background(), ovnRecon, "http://collector:8090", &count)
+
+	updated := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, updated); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	if updated.Status.ObservedNodeCount == nil || *updated.Status.ObservedNodeCount != 3 {
+		t.Fatalf("expected observed node count 3, got %v", updated.Status.ObservedNodeCount)
+	}
+
+	sameCount := int32(3)
+	resourceVersion := updated.ResourceVersion
+	reconciler.updateCollectorStatus(context.Background(), updated, "http://collector:8090", &sameCount)
+	if updated.ResourceVersion != resourceVersion {
+		t.Fatalf("expected no status write for unchanged collector status")
+	}
+}
+
+func TestReconcileRequeuesToRefreshCollectorStatus(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			Collector:       reconv1beta1.CollectorSpec{Enabled: ptr.To(true)},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepDeployment},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", Namespace: "ovn-recon"},
+		Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](1)},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon, namespace, deployment)
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}})
+	if err != nil {
+		t.Fatalf("reconcile failed: %v", err)
+	}
+	if result.RequeueAfter != collectorStatusRefreshInterval {
+		t.Fatalf("expected requeue after %s to refresh collector status, got %#v", collectorStatusRefreshInterval, result)
+	}
+}
+
+func TestReconcileDegradesWhenConsolePluginAPIIsMissing(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			ConsolePlugin:   reconv1beta1.ConsolePluginSpec{Enabled: true},
+			Operator: reconv1beta1.OperatorSpec{
+				DisabledSteps: []string{reconcileStepDeployment},
+			},
+		},
+	}
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", Namespace: "ovn-recon"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+		Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	// Answer console.openshift.io lookups the way a non-OpenShift API server does.
+	reconciler.Client = fake.NewClientBuilder().
+		WithScheme(reconciler.Scheme).
+		WithObjects(ovnRecon, namespace, deployment).
+		WithStatusSubresource(&reconv1beta1.OvnRecon{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Group == "console.openshift.io" {
+					return &meta.NoKindMatchError{GroupKind: gvk.GroupKind(), SearchedVersions: []string{gvk.Version}}
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+	recorder := record.NewFakeRecorder(20)
+	reconciler.Recorder = recorder
+
+	result, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "ovn-recon"}})
+	if err != nil {
+		t.Fatalf("expected missing ConsolePlugin API not to fail reconcile, got %v", err)
+	}
+	if result.RequeueAfter != consoleAPIRetryInterval {
+		t.Fatalf("expected requeue after %s, got %#v", consoleAPIRetryInterval, result)
+	}
+
+	updated := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(context.Background(), types.NamespacedName{Name: "ovn-recon"}, updated); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, "ConsoleAPIUnavailable")
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != "ConsoleAPIUnavailable" {
+		t.Fatalf("expected ConsoleAPIUnavailable=True, got %#v", condition)
+	}
+	if ready := meta.FindStatusCondition(updated.Status.Conditions, "ConsolePluginReady"); ready == nil || ready.Status != metav1.ConditionFalse {
+		t.Fatalf("expected ConsolePluginReady=False, got %#v", ready)
+	}
+	if available := meta.FindStatusCondition(updated.Status.Conditions, "Available"); available == nil || available.Status != metav1.ConditionTrue {
+		t.Fatalf("expected the plugin Deployment to still report Available, got %#v", available)
+	}
+
+	found := false
+	for len(recorder.Events) > 0 {
+		event := <-recorder.Events
+		if strings.HasPrefix(event, "Warning ConsoleAPIUnavailable") {
+			found = true
+		}
+		if strings.Contains(event, "ConsoleOperatorUpdateFailed") {
+			t.Fatalf("expected console operator step to be skipped, got event %q", event)
+		}
+	}
+	if !found {
+		t.Fatalf("expected a Warning ConsoleAPIUnavailable event")
+	}
+}
+
+func TestReconcileConsolePluginCreateOnlyLeavesManualEdits(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				DisplayName:       "OVN Recon",
+				ReconcileStrategy: reconv1beta1.ReconcileStrategyCreateOnly,
+			},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	reconciler.Scheme.AddKnownTypeWithName(consolePluginGVK(), &unstructured.Unstructured{})
+
+	edited := DesiredConsolePlugin(ovnRecon)
+	if err := unstructured.SetNestedField(edited.Object, "Edited by hand", "spec", "displayName"); err != nil {
+		t.Fatalf("failed to edit ConsolePlugin: %v", err)
+	}
+	reconciler.Client = fake.NewClientBuilder().
+		WithScheme(reconciler.Scheme).
+		WithObjects(edited).
+		Build()
+
+	if err := reconciler.reconcileConsolePlugin(context.Background(), ovnRecon); err != nil {
+		t.Fatalf("reconcile ConsolePlugin failed: %v", err)
+	}
+
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(consolePluginGVK())
+	if err := reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon"}, live); err != nil {
+		t.Fatalf("failed to get ConsolePlugin: %v", err)
+	}
+	if displayName, _, _ := unstructured.NestedString(live.Object, "spec", "displayName"); displayName != "Edited by hand" {
+		t.Fatalf("expected CreateOnly to keep the manual edit, got displayName %q", displayName)
+	}
+
+	// Managed reverts the edit on the next reconcile.
+	ovnRecon.Spec.ConsolePlugin.ReconcileStrategy = reconv1beta1.ReconcileStrategyManaged
+	if err := reconciler.reconcileConsolePlugin(context.Background(), ovnRecon); err != nil {
+		t.Fatalf("reconcile ConsolePlugin failed: %v", err)
+	}
+	if err := reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon"}, live); err != nil {
+		t.Fatalf("failed to get ConsolePlugin: %v", err)
+	}
+	if displayName, _, _ := unstructured.NestedString(live.Object, "spec", "displayName"); displayName != "OVN Recon" {
+		t.Fatalf("expected Managed to restore the desired displayName, got %q", displayName)
+	}
+}
+
+func TestReconcileConsolePluginCreateOnlyCreatesWhenAbsent(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "ovn-recon",
+			ConsolePlugin:   reconv1beta1.ConsolePluginSpec{ReconcileStrategy: reconv1beta1.ReconcileStrategyCreateOnly},
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	reconciler.Scheme.AddKnownTypeWithName(consolePluginGVK(), &unstructured.Unstructured{})
+	reconciler.Client = fake.NewClientBuilder().WithScheme(reconciler.Scheme).Build()
+
+	if err := reconciler.reconcileConsolePlugin(context.Background(), ovnRecon); err != nil {
+		t.Fatalf("reconcile ConsolePlugin failed: %v", err)
+	}
+	live := &unstructured.Unstructured{}
+	live.SetGroupVersionKind(consolePluginGVK())
+	if err := reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon"}, live); err != nil {
+		t.Fatalf("expected CreateOnly to create the missing ConsolePlugin: %v", err)
+	}
+}
+
+func consolePluginGVK() schema.GroupVersionKind {
+	return schema.GroupVersionKind{Group: "console.openshift.io", Version: "v1", Kind: "ConsolePlugin"}
+}
+
+func TestUpdateConditionSkipsStatusWriteForUnchangedCondition(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", Generation: 1},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+	statusWrites := 0
+	reconciler.Client = fake.NewClientBuilder().
+		WithScheme(reconciler.Scheme).
+		WithObjects(ovnRecon).
+		WithStatusSubresource(&reconv1beta1.OvnRecon{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				statusWrites++
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	ctx := context.Background()
+
+	current := &reconv1beta1.OvnRecon{}
+	if err := reconciler.Get(ctx, types.NamespacedName{Name: "ovn-recon"}, current); err != nil {
+		t.Fatalf("failed to get OvnRecon: %v", err)
+	}
+	if !reconciler.updateCondition(ctx, current, "Available", metav1.ConditionTrue, "DeploymentReady", "ready") {
+		t.Fatalf("expected the first condition update to be written")
+	}
+	if reconciler.updateCondition(ctx, current, "Available", metav1.ConditionTrue, "DeploymentReady", "ready") {
+		t.Fatalf("expected an identical condition of the same generation not to be written")
+	}
+	current.Generation = 2
+	if reconciler.updateCondition(ctx, current, "Available", metav1.ConditionTrue, "DeploymentReady", "ready") {
+		t.Fatalf("expected a generation bump alone not to be written")
+	}
+	if statusWrites != 1 {
+		t.Fatalf("expected exactly one status write, got %d", statusWrites)
+	}
+
+	if !reconciler.updateCondition(ctx, current, "Available", metav1.ConditionTrue, "DeploymentReady", "still ready") {
+		t.Fatalf("expected a message change to be written")
+	}
+	if condition := meta.FindStatusCondition(current.Status.Conditions, "Available"); condition == nil || condition.ObservedGeneration != 2 {
+		t.Fatalf("expected a written condition to record the current generation, got %#v", condition)
+	}
+}
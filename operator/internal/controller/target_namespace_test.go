@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func newTargetNamespaceTestReconciler(t *testing.T, objs ...client.Object) *OvnReconReconciler {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	for _, add := range []func(*runtime.Scheme) error{
+		appsv1.AddToScheme,
+		corev1.AddToScheme,
+		networkingv1.AddToScheme,
+		policyv1.AddToScheme,
+		rbacv1.AddToScheme,
+		reconv1beta1.AddToScheme,
+	} {
+		if err := add(scheme); err != nil {
+			t.Fatalf("failed to build scheme: %v", err)
+		}
+	}
+
+	return &OvnReconReconciler{
+		Client: fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(objs...).
+			WithStatusSubresource(&reconv1beta1.OvnRecon{}).
+			Build(),
+		Scheme: scheme,
+	}
+}
+
+func TestEnsureTargetNamespaceCreatesAndCleansUpOwnedNamespace(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ovn-recon",
+			Finalizers: []string{defaultFinalizerName},
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace:       "ovn-recon-created",
+			CreateTargetNamespace: true,
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, ovnRecon)
+
+	created, err := reconciler.ensureTargetNamespaceExists(context.Background(), ovnRecon)
+	if err != nil {
+		t.Fatalf("ensureTargetNamespaceExists failed: %v", err)
+	}
+	if !created {
+		t.Fatalf("expected namespace to be created")
+	}
+
+	ns := &corev1.Namespace{}
+	if err := reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon-created"}, ns); err != nil {
+		t.Fatalf("expected created namespace: %v", err)
+	}
+	if ns.Annotations[namespaceCreatedByAnnotation] != "ovn-recon" {
+		t.Fatalf("expected ownership annotation, got %v", ns.Annotations)
+	}
+	if ns.Labels["app.kubernetes.io/managed-by"] != "ovn-recon-operator" {
+		t.Fatalf("expected managed labels, got %v", ns.Labels)
+	}
+
+	created, err = reconciler.ensureTargetNamespaceExists(context.Background(), ovnRecon)
+	if err != nil || created {
+		t.Fatalf("expected existing namespace to be reused, created=%v err=%v", created, err)
+	}
+
+	if _, err := reconciler.handleDeletion(context.Background(), ovnRecon); err != nil {
+		t.Fatalf("handleDeletion failed: %v", err)
+	}
+	err = reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon-created"}, &corev1.Namespace{})
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected owned namespace to be deleted, got err=%v", err)
+	}
+}
+
+func TestDeletionKeepsPreexistingTargetNamespace(t *testing.T) {
+	t.Parallel()
+
+	preexisting := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}}
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "ovn-recon",
+			Finalizers: []string{defaultFinalizerName},
+		},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace:       "ovn-recon",
+			CreateTargetNamespace: true,
+		},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t, preexisting, ovnRecon)
+
+	created, err := reconciler.ensureTargetNamespaceExists(context.Background(), ovnRecon)
+	if err != nil {
+		t.Fatalf("ensureTargetNamespaceExists failed: %v", err)
+	}
+	if created {
+		t.Fatalf("did not expect pre-existing namespace to be created")
+	}
+
+	if _, err := reconciler.handleDeletion(context.Background(), ovnRecon); err != nil {
+		t.Fatalf("handleDeletion failed: %v", err)
+	}
+	if err := reconciler.Get(context.Background(), client.ObjectKey{Name: "ovn-recon"}, &corev1.Namespace{}); err != nil {
+		t.Fatalf("expected pre-existing namespace to remain: %v", err)
+	}
+}
+
+func TestEnsureTargetNamespaceFailsWhenMissingAndCreationDisabled(t *testing.T) {
+	t.Parallel()
+
+	ovnRecon := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec:       reconv1beta1.OvnReconSpec{TargetNamespace: "missing"},
+	}
+	reconciler := newTargetNamespaceTestReconciler(t)
+
+	created, err := reconciler.ensureTargetNamespaceExists(context.Background(), ovnRecon)
+	if !errors.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+	if created {
+		t.Fatalf("did not expect namespace creation")
+	}
+}
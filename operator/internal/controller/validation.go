@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+var validPullPolicies = []string{
+	string(corev1.PullAlways),
+	string(corev1.PullIfNotPresent),
+	string(corev1.PullNever),
+}
+
+// ValidateOvnRecon checks an OvnRecon for semantic problems the CRD schema does not catch:
+// image fields, the target namespace and pull secret names, the collector probe and RBAC
+// namespaces, and ovn-nbctl arguments.
+// It returns nil when the spec is valid.
+func ValidateOvnRecon(ovnRecon *reconv1beta1.OvnRecon) []error {
+	spec := ovnRecon.Spec
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, validateImage(specPath.Child("image"), spec.Image.Repository, spec.Image.Tag, spec.Image.PullPolicy)...)
+	allErrs = append(allErrs, validateImage(specPath.Child("consolePlugin", "image"), spec.ConsolePlugin.Image.Repository, spec.ConsolePlugin.Image.Tag, spec.ConsolePlugin.Image.PullPolicy)...)
+	allErrs = append(allErrs, validateImage(specPath.Child("collectorImage"), spec.CollectorImage.Repository, spec.CollectorImage.Tag, spec.CollectorImage.PullPolicy)...)
+	allErrs = append(allErrs, validateImage(specPath.Child("collector", "image"), spec.Collector.Image.Repository, spec.Collector.Image.Tag, spec.Collector.Image.PullPolicy)...)
+
+	if spec.TargetNamespace != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.TargetNamespace) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("targetNamespace"), spec.TargetNamespace, msg))
+		}
+	}
+
+	if spec.PullSecretName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.PullSecretName) {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("pullSecretName"), spec.PullSecretName, msg))
+		}
+	}
+
+	allErrs = append(allErrs, validateProbeNamespaces(specPath.Child("collectorProbeNamespaces"), spec.CollectorProbeNamespaces)...)
+	allErrs = append(allErrs, validateProbeNamespaces(specPath.Child("collector", "probeNamespaces"), spec.Collector.ProbeNamespaces)...)
+	allErrs = append(allErrs, validateProbeNamespaces(specPath.Child("collector", "rbacNamespaces"), spec.Collector.RBACNamespaces)...)
+	if len(spec.Collector.RBACNamespaces) != 0 {
+		// Probing a namespace without a RoleBinding there would fail every exec.
+		for _, namespace := range collectorProbeNamespacesFor(ovnRecon) {
+			if !slices.Contains(spec.Collector.RBACNamespaces, namespace) {
+				allErrs = append(allErrs, field.Invalid(specPath.Child("collector", "rbacNamespaces"), spec.Collector.RBACNamespaces, fmt.Sprintf("must include probe namespace %q", namespace)))
+			}
+		}
+	}
+
+	// The collector splits COLLECTOR_NBCTL_EXTRA_ARGS on whitespace, so an argument cannot hold any.
+	for i, arg := range spec.Collector.NbctlArgs {
+		if strings.TrimSpace(arg) == "" || strings.ContainsAny(arg, " \t\n") {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("collector", "nbctlArgs").Index(i), arg, "argument must be non-empty and must not contain whitespace"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(allErrs))
+	for _, err := range allErrs {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// validateImage checks one image block. Every field is optional, but a field that is set must
+// be usable: repositories and tags cannot be blank or contain whitespace.
+func validateImage(path *field.Path, repository, tag, pullPolicy string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if repository != "" && strings.TrimSpace(repository) == "" {
+		allErrs = append(allErrs, field.Required(path.Child("repository"), "repository must not be blank"))
+	} else if strings.ContainsAny(repository, " \t\n") {
+		allErrs = append(allErrs, field.Invalid(path.Child("repository"), repository, "repository must not contain whitespace"))
+	}
+	if strings.ContainsAny(tag, " \t\n") {
+		allErrs = append(allErrs, field.Invalid(path.Child("tag"), tag, "tag must not contain whitespace"))
+	}
+	if pullPolicy != "" {
+		valid := false
+		for _, policy := range validPullPolicies {
+			if pullPolicy == policy {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			allErrs = append(allErrs, field.NotSupported(path.Child("pullPolicy"), pullPolicy, validPullPolicies))
+		}
+	}
+	return allErrs
+}
+
+func validateProbeNamespaces(path *field.Path, namespaces []string) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, namespace := range namespaces {
+		for _, msg := range validation.IsDNS1123Label(namespace) {
+			allErrs = append(allErrs, field.Invalid(path.Index(i), namespace, msg))
+		}
+		if seen[namespace] {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i), namespace))
+		}
+		seen[namespace] = true
+	}
+	return allErrs
+}
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestValidateOvnReconAcceptsDefaultsAndSample(t *testing.T) {
+	for _, cr := range []*reconv1beta1.OvnRecon{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+			Spec: reconv1beta1.OvnReconSpec{
+				TargetNamespace: "ovn-recon",
+				ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+					Image: reconv1beta1.ImageSpec{Repository: "quay.io/dbewley/ovn-recon", Tag: "v0.1.0", PullPolicy: "Always"},
+				},
+				Collector: reconv1beta1.CollectorSpec{
+					ProbeNamespaces: []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+				},
+			},
+		},
+	} {
+		if errs := ValidateOvnRecon(cr); errs != nil {
+			t.Fatalf("expected valid OvnRecon, got %v", errs)
+		}
+	}
+}
+
+func TestValidateOvnReconReportsEveryProblem(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			TargetNamespace: "OVN_Recon",
+			PullSecretName:  "Quay Creds",
+			ConsolePlugin: reconv1beta1.ConsolePluginSpec{
+				Image: reconv1beta1.ImageSpec{Repository: "  ", PullPolicy: "Sometimes"},
+			},
+			Collector: reconv1beta1.CollectorSpec{
+				Image:           reconv1beta1.CollectorImageSpec{Tag: "v1 beta"},
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes", "openshift-ovn-kubernetes", "Bad_NS"},
+				NbctlArgs:       []string{"--db=unix:/var/run/ovn/ovnnb_db.sock", "--timeout 5"},
+			},
+		},
+	}
+
+	errs := ValidateOvnRecon(cr)
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	joined := strings.Join(messages, "\n")
+
+	for _, want := range []string{
+		"spec.targetNamespace",
+		"spec.pullSecretName",
+		"spec.consolePlugin.image.repository",
+		"spec.consolePlugin.image.pullPolicy",
+		"spec.collector.image.tag",
+		"spec.collector.probeNamespaces[1]: Duplicate value",
+		"spec.collector.probeNamespaces[2]",
+		"spec.collector.nbctlArgs[1]",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Fatalf("expected a validation error for %s, got:\n%s", want, joined)
+		}
+	}
+	if len(errs) != 8 {
+		t.Fatalf("expected 8 validation errors, got %d:\n%s", len(errs), joined)
+	}
+}
+
+func TestValidateOvnReconRequiresProbeNamespacesWithinRBACNamespaces(t *testing.T) {
+	cr := &reconv1beta1.OvnRecon{
+		ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"},
+		Spec: reconv1beta1.OvnReconSpec{
+			Collector: reconv1beta1.CollectorSpec{
+				ProbeNamespaces: []string{"openshift-ovn-kubernetes"},
+				RBACNamespaces:  []string{"openshift-ovn-kubernetes", "openshift-frr-k8s"},
+			},
+		},
+	}
+	if errs := ValidateOvnRecon(cr); len(errs) != 0 {
+		t.Fatalf("expected probe namespaces within the RBAC superset to be valid, got %v", errs)
+	}
+
+	cr.Spec.Collector.ProbeNamespaces = []string{"openshift-ovn-kubernetes", "openshift-ovn-ipsec"}
+	errs := ValidateOvnRecon(cr)
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), `spec.collector.rbacNamespaces`) || !strings.Contains(errs[0].Error(), `"openshift-ovn-ipsec"`) {
+		t.Fatalf("expected one error naming the probe namespace outside rbacNamespaces, got %v", errs)
+	}
+}
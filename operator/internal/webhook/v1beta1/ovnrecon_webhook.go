@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+	"github.com/dlbewley/ovn-recon-operator/internal/controller"
+)
+
+var ovnreconlog = logf.Log.WithName("ovnrecon-resource")
+
+// SetupOvnReconWebhookWithManager registers the OvnRecon validating webhook. With
+// rejectImageConflicts set, conflicting legacy and hierarchical image fields are rejected
+// instead of only producing admission warnings.
+func SetupOvnReconWebhookWithManager(mgr ctrl.Manager, rejectImageConflicts bool) error {
+	return ctrl.NewWebhookManagedBy(mgr).For(&reconv1beta1.OvnRecon{}).
+		WithValidator(&OvnReconCustomValidator{RejectImageConflicts: rejectImageConflicts}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-recon-bewley-net-v1beta1-ovnrecon,mutating=false,failurePolicy=fail,sideEffects=None,groups=recon.bewley.net,resources=ovnrecons,verbs=create;update,versions=v1beta1,name=vovnrecon-v1beta1.kb.io,admissionReviewVersions=v1
+
+// OvnReconCustomValidator validates OvnRecon resources on create and update. It runs
+// controller.ValidateOvnRecon, the same checks as `render -validate`, so invalid specs are
+// refused at admission instead of after the fact.
+type OvnReconCustomValidator struct {
+	// RejectImageConflicts turns legacy/hierarchical image conflicts from warnings into errors.
+	RejectImageConflicts bool
+}
+
+var _ webhook.CustomValidator = &OvnReconCustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator.
+func (v *OvnReconCustomValidator) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	ovnRecon, ok := obj.(*reconv1beta1.OvnRecon)
+	if !ok {
+		return nil, fmt.Errorf("expected an OvnRecon object but got %T", obj)
+	}
+	ovnreconlog.V(1).Info("validation for OvnRecon upon creation", "name", ovnRecon.GetName())
+	return v.validate(ovnRecon)
+}
+
+// ValidateUpdate implements webhook.CustomValidator. Updates that leave the spec unchanged and
+// updates to an object being deleted are always allowed, so metadata writes such as finalizer
+// changes succeed on objects admitted before the current checks existed.
+func (v *OvnReconCustomValidator) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldOvnRecon, ok := oldObj.(*reconv1beta1.OvnRecon)
+	if !ok {
+		return nil, fmt.Errorf("expected an OvnRecon object for the oldObj but got %T", oldObj)
+	}
+	ovnRecon, ok := newObj.(*reconv1beta1.OvnRecon)
+	if !ok {
+		return nil, fmt.Errorf("expected an OvnRecon object for the newObj but got %T", newObj)
+	}
+	ovnreconlog.V(1).Info("validation for OvnRecon upon update", "name", ovnRecon.GetName())
+	if ovnRecon.DeletionTimestamp != nil || equality.Semantic.DeepEqual(oldOvnRecon.Spec, ovnRecon.Spec) {
+		return nil, nil
+	}
+	return v.validate(ovnRecon)
+}
+
+// ValidateDelete implements webhook.CustomValidator. Deletes are always allowed.
+func (v *OvnReconCustomValidator) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *OvnReconCustomValidator) validate(ovnRecon *reconv1beta1.OvnRecon) (admission.Warnings, error) {
+	allErrs := field.ErrorList{}
+	for _, err := range controller.ValidateOvnRecon(ovnRecon) {
+		if fieldErr, ok := err.(*field.Error); ok {
+			allErrs = append(allErrs, fieldErr)
+			continue
+		}
+		allErrs = append(allErrs, field.InternalError(field.NewPath("spec"), err))
+	}
+
+	var warnings admission.Warnings
+	for _, conflict := range controller.ConflictingImageFields(ovnRecon) {
+		if v.RejectImageConflicts {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "conflicting legacy and hierarchical image fields: "+conflict))
+			continue
+		}
+		warnings = append(warnings, "conflicting legacy and hierarchical image fields: "+conflict)
+	}
+
+	if len(allErrs) == 0 {
+		return warnings, nil
+	}
+	return warnings, apierrors.NewInvalid(reconv1beta1.GroupVersion.WithKind("OvnRecon").GroupKind(), ovnRecon.Name, allErrs)
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	reconv1beta1 "github.com/dlbewley/ovn-recon-operator/api/v1beta1"
+)
+
+func TestOvnReconValidator(t *testing.T) {
+	tests := []struct {
+		name         string
+		reject       bool
+		spec         reconv1beta1.OvnReconSpec
+		wantWarnings int
+		wantErr      string
+	}{
+		{
+			name: "empty spec is valid",
+		},
+		{
+			name: "matching legacy and hierarchical images are valid",
+			spec: reconv1beta1.OvnReconSpec{
+				Image:         reconv1beta1.ImageSpec{Tag: "v1.0.0"},
+				ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{Tag: "v1.0.0"}},
+			},
+		},
+		{
+			name: "conflicting plugin tag warns",
+			spec: reconv1beta1.OvnReconSpec{
+				Image:         reconv1beta1.ImageSpec{Tag: "v1.0.0"},
+				ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{Tag: "v2.0.0"}},
+			},
+			wantWarnings: 1,
+		},
+		{
+			name:   "conflicting collector repository is rejected with the flag",
+			reject: true,
+			spec: reconv1beta1.OvnReconSpec{
+				CollectorImage: reconv1beta1.LegacyCollectorImageSpec{Repository: "quay.io/a/collector"},
+				Collector:      reconv1beta1.CollectorSpec{Image: reconv1beta1.CollectorImageSpec{Repository: "quay.io/b/collector"}},
+			},
+			wantErr: "collector.image.repository",
+		},
+		{
+			name: "unknown pull policy is rejected",
+			spec: reconv1beta1.OvnReconSpec{
+				ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{PullPolicy: "Sometimes"}},
+			},
+			wantErr: "spec.consolePlugin.image.pullPolicy",
+		},
+		{
+			name: "valid pull policies are accepted",
+			spec: reconv1beta1.OvnReconSpec{
+				Image:     reconv1beta1.ImageSpec{PullPolicy: "Always"},
+				Collector: reconv1beta1.CollectorSpec{Image: reconv1beta1.CollectorImageSpec{PullPolicy: "Never"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &OvnReconCustomValidator{RejectImageConflicts: tt.reject}
+			obj := &reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}, Spec: tt.spec}
+
+			for op, validate := range map[string]func() ([]string, error){
+				"create": func() ([]string, error) { return validator.ValidateCreate(context.Background(), obj) },
+				"update": func() ([]string, error) {
+					return validator.ValidateUpdate(context.Background(), &reconv1beta1.OvnRecon{}, obj)
+				},
+			} {
+				warnings, err := validate()
+				if tt.wantErr == "" {
+					if err != nil {
+						t.Fatalf("%s: unexpected error: %v", op, err)
+					}
+				} else {
+					if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Fatalf("%s: expected Invalid error mentioning %q, got %v", op, tt.wantErr, err)
+					}
+				}
+				if len(warnings) != tt.wantWarnings {
+					t.Fatalf("%s: expected %d warnings, got %v", op, tt.wantWarnings, warnings)
+				}
+			}
+		})
+	}
+}
+
+func TestOvnReconValidatorUpdate(t *testing.T) {
+	invalidSpec := reconv1beta1.OvnReconSpec{
+		ConsolePlugin: reconv1beta1.ConsolePluginSpec{Image: reconv1beta1.ImageSpec{PullPolicy: "Sometimes"}},
+	}
+	deleting := metav1.Now()
+
+	tests := []struct {
+		name    string
+		old     reconv1beta1.OvnRecon
+		new     reconv1beta1.OvnRecon
+		wantErr string
+	}{
+		{
+			name:    "spec change to an invalid spec is rejected",
+			old:     reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}},
+			new:     reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}, Spec: invalidSpec},
+			wantErr: "spec.consolePlugin.image.pullPolicy",
+		},
+		{
+			name: "metadata-only update of an invalid spec is allowed",
+			old:  reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}, Spec: invalidSpec},
+			new: reconv1beta1.OvnRecon{
+				ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", Finalizers: []string{"ovnrecon.bewley.net/finalizer"}},
+				Spec:       invalidSpec,
+			},
+		},
+		{
+			name: "update of a deleting object is allowed",
+			old:  reconv1beta1.OvnRecon{ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon"}},
+			new: reconv1beta1.OvnRecon{
+				ObjectMeta: metav1.ObjectMeta{Name: "ovn-recon", DeletionTimestamp: &deleting},
+				Spec:       invalidSpec,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &OvnReconCustomValidator{}
+			_, err := validator.ValidateUpdate(context.Background(), &tt.old, &tt.new)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !apierrors.IsInvalid(err) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected Invalid error mentioning %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -31,7 +31,7 @@ import {
     AlertGroup,
 } from '@patternfly/react-core';
 
-import { LogicalTopologyEdge, LogicalTopologyNode, LogicalTopologySnapshot } from '../types';
+import { LogicalTopologyEdge, LogicalTopologyNode, LogicalTopologySnapshot, LogicalTopologyWarningSeverity } from '../types';
 import { useOvnCollectorFeatureGate } from './useOvnCollectorFeatureGate';
 import { getLogicalTopologyFixture } from './logicalTopologyFixtures';
 import {
@@ -109,6 +109,17 @@ const getNodeColor = (kind: string): string => {
     if (kind === 'logical_router') return '#0066CC';
     if (kind === 'logical_switch') return '#2B9A66';
     if (kind === 'logical_switch_port') return '#8A5A00';
+    if (kind === 'logical_router_port') return '#004080';
+    if (kind === 'load_balancer') return '#5752D1';
+    if (kind === 'nat') return '#B2352E';
+    if (kind === 'acl') return '#8476D1';
+    if (kind === 'qos') return '#F0AB00';
+    if (kind === 'dhcp_options') return '#009596';
+    if (kind === 'static_route') return '#3E8635';
+    if (kind === 'port_group') return '#C46100';
+    if (kind === 'gateway_chassis') return '#A18FFF';
+    if (kind === 'ha_chassis_group') return '#5752D1';
+    if (kind === 'chassis') return '#4F5255';
     return '#6A6E73';
 };
 
@@ -132,6 +143,12 @@ const freshnessVariant = (state: SnapshotFreshnessState): 'success' | 'warning'
     return 'success';
 };
 
+const warningVariant = (severity?: LogicalTopologyWarningSeverity): 'info' | 'warning' | 'danger' => {
+    if (severity === 'error') return 'danger';
+    if (severity === 'info') return 'info';
+    return 'warning';
+};
+
 const freshnessTitle = (state: SnapshotFreshnessState): string => {
     if (state === 'critical') return 'Snapshot is stale';
     if (state === 'warning') return 'Snapshot age exceeds warning threshold';
@@ -383,7 +400,7 @@ const NodeLogicalTopologyDetails: React.FC = () => {
                                     {snapshot?.warnings?.map((warning) => (
                                         <Alert
                                             key={warning.code}
-                                            variant="warning"
+                                            variant={warningVariant(warning.severity)}
                                             isInline
                                             title={`${warning.code}: ${warning.message}`}
                                         />
@@ -7,7 +7,7 @@ export interface Point {
 
 export type SnapshotFreshnessState = 'fresh' | 'warning' | 'critical' | 'unknown';
 
-const KIND_ORDER = ['logical_router', 'logical_switch', 'logical_switch_port'];
+const KIND_ORDER = ['logical_router', 'logical_switch', 'logical_switch_port', 'logical_router_port', 'load_balancer', 'nat', 'acl', 'qos', 'dhcp_options', 'static_route', 'port_group', 'gateway_chassis', 'ha_chassis_group', 'chassis'];
 
 const STALE_WARNING_MS = 2 * 60 * 1000;
 const STALE_CRITICAL_MS = 10 * 60 * 1000;
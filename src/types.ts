@@ -97,11 +97,17 @@ export interface LogicalTopologyMetadata {
     generatedAt: string;
     sourceHealth: string;
     nodeName: string;
+    clusterID?: string;
+    kindCounts?: Record<string, number>;
+    edgeKindCounts?: Record<string, number>;
 }
 
+export type LogicalTopologyWarningSeverity = 'info' | 'warning' | 'error';
+
 export interface LogicalTopologyWarning {
     code: string;
     message: string;
+    severity?: LogicalTopologyWarningSeverity;
 }
 
 export interface LogicalTopologyNode {